@@ -28,6 +28,7 @@ import (
 	dogstatsdServer "github.com/DataDog/datadog-agent/comp/dogstatsd/server"
 	dogstatsdDebug "github.com/DataDog/datadog-agent/comp/dogstatsd/serverDebug"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers"
 	"github.com/DataDog/datadog-agent/pkg/config"
 	settingshttp "github.com/DataDog/datadog-agent/pkg/config/settings/http"
 	"github.com/DataDog/datadog-agent/pkg/epforwarder"
@@ -63,8 +64,10 @@ func SetupHandlers(r *mux.Router, flareComp flare.Component, server dogstatsdSer
 	r.HandleFunc("/{component}/configs", componentConfigHandler).Methods("GET")
 	r.HandleFunc("/gui/csrf-token", getCSRFToken).Methods("GET")
 	r.HandleFunc("/config-check", getConfigCheck).Methods("GET")
+	r.HandleFunc("/config-check/explain", getConfigCheckExplain).Methods("GET")
 	r.HandleFunc("/config", settingshttp.Server.GetFullDatadogConfig("")).Methods("GET")
 	r.HandleFunc("/config/list-runtime", settingshttp.Server.ListConfigurable).Methods("GET")
+	r.HandleFunc("/config/with-sources", settingshttp.Server.GetFullDatadogConfigWithSources).Methods("GET")
 	r.HandleFunc("/config/{setting}", settingshttp.Server.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", settingshttp.Server.SetValue).Methods("POST")
 	r.HandleFunc("/tagger-list", getTaggerList).Methods("GET")
@@ -360,6 +363,7 @@ func getConfigCheck(w http.ResponseWriter, r *http.Request) {
 	response.ResolveWarnings = autodiscovery.GetResolveWarnings()
 	response.ConfigErrors = autodiscovery.GetConfigErrors()
 	response.Unresolved = common.AC.GetUnresolvedTemplates()
+	response.GitRepositories = providers.GetGitRepositoriesStatus()
 
 	jsonConfig, err := json.Marshal(response)
 	if err != nil {
@@ -370,6 +374,28 @@ func getConfigCheck(w http.ResponseWriter, r *http.Request) {
 	w.Write(jsonConfig)
 }
 
+func getConfigCheckExplain(w http.ResponseWriter, r *http.Request) {
+	if common.AC == nil {
+		log.Errorf("Trying to use /config-check/explain before the agent has been initialized.")
+		setJSONError(w, fmt.Errorf("agent not initialized"), 503)
+		return
+	}
+
+	service := r.URL.Query().Get("service")
+	if service == "" {
+		setJSONError(w, fmt.Errorf("missing service parameter"), 400)
+		return
+	}
+
+	jsonExplain, err := json.Marshal(common.AC.Explain(service))
+	if err != nil {
+		setJSONError(w, log.Errorf("Unable to marshal config check explain response: %s", err), 500)
+		return
+	}
+
+	w.Write(jsonExplain)
+}
+
 func getTaggerList(w http.ResponseWriter, r *http.Request) {
 	// query at the highest cardinality between checks and dogstatsd cardinalities
 	cardinality := collectors.TagCardinality(max(int(tagger.ChecksCardinality), int(tagger.DogstatsdCardinality)))
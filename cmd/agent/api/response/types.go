@@ -7,6 +7,7 @@ package response
 
 import (
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers"
 )
 
 // ConfigCheckResponse holds the config check response
@@ -15,4 +16,5 @@ type ConfigCheckResponse struct {
 	ResolveWarnings map[string][]string             `json:"resolve_warnings"`
 	ConfigErrors    map[string]string               `json:"config_errors"`
 	Unresolved      map[string][]integration.Config `json:"unresolved"`
+	GitRepositories []providers.GitRepositoryStatus `json:"git_repositories,omitempty"`
 }
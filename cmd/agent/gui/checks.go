@@ -110,10 +110,13 @@ func runCheckOnce(w http.ResponseWriter, r *http.Request) {
 		s := checkstats.NewStats(ch)
 
 		t0 := time.Now()
+		meter := checkstats.StartResourceUsageMeter()
 		err := ch.Run()
+		usage := meter.Stop()
 		warnings := ch.GetWarnings()
 		sStats, _ := ch.GetSenderStats()
 		s.Add(time.Since(t0), err, warnings, sStats)
+		s.AddResourceUsage(usage)
 
 		// Without a small delay some of the metrics will not show up
 		time.Sleep(100 * time.Millisecond)
@@ -3,7 +3,7 @@
 // This product includes software developed at Datadog (https://www.datadoghq.com/).
 // Copyright 2016-present Datadog, Inc.
 
-// Package check implements 'agent check'.
+// Package check implements 'agent check' and 'agent check-diff'.
 package check
 
 import (
@@ -24,5 +24,5 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 		}
 	})
 
-	return []*cobra.Command{cmd}
+	return []*cobra.Command{cmd, check.MakeDiffCommand()}
 }
@@ -27,6 +27,9 @@ type cliParams struct {
 	*command.GlobalParams
 
 	verbose bool
+
+	// service is the identifier of the service to explain
+	service string
 }
 
 // Commands returns a slice of subcommands for the 'agent' command.
@@ -50,7 +53,30 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 			)
 		},
 	}
-	configCheckCommand.Flags().BoolVarP(&cliParams.verbose, "verbose", "v", false, "print additional debug info")
+	configCheckCommand.PersistentFlags().BoolVarP(&cliParams.verbose, "verbose", "v", false, "print additional debug info")
+
+	explainCommand := &cobra.Command{
+		Use:   "explain <service>",
+		Short: "Explain why the autodiscovery templates were or weren't scheduled for a service",
+		Long: `Explain why the autodiscovery templates were or weren't scheduled for a service of a running agent.
+
+The service is a container, pod or other autodiscovery service, identified by its service ID, such
+as "docker://<container ID>" or "kubernetes_pod://<pod UID>", or by the ID after the "://" separator.
+For each template, the command prints the matched auto-discovery IDs, the result of the template
+variables resolution and the scheduling decision.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliParams.service = args[0]
+			return fxutil.OneShot(explain,
+				fx.Supply(cliParams),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParamsWithSecrets(globalParams.ConfFilePath),
+					LogParams:    log.LogForOneShot("CORE", "off", true)}),
+				core.Bundle,
+			)
+		},
+	}
+	configCheckCommand.AddCommand(explainCommand)
 
 	return []*cobra.Command{configCheckCommand}
 }
@@ -66,3 +92,17 @@ func run(config config.Component, cliParams *cliParams) error {
 	fmt.Println(b.String())
 	return nil
 }
+
+func explain(config config.Component, cliParams *cliParams) error {
+	explanation, err := getExplanation(cliParams.service)
+	if err != nil {
+		return err
+	}
+
+	var b bytes.Buffer
+	color.Output = &b
+	printExplanation(color.Output, cliParams.service, explanation, cliParams.verbose)
+
+	fmt.Println(b.String())
+	return nil
+}
@@ -25,3 +25,14 @@ func TestCommand(t *testing.T) {
 			require.Equal(t, true, coreParams.ConfigLoadSecrets())
 		})
 }
+
+func TestExplainCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"configcheck", "explain", "docker://abc", "-v"},
+		explain,
+		func(cliParams *cliParams, coreParams core.BundleParams) {
+			require.Equal(t, "docker://abc", cliParams.service)
+			require.Equal(t, true, cliParams.verbose)
+		})
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package configcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/fatih/color"
+
+	"github.com/DataDog/datadog-agent/pkg/api/util"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery"
+	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/flare"
+)
+
+// getExplanation queries the running agent for the explanation of the
+// services matching the given identifier
+func getExplanation(service string) (autodiscovery.ExplainResponse, error) {
+	var explanation autodiscovery.ExplainResponse
+
+	c := util.GetClient(false) // FIX: get certificates right then make this true
+
+	// Set session token
+	if err := util.SetAuthToken(); err != nil {
+		return explanation, err
+	}
+	ipcAddress, err := pkgconfig.GetIPCAddress()
+	if err != nil {
+		return explanation, err
+	}
+
+	explainURL := fmt.Sprintf("https://%v:%v/agent/config-check/explain?service=%s", ipcAddress, pkgconfig.Datadog.GetInt("cmd_port"), url.QueryEscape(service))
+	r, err := util.DoGet(c, explainURL, util.LeaveConnectionOpen)
+	if err != nil {
+		if r != nil && string(r) != "" {
+			return explanation, fmt.Errorf("the agent ran into an error while explaining the configs: %s", string(r))
+		}
+		return explanation, fmt.Errorf("failed to query the agent (running?): %s", err)
+	}
+
+	err = json.Unmarshal(r, &explanation)
+	return explanation, err
+}
+
+// printExplanation prints a human-readable explanation of how autodiscovery
+// handled the services. The templates without matching AD identifiers are
+// only listed in verbose mode.
+func printExplanation(w io.Writer, service string, explanation autodiscovery.ExplainResponse, verbose bool) {
+	if len(explanation.Services) == 0 {
+		fmt.Fprintln(w, fmt.Sprintf("No service matching %s was found. It may not have been discovered yet by the autodiscovery listeners, or it may be excluded by the container exclusion rules.", color.YellowString(service)))
+	}
+
+	for _, svc := range explanation.Services {
+		fmt.Fprintln(w, fmt.Sprintf("=== Service %s ===", color.GreenString(svc.ServiceID)))
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Auto-discovery IDs"), color.CyanString(strings.Join(svc.ADIdentifiers, ", "))))
+
+		unmatched := 0
+		for _, tpl := range svc.Templates {
+			if tpl.Decision == autodiscovery.DecisionNoADIdentifierMatch && !verbose {
+				unmatched++
+				continue
+			}
+			printTemplateExplanation(w, tpl)
+		}
+
+		if unmatched > 0 {
+			fmt.Fprintln(w, fmt.Sprintf("\n%d other templates don't match any of the service's auto-discovery IDs, use --verbose to list them", unmatched))
+		}
+		fmt.Fprintln(w, "")
+	}
+
+	if len(explanation.ProviderErrors) > 0 {
+		fmt.Fprintln(w, fmt.Sprintf("=== Configuration provider %s ===", color.RedString("errors")))
+		for provider, errors := range explanation.ProviderErrors {
+			for resource, messages := range errors {
+				for message := range messages {
+					fmt.Fprintln(w, fmt.Sprintf("%s %s: %s", color.RedString(provider), resource, message))
+				}
+			}
+		}
+	}
+}
+
+func printTemplateExplanation(w io.Writer, tpl autodiscovery.TemplateExplanation) {
+	decision := color.RedString(tpl.Decision)
+	if tpl.Decision == autodiscovery.DecisionScheduled {
+		decision = color.GreenString(tpl.Decision)
+	}
+
+	fmt.Fprintln(w, fmt.Sprintf("\n--- Template %s: %s ---", color.GreenString(tpl.Name), decision))
+	fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Configuration provider"), color.CyanString(tpl.Provider)))
+	fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Configuration source"), color.CyanString(tpl.Source)))
+	fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Auto-discovery IDs"), color.CyanString(strings.Join(tpl.ADIdentifiers, ", "))))
+	if len(tpl.MatchedADIdentifiers) > 0 {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Matched auto-discovery IDs"), color.CyanString(strings.Join(tpl.MatchedADIdentifiers, ", "))))
+	}
+	if tpl.ResolveError != "" {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Resolution error"), color.RedString(tpl.ResolveError)))
+	}
+	if tpl.Resolved != nil {
+		fmt.Fprintln(w, fmt.Sprintf("%s:", color.BlueString("Resolved config")))
+		flare.PrintConfig(w, *tpl.Resolved, "")
+	}
+}
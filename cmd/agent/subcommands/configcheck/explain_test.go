@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package configcheck
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/fatih/color"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+)
+
+func TestPrintExplanation(t *testing.T) {
+	color.NoColor = true
+
+	explanation := autodiscovery.ExplainResponse{
+		Services: []autodiscovery.ServiceExplanation{
+			{
+				ServiceID:     "docker://abc",
+				ADIdentifiers: []string{"redis"},
+				Templates: []autodiscovery.TemplateExplanation{
+					{
+						Name:                 "redisdb",
+						Provider:             "file",
+						ADIdentifiers:        []string{"redis"},
+						MatchedADIdentifiers: []string{"redis"},
+						Resolved:             &integration.Config{Name: "redisdb", Instances: []integration.Data{integration.Data("host: 127.0.0.1\n")}},
+						Decision:             autodiscovery.DecisionScheduled,
+					},
+					{
+						Name:                 "redisdb-port",
+						ADIdentifiers:        []string{"redis"},
+						MatchedADIdentifiers: []string{"redis"},
+						ResolveError:         "no port found",
+						Decision:             autodiscovery.DecisionResolveError,
+					},
+					{
+						Name:                 "nginx",
+						ADIdentifiers:        []string{"nginx"},
+						MatchedADIdentifiers: []string{},
+						Decision:             autodiscovery.DecisionNoADIdentifierMatch,
+					},
+				},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	printExplanation(&b, "abc", explanation, false)
+	out := b.String()
+	assert.Contains(t, out, "=== Service docker://abc ===")
+	assert.Contains(t, out, "--- Template redisdb: scheduled ---")
+	assert.Contains(t, out, "host: 127.0.0.1")
+	assert.Contains(t, out, "--- Template redisdb-port: resolution failed ---")
+	assert.Contains(t, out, "Resolution error: no port found")
+	assert.NotContains(t, out, "Template nginx")
+	assert.Contains(t, out, "1 other templates don't match any of the service's auto-discovery IDs")
+
+	b.Reset()
+	printExplanation(&b, "abc", explanation, true)
+	assert.Contains(t, b.String(), "--- Template nginx: no matching AD identifier ---")
+
+	b.Reset()
+	printExplanation(&b, "def", autodiscovery.ExplainResponse{}, false)
+	assert.Contains(t, b.String(), "No service matching def was found.")
+}
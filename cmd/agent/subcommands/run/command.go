@@ -17,6 +17,7 @@ import (
 	"os/signal"
 	"runtime"
 	"syscall"
+	"time"
 
 	"github.com/spf13/cobra"
 	"go.uber.org/fx"
@@ -64,6 +65,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/netflow"
 	"github.com/DataDog/datadog-agent/pkg/otlp"
 	"github.com/DataDog/datadog-agent/pkg/pidfile"
+	"github.com/DataDog/datadog-agent/pkg/secrets"
 	"github.com/DataDog/datadog-agent/pkg/serializer"
 	"github.com/DataDog/datadog-agent/pkg/snmp/traps"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
@@ -95,6 +97,7 @@ import (
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/embed"
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/net"
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/nvidia/jetson"
+	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/openmetrics"
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/oracle-dbm"
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/sbom"
 	_ "github.com/DataDog/datadog-agent/pkg/collector/corechecks/snmp"
@@ -446,6 +449,13 @@ func startAgent(
 	// create and setup the Autoconfig instance
 	common.LoadComponents(common.MainCtx, pkgconfig.Datadog.GetString("confd_path"))
 
+	// refresh the secrets, to use the ones rotated in the secret backend
+	secrets.StartRefreshRoutine(
+		common.MainCtx,
+		time.Duration(pkgconfig.Datadog.GetInt("secret_refresh_interval"))*time.Second,
+		pkgconfig.Datadog.GetBool("secret_refresh_on_api_key_failure"),
+	)
+
 	// start the cloudfoundry container tagger
 	if pkgconfig.IsFeaturePresent(pkgconfig.CloudFoundry) && !pkgconfig.Datadog.GetBool("cloud_foundry_buildpack") {
 		containerTagger, err := containertagger.NewContainerTagger()
@@ -570,6 +580,9 @@ func startAgent(
 		return err
 	}
 
+	// reload the reloadable settings of datadog.yaml upon SIGHUP or file changes
+	startConfigReload(common.MainCtx, log, server)
+
 	// start dependent services
 	go startDependentServices()
 
@@ -44,6 +44,11 @@ func (s DsdStatsRuntimeSetting) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (s DsdStatsRuntimeSetting) Set(v interface{}) error {
+	return s.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (s DsdStatsRuntimeSetting) SetWithSource(v interface{}, source config.Source) error {
 	var newValue bool
 	var err error
 
@@ -53,6 +58,6 @@ func (s DsdStatsRuntimeSetting) Set(v interface{}) error {
 
 	s.ServerDebug.SetMetricStatsEnabled(newValue)
 
-	config.Datadog.Set("dogstatsd_metrics_stats_enable", newValue)
+	config.Datadog.SetWithSource("dogstatsd_metrics_stats_enable", newValue, source)
 	return nil
 }
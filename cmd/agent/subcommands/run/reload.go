@@ -0,0 +1,97 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package run
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/DataDog/datadog-agent/comp/core/log"
+	dogstatsdServer "github.com/DataDog/datadog-agent/comp/dogstatsd/server"
+	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/logs"
+	"github.com/DataDog/datadog-agent/pkg/metadata/host"
+)
+
+// initReloadableSettings registers the settings of datadog.yaml that can be
+// changed without restarting the agent. The check intervals aren't part of
+// them, as they're set by the check configurations rather than datadog.yaml.
+func initReloadableSettings(server dogstatsdServer.Component) {
+	pkgconfig.RegisterReloadable("host tags", func() error {
+		host.ResetHostTagsCache()
+		return nil
+	}, "tags", "extra_tags", "env")
+	pkgconfig.RegisterReloadable("logs processing rules", logs.ReloadProcessingRules, "logs_config.processing_rules")
+	pkgconfig.RegisterReloadable("dogstatsd mapper profiles", server.ReloadMappingProfiles, "dogstatsd_mapper_profiles")
+	// the check runners read the failure policy options on every run
+	pkgconfig.RegisterReloadable("check failure policy", func() error {
+		return nil
+	}, "check_run_timeout", "check_backoff_failures", "check_max_backoff")
+}
+
+// startConfigReload reloads datadog.yaml upon SIGHUP and, if enabled, when the
+// file changes, until the context is done.
+func startConfigReload(ctx context.Context, log log.Component, server dogstatsdServer.Component) {
+	initReloadableSettings(server)
+
+	onReload := func(result *pkgconfig.ReloadResult, err error) {
+		logReloadResult(log, result, err)
+	}
+
+	// SIGHUP is never received on Windows, where only the file watch can be used
+	sighupCh := make(chan os.Signal, 1)
+	signal.Notify(sighupCh, syscall.SIGHUP)
+	go func() {
+		defer signal.Stop(sighupCh)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sighupCh:
+				log.Infof("Received signal 'hangup', reloading %s", pkgconfig.Datadog.ConfigFileUsed())
+				onReload(pkgconfig.Reload(pkgconfig.Datadog))
+			}
+		}
+	}()
+
+	if pkgconfig.Datadog.GetBool("config_reload.watch_file") {
+		interval := time.Duration(pkgconfig.Datadog.GetInt("config_reload.watch_interval")) * time.Second
+		if interval <= 0 {
+			log.Warnf("Invalid config_reload.watch_interval %v, %s won't be watched", interval, pkgconfig.Datadog.ConfigFileUsed())
+			return
+		}
+		go pkgconfig.WatchConfigFile(ctx, pkgconfig.Datadog, interval, onReload)
+	}
+}
+
+// logReloadResult logs the result of a reload of datadog.yaml
+func logReloadResult(log log.Component, result *pkgconfig.ReloadResult, err error) {
+	if err != nil {
+		log.Errorf("Unable to reload %s: %v", pkgconfig.Datadog.ConfigFileUsed(), err)
+		return
+	}
+	if !result.HasChanges() {
+		log.Infof("Reloaded %s, no setting changed", pkgconfig.Datadog.ConfigFileUsed())
+		return
+	}
+
+	if len(result.Applied) > 0 {
+		log.Infof("Reloaded %s, applied the new value of: %s", pkgconfig.Datadog.ConfigFileUsed(), strings.Join(result.Applied, ", "))
+	}
+	if len(result.RequiresRestart) > 0 {
+		log.Warnf("Settings changed in %s that require a restart of the agent: %s", pkgconfig.Datadog.ConfigFileUsed(), strings.Join(result.RequiresRestart, ", "))
+	}
+	if len(result.OverriddenByEnv) > 0 {
+		log.Warnf("Settings changed in %s that are overridden by environment variables: %s", pkgconfig.Datadog.ConfigFileUsed(), strings.Join(result.OverriddenByEnv, ", "))
+	}
+	for _, reloadErr := range result.Errors {
+		log.Errorf("Unable to apply the reloaded settings of %s: %s", pkgconfig.Datadog.ConfigFileUsed(), reloadErr)
+	}
+}
@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/secretsmanager"
+	"github.com/aws/aws-sdk-go/service/ssm"
+
+	s "github.com/DataDog/datadog-agent/pkg/secrets"
+)
+
+const awsRequestTimeout = 10 * time.Second
+
+// AWSConfig holds the settings used to read secrets from the AWS Systems Manager
+// Parameter Store and Secrets Manager. The region and the credentials are the
+// ones of the default AWS SDK chain.
+type AWSConfig struct {
+	// SSMEndpoint and SecretsManagerEndpoint override the endpoints of the
+	// services, for instance to use VPC endpoints
+	SSMEndpoint            string
+	SecretsManagerEndpoint string
+}
+
+// AWSConfigFromEnv returns the AWS settings set by the AWS_ENDPOINT_URL_SSM and
+// AWS_ENDPOINT_URL_SECRETS_MANAGER environment variables
+func AWSConfigFromEnv() AWSConfig {
+	return AWSConfig{
+		SSMEndpoint:            os.Getenv("AWS_ENDPOINT_URL_SSM"),
+		SecretsManagerEndpoint: os.Getenv("AWS_ENDPOINT_URL_SECRETS_MANAGER"),
+	}
+}
+
+// AWSClient reads secrets from the AWS Systems Manager Parameter Store and
+// Secrets Manager. The secrets read from Secrets Manager are cached, so that the
+// keys of a JSON secret are read with a single request.
+type AWSClient struct {
+	config         AWSConfig
+	session        *session.Session
+	ssm            *ssm.SSM
+	secretsManager *secretsmanager.SecretsManager
+	cache          map[string]awsSecret
+}
+
+// awsSecret is the result of the read of a Secrets Manager secret
+type awsSecret struct {
+	value string
+	err   error
+}
+
+// NewAWSClient returns a new AWSClient
+func NewAWSClient(config AWSConfig) (*AWSClient, error) {
+	sess, err := session.NewSession(aws.NewConfig().WithHTTPClient(&http.Client{Timeout: awsRequestTimeout}))
+	if err != nil {
+		return nil, err
+	}
+
+	return &AWSClient{
+		config:  config,
+		session: sess,
+		cache:   make(map[string]awsSecret),
+	}, nil
+}
+
+// serviceConfig returns the configuration of an AWS service client using the
+// given endpoint, if it's set
+func serviceConfig(endpoint string) *aws.Config {
+	config := aws.NewConfig()
+	if endpoint != "" {
+		config = config.WithEndpoint(endpoint)
+	}
+	return config
+}
+
+// ReadSSMParameter reads a parameter from the Parameter Store, decrypting it if
+// it's a SecureString
+func (c *AWSClient) ReadSSMParameter(name string) s.Secret {
+	if c.ssm == nil {
+		c.ssm = ssm.New(c.session, serviceConfig(c.config.SSMEndpoint))
+	}
+
+	output, err := c.ssm.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return s.Secret{ErrorMsg: err.Error()}
+	}
+	if output.Parameter == nil || output.Parameter.Value == nil {
+		return s.Secret{ErrorMsg: fmt.Sprintf("parameter %s has no value", name)}
+	}
+	return s.Secret{Value: *output.Parameter.Value}
+}
+
+// ReadSecretsManagerSecret reads a secret from Secrets Manager. The id has the
+// format "secret-id" to read the whole secret, or "secret-id#key" to read a key
+// of a secret holding a JSON object.
+func (c *AWSClient) ReadSecretsManagerSecret(id string) s.Secret {
+	secretID, key, hasKey := strings.Cut(id, "#")
+
+	secret, cached := c.cache[secretID]
+	if !cached {
+		secret.value, secret.err = c.getSecretValue(secretID)
+		c.cache[secretID] = secret
+	}
+	if secret.err != nil {
+		return s.Secret{ErrorMsg: secret.err.Error()}
+	}
+
+	if !hasKey {
+		return s.Secret{Value: secret.value}
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(secret.value), &data); err != nil {
+		return s.Secret{ErrorMsg: fmt.Sprintf("secret %s is not a JSON object: %s", secretID, err)}
+	}
+	value, found := data[key]
+	if !found {
+		return s.Secret{ErrorMsg: fmt.Sprintf("key %s not found in secret %s", key, secretID)}
+	}
+	stringValue, ok := value.(string)
+	if !ok {
+		return s.Secret{ErrorMsg: fmt.Sprintf("key %s of secret %s is not a string", key, secretID)}
+	}
+	return s.Secret{Value: stringValue}
+}
+
+// getSecretValue returns the value of a Secrets Manager secret
+func (c *AWSClient) getSecretValue(secretID string) (string, error) {
+	if c.secretsManager == nil {
+		c.secretsManager = secretsmanager.New(c.session, serviceConfig(c.config.SecretsManagerEndpoint))
+	}
+
+	output, err := c.secretsManager.GetSecretValue(&secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+	if err != nil {
+		return "", err
+	}
+	if output.SecretString != nil {
+		return *output.SecretString, nil
+	}
+	// the SDK decodes the base64 encoded binary secrets
+	if output.SecretBinary != nil {
+		return string(output.SecretBinary), nil
+	}
+	return "", fmt.Errorf("secret %s has no value", secretID)
+}
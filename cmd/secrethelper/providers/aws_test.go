@@ -0,0 +1,122 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newAWSServer returns a stand-in for the SSM and Secrets Manager APIs, which
+// are both JSON APIs selected by the X-Amz-Target header
+func newAWSServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		switch r.Header.Get("X-Amz-Target") {
+		case "AmazonSSM.GetParameter":
+			assert.Equal(t, true, body["WithDecryption"])
+			if body["Name"] == "/datadog/api_key" {
+				w.Write([]byte(`{"Parameter":{"Name":"/datadog/api_key","Type":"SecureString","Value":"some_api_key"}}`))
+				return
+			}
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"ParameterNotFound","message":"parameter not found"}`))
+		case "secretsmanager.GetSecretValue":
+			switch body["SecretId"] {
+			case "db":
+				w.Write([]byte(`{"Name":"db","SecretString":"{\"user\":\"datadog\",\"port\":5432}"}`))
+			case "plain":
+				w.Write([]byte(`{"Name":"plain","SecretString":"plain_value"}`))
+			default:
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"secret not found"}`))
+			}
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestAWSReadSecrets(t *testing.T) {
+	t.Setenv("AWS_REGION", "us-east-1")
+	t.Setenv("AWS_ACCESS_KEY_ID", "some_key_id")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "some_secret_key")
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+
+	requests := 0
+	server := newAWSServer(t, &requests)
+	defer server.Close()
+
+	client, err := NewAWSClient(AWSConfig{SSMEndpoint: server.URL, SecretsManagerEndpoint: server.URL})
+	require.NoError(t, err)
+
+	secret := client.ReadSSMParameter("/datadog/api_key")
+	assert.Equal(t, "some_api_key", secret.Value)
+	assert.Empty(t, secret.ErrorMsg)
+
+	secret = client.ReadSSMParameter("/datadog/other")
+	assert.Empty(t, secret.Value)
+	assert.Contains(t, secret.ErrorMsg, "parameter not found")
+
+	tests := []struct {
+		name          string
+		id            string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			name:          "whole secret",
+			id:            "plain",
+			expectedValue: "plain_value",
+		},
+		{
+			name:          "key of a JSON secret",
+			id:            "db#user",
+			expectedValue: "datadog",
+		},
+		{
+			name:          "key does not exist",
+			id:            "db#password",
+			expectedError: "key password not found in secret db",
+		},
+		{
+			name:          "key is not a string",
+			id:            "db#port",
+			expectedError: "key port of secret db is not a string",
+		},
+		{
+			name:          "secret is not a JSON object",
+			id:            "plain#user",
+			expectedError: "secret plain is not a JSON object: invalid character 'p' looking for beginning of value",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := client.ReadSecretsManagerSecret(test.id)
+			assert.Equal(t, test.expectedValue, secret.Value)
+			assert.Equal(t, test.expectedError, secret.ErrorMsg)
+		})
+	}
+
+	secret = client.ReadSecretsManagerSecret("other")
+	assert.Contains(t, secret.ErrorMsg, "secret not found")
+
+	// 2 parameters and 3 secrets, as the secrets are read once per ID
+	assert.Equal(t, 5, requests)
+}
@@ -0,0 +1,24 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"fmt"
+	"os"
+
+	s "github.com/DataDog/datadog-agent/pkg/secrets"
+)
+
+// ReadEnvSecret reads a secret from an environment variable of the secret helper
+func ReadEnvSecret(name string) s.Secret {
+	value, found := os.LookupEnv(name)
+	if !found {
+		return s.Secret{ErrorMsg: fmt.Sprintf("environment variable %s is not set", name)}
+	}
+	return s.Secret{Value: value}
+}
@@ -0,0 +1,26 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadEnvSecret(t *testing.T) {
+	t.Setenv("DD_TEST_SECRET", "some_value")
+
+	secret := ReadEnvSecret("DD_TEST_SECRET")
+	assert.Equal(t, "some_value", secret.Value)
+	assert.Empty(t, secret.ErrorMsg)
+
+	secret = ReadEnvSecret("DD_TEST_SECRET_NOT_SET")
+	assert.Empty(t, secret.Value)
+	assert.Equal(t, "environment variable DD_TEST_SECRET_NOT_SET is not set", secret.ErrorMsg)
+}
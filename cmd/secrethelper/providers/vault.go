@@ -0,0 +1,225 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	s "github.com/DataDog/datadog-agent/pkg/secrets"
+)
+
+const (
+	vaultRequestTimeout  = 10 * time.Second
+	vaultMaxResponseSize = 1024 * 1024
+	vaultDefaultK8sMount = "kubernetes"
+	vaultK8sTokenPath    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// VaultConfig holds the settings used to read secrets from a HashiCorp Vault
+// server, through its HTTP API
+type VaultConfig struct {
+	// Address is the URL of the Vault server
+	Address string
+	// Token authenticates the requests. If it's not set, the secret helper logs
+	// in with the Kubernetes auth method, using K8sRole.
+	Token string
+	// Namespace is the Vault Enterprise namespace of the secrets
+	Namespace string
+	// K8sRole and K8sMount are the role and the mount path of the Kubernetes
+	// auth method
+	K8sRole  string
+	K8sMount string
+	// K8sTokenPath is the path to the service account token used to log in
+	// with the Kubernetes auth method
+	K8sTokenPath string
+}
+
+// VaultConfigFromEnv returns the Vault settings set by the VAULT_ADDR,
+// VAULT_TOKEN, VAULT_NAMESPACE, VAULT_K8S_ROLE and VAULT_K8S_MOUNT environment
+// variables
+func VaultConfigFromEnv() VaultConfig {
+	return VaultConfig{
+		Address:      os.Getenv("VAULT_ADDR"),
+		Token:        os.Getenv("VAULT_TOKEN"),
+		Namespace:    os.Getenv("VAULT_NAMESPACE"),
+		K8sRole:      os.Getenv("VAULT_K8S_ROLE"),
+		K8sMount:     os.Getenv("VAULT_K8S_MOUNT"),
+		K8sTokenPath: vaultK8sTokenPath,
+	}
+}
+
+// VaultClient reads secrets from a Vault server. The secrets read are cached,
+// so that the keys of a secret are read with a single request.
+type VaultClient struct {
+	config VaultConfig
+	client *http.Client
+	token  string
+	cache  map[string]vaultSecret
+}
+
+// vaultSecret is the result of the read of a secret
+type vaultSecret struct {
+	data map[string]interface{}
+	err  error
+}
+
+// NewVaultClient returns a new VaultClient. The client logs in lazily, when the
+// first secret is read.
+func NewVaultClient(config VaultConfig) *VaultClient {
+	if config.K8sMount == "" {
+		config.K8sMount = vaultDefaultK8sMount
+	}
+	return &VaultClient{
+		config: config,
+		client: &http.Client{Timeout: vaultRequestTimeout},
+		token:  config.Token,
+		cache:  make(map[string]vaultSecret),
+	}
+}
+
+// ReadSecret reads a key of a Vault secret. The path has the format
+// "path/to/secret#key", such as "secret/data/app#password" with the KV v2
+// secrets engine or "secret/app#password" with KV v1.
+func (c *VaultClient) ReadSecret(path string) s.Secret {
+	secretPath, key, found := strings.Cut(path, "#")
+	if !found || secretPath == "" || key == "" {
+		return s.Secret{ErrorMsg: "invalid format. Use: \"path/to/secret#key\""}
+	}
+
+	secret, cached := c.cache[secretPath]
+	if !cached {
+		secret.data, secret.err = c.readPath(secretPath)
+		c.cache[secretPath] = secret
+	}
+	if secret.err != nil {
+		return s.Secret{ErrorMsg: secret.err.Error()}
+	}
+
+	value, found := secret.data[key]
+	if !found {
+		return s.Secret{ErrorMsg: fmt.Sprintf("key %s not found in secret %s", key, secretPath)}
+	}
+	stringValue, ok := value.(string)
+	if !ok {
+		return s.Secret{ErrorMsg: fmt.Sprintf("key %s of secret %s is not a string", key, secretPath)}
+	}
+	return s.Secret{Value: stringValue}
+}
+
+// readPath returns the data of the secret at the given path
+func (c *VaultClient) readPath(path string) (map[string]interface{}, error) {
+	if c.config.Address == "" {
+		return nil, errors.New("the address of the Vault server is not set")
+	}
+
+	if c.token == "" {
+		token, err := c.loginWithKubernetes()
+		if err != nil {
+			return nil, fmt.Errorf("unable to log in to Vault: %s", err)
+		}
+		c.token = token
+	}
+
+	var response struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := c.do(http.MethodGet, "/v1/"+strings.TrimPrefix(path, "/"), nil, &response); err != nil {
+		return nil, err
+	}
+
+	// the KV v2 secrets engine nests the data of the secrets along with their
+	// metadata
+	if nested, ok := response.Data["data"].(map[string]interface{}); ok {
+		if _, ok := response.Data["metadata"]; ok {
+			return nested, nil
+		}
+	}
+	return response.Data, nil
+}
+
+// loginWithKubernetes logs in to Vault with the Kubernetes auth method and
+// returns the token to use
+func (c *VaultClient) loginWithKubernetes() (string, error) {
+	if c.config.K8sRole == "" {
+		return "", errors.New("neither a token nor a Kubernetes role is set")
+	}
+
+	jwt, err := os.ReadFile(c.config.K8sTokenPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read the service account token: %s", err)
+	}
+
+	body, err := json.Marshal(map[string]string{
+		"role": c.config.K8sRole,
+		"jwt":  strings.TrimSpace(string(jwt)),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Auth map[string]interface{} `json:"auth"`
+	}
+	if err := c.do(http.MethodPost, "/v1/auth/"+c.config.K8sMount+"/login", body, &response); err != nil {
+		return "", err
+	}
+
+	token, ok := response.Auth["client_token"].(string)
+	if !ok || token == "" {
+		return "", errors.New("no token in the login response")
+	}
+	return token, nil
+}
+
+// do sends a request to the Vault server and decodes its JSON response
+func (c *VaultClient) do(method string, path string, body []byte, response interface{}) error {
+	req, err := http.NewRequest(method, strings.TrimSuffix(c.config.Address, "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		req.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, vaultMaxResponseSize))
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		if json.Unmarshal(content, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			return fmt.Errorf("vault returned status %d: %s", resp.StatusCode, strings.Join(vaultErr.Errors, ", "))
+		}
+		return fmt.Errorf("vault returned status %d", resp.StatusCode)
+	}
+
+	if err := json.Unmarshal(content, response); err != nil {
+		return fmt.Errorf("unable to decode the vault response: %s", err)
+	}
+	return nil
+}
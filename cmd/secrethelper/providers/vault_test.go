@@ -0,0 +1,134 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package providers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newVaultServer returns a stand-in for a Vault server, serving a KV v1 secret
+// at "kv/app", a KV v2 secret at "secret/data/app" and accepting Kubernetes
+// logins with the "agent" role
+func newVaultServer(t *testing.T, requests *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+
+		if r.URL.Path == "/v1/auth/kubernetes/login" {
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			if body["role"] != "agent" || body["jwt"] != "some_jwt" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"errors":["permission denied"]}`))
+				return
+			}
+			w.Write([]byte(`{"auth":{"client_token":"k8s_token"}}`))
+			return
+		}
+
+		if token := r.Header.Get("X-Vault-Token"); token != "some_token" && token != "k8s_token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/app":
+			w.Write([]byte(`{"data":{"password":"v1_password","port":5432}}`))
+		case "/v1/secret/data/app":
+			w.Write([]byte(`{"data":{"data":{"password":"v2_password"},"metadata":{"version":3}}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+}
+
+func TestVaultReadSecret(t *testing.T) {
+	requests := 0
+	server := newVaultServer(t, &requests)
+	defer server.Close()
+
+	tests := []struct {
+		name          string
+		path          string
+		expectedValue string
+		expectedError string
+	}{
+		{
+			name:          "invalid path format",
+			path:          "kv/app",
+			expectedError: "invalid format. Use: \"path/to/secret#key\"",
+		},
+		{
+			name:          "KV v1 secret",
+			path:          "kv/app#password",
+			expectedValue: "v1_password",
+		},
+		{
+			name:          "KV v2 secret",
+			path:          "secret/data/app#password",
+			expectedValue: "v2_password",
+		},
+		{
+			name:          "key does not exist",
+			path:          "kv/app#user",
+			expectedError: "key user not found in secret kv/app",
+		},
+		{
+			name:          "key is not a string",
+			path:          "kv/app#port",
+			expectedError: "key port of secret kv/app is not a string",
+		},
+		{
+			name:          "secret does not exist",
+			path:          "kv/other#password",
+			expectedError: "vault returned status 404",
+		},
+	}
+
+	client := NewVaultClient(VaultConfig{Address: server.URL, Token: "some_token"})
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			secret := client.ReadSecret(test.path)
+			assert.Equal(t, test.expectedValue, secret.Value)
+			assert.Equal(t, test.expectedError, secret.ErrorMsg)
+		})
+	}
+
+	// the secrets are read once per path
+	assert.Equal(t, 3, requests)
+}
+
+func TestVaultKubernetesLogin(t *testing.T) {
+	requests := 0
+	server := newVaultServer(t, &requests)
+	defer server.Close()
+
+	tokenPath := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenPath, []byte("some_jwt\n"), 0600))
+
+	client := NewVaultClient(VaultConfig{Address: server.URL, K8sRole: "agent", K8sTokenPath: tokenPath})
+	assert.Equal(t, "v1_password", client.ReadSecret("kv/app#password").Value)
+	assert.Equal(t, "v2_password", client.ReadSecret("secret/data/app#password").Value)
+	// a single login
+	assert.Equal(t, 3, requests)
+
+	client = NewVaultClient(VaultConfig{Address: server.URL, K8sRole: "other", K8sTokenPath: tokenPath})
+	assert.Equal(t, "unable to log in to Vault: vault returned status 403: permission denied", client.ReadSecret("kv/app#password").ErrorMsg)
+
+	client = NewVaultClient(VaultConfig{Address: server.URL})
+	assert.Equal(t, "unable to log in to Vault: neither a token nor a Kubernetes role is set", client.ReadSecret("kv/app#password").ErrorMsg)
+}
@@ -13,10 +13,22 @@
 //
 // 1) With the "--with-provider-prefixes" option enabled. Each input secret
 // should follow this format: "providerPrefix/some/path". The provider prefix
-// indicates where to fetch the secrets from. At the moment, we support "file"
-// and "k8s_secret". The path can mean different things depending on the
-// provider. In "file" it's a file system path. In "k8s_secret", it follows this
-// format: "namespace/name/key".
+// indicates where to fetch the secrets from. At the moment, we support "file",
+// "k8s_secret", "env", "vault", "aws_ssm" and "aws_secrets". The path can mean
+// different things depending on the provider:
+//   - In "file" it's a file system path.
+//   - In "k8s_secret", it follows this format: "namespace/name/key".
+//   - In "env" it's the name of an environment variable.
+//   - In "vault", it follows this format: "path/to/secret#key". The Vault
+//     server and the authentication are set with the VAULT_ADDR, VAULT_TOKEN,
+//     VAULT_NAMESPACE, VAULT_K8S_ROLE and VAULT_K8S_MOUNT environment variables.
+//   - In "aws_ssm" it's the name of a Parameter Store parameter.
+//   - In "aws_secrets" it's the ID of a Secrets Manager secret, optionally
+//     followed by "#key" to read a key of a JSON secret. The AWS region and
+//     credentials are the ones of the default AWS SDK chain.
+//
+// The clients of the remote providers are created once per call, and the
+// secrets they read are cached for the duration of the call.
 //
 // 2) Without the "--with-provider-prefixes" option. The program expects a root
 // path in the arguments and input secrets are just paths relative to the root
@@ -51,6 +63,10 @@ const (
 	providerPrefixSeparator = "@"
 	filePrefix              = "file"
 	k8sSecretPrefix         = "k8s_secret"
+	envPrefix               = "env"
+	vaultPrefix             = "vault"
+	awsSSMPrefix            = "aws_ssm"
+	awsSecretsPrefix        = "aws_secrets"
 )
 
 // NewKubeClient TODO <agent-core>
@@ -79,7 +95,7 @@ func Commands() []*cobra.Command {
 			)
 		},
 	}
-	cmd.PersistentFlags().BoolVarP(&cliParams.usePrefixes, providerPrefixesFlag, "", false, "Use prefixes to select the secrets provider (file, k8s_secret)")
+	cmd.PersistentFlags().BoolVarP(&cliParams.usePrefixes, providerPrefixesFlag, "", false, "Use prefixes to select the secrets provider (file, k8s_secret, env, vault, aws_ssm, aws_secrets)")
 
 	secretHelperCmd := &cobra.Command{
 		Use:   "secret-helper",
@@ -166,6 +182,18 @@ func readSecretsFromFile(secrets []string, dir string) map[string]s.Secret {
 func readSecretsUsingPrefixes(secrets []string, rootPath string, newKubeClientFunc NewKubeClient) map[string]s.Secret {
 	res := make(map[string]s.Secret)
 
+	// the clients of the remote providers are created the first time they're
+	// needed, and shared by all the secrets of the request
+	var vaultClient *providers.VaultClient
+	var awsClient *providers.AWSClient
+	var awsClientErr error
+	getAWSClient := func() (*providers.AWSClient, error) {
+		if awsClient == nil && awsClientErr == nil {
+			awsClient, awsClientErr = providers.NewAWSClient(providers.AWSConfigFromEnv())
+		}
+		return awsClient, awsClientErr
+	}
+
 	for _, secretID := range secrets {
 		prefix, id, err := parseSecretWithPrefix(secretID, rootPath)
 		if err != nil {
@@ -183,6 +211,27 @@ func readSecretsUsingPrefixes(secrets []string, rootPath string, newKubeClientFu
 			} else {
 				res[secretID] = providers.ReadKubernetesSecret(kubeClient, id)
 			}
+		case envPrefix:
+			res[secretID] = providers.ReadEnvSecret(id)
+		case vaultPrefix:
+			if vaultClient == nil {
+				vaultClient = providers.NewVaultClient(providers.VaultConfigFromEnv())
+			}
+			res[secretID] = vaultClient.ReadSecret(id)
+		case awsSSMPrefix:
+			client, err := getAWSClient()
+			if err != nil {
+				res[secretID] = s.Secret{Value: "", ErrorMsg: err.Error()}
+			} else {
+				res[secretID] = client.ReadSSMParameter(id)
+			}
+		case awsSecretsPrefix:
+			client, err := getAWSClient()
+			if err != nil {
+				res[secretID] = s.Secret{Value: "", ErrorMsg: err.Error()}
+			} else {
+				res[secretID] = client.ReadSecretsManagerSecret(id)
+			}
 		default:
 			res[secretID] = s.Secret{Value: "", ErrorMsg: fmt.Sprintf("provider not supported: %s", prefix)}
 		}
@@ -116,6 +116,29 @@ func TestReadSecrets(t *testing.T) {
 			}`, secretAbsPath("secret1"), secretAbsPath("secret2")),
 			usePrefixes: true,
 		},
+		{
+			name: "valid input, reading from env provider",
+			in: `
+			{
+				"version": "1.0",
+				"secrets": [
+					"env@DD_TEST_SECRET",
+					"env@DD_TEST_SECRET_NOT_SET"
+				]
+			}
+			`,
+			out: `
+			{
+				"env@DD_TEST_SECRET": {
+					"value": "some_env_value"
+				},
+				"env@DD_TEST_SECRET_NOT_SET": {
+					"error": "environment variable DD_TEST_SECRET_NOT_SET is not set"
+				}
+			}
+			`,
+			usePrefixes: true,
+		},
 		{
 			name: "prefixes option enabled, but using old format",
 			in: `
@@ -156,6 +179,8 @@ func TestReadSecrets(t *testing.T) {
 		},
 	}
 
+	t.Setenv("DD_TEST_SECRET", "some_env_value")
+
 	path := filepath.Join("testdata", "read-secrets")
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+	"github.com/DataDog/datadog-agent/pkg/trace/replay"
+)
+
+// startCapture asks the running trace-agent to capture incoming payloads for d.
+func startCapture(cfg *config.AgentConfig, d time.Duration) error {
+	url := fmt.Sprintf("http://127.0.0.1:%d/debug/capture?duration=%s", cfg.DebugServerPort, d)
+	client := http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(url, "", nil)
+	if err != nil {
+		return fmt.Errorf("could not reach the trace-agent debug server (is the trace-agent running?): %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("could not start capture: %s", msg)
+	}
+	var status replay.Status
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil {
+		return err
+	}
+	fmt.Printf("Capturing trace payloads to %s until %s\n", status.Path, status.Until.Format(time.RFC3339))
+	return nil
+}
+
+// replayCapture replays the capture file at path against the running trace-agent.
+func replayCapture(ctx context.Context, cfg *config.AgentConfig, path string, speed float64, target string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	r, err := replay.NewReader(f)
+	if err != nil {
+		return fmt.Errorf("%s: %v", path, err)
+	}
+	if target == "" {
+		host := cfg.ReceiverHost
+		if host == "" || host == "0.0.0.0" {
+			host = "localhost"
+		}
+		target = "http://" + net.JoinHostPort(host, strconv.Itoa(cfg.ReceiverPort))
+	}
+	rp := &replay.Replayer{
+		Target: target,
+		Speed:  speed,
+		Client: &http.Client{Timeout: 10 * time.Second},
+	}
+	start := time.Now()
+	n, err := rp.Replay(ctx, r)
+	fmt.Printf("Replayed %d requests to %s in %s\n", n, target, time.Since(start).Round(time.Millisecond))
+	return err
+}
@@ -15,6 +15,8 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
+	"path/filepath"
 	"regexp"
 	"strconv"
 	"strings"
@@ -262,6 +264,15 @@ func applyDatadogConfig(c *config.AgentConfig) error {
 		}
 	}
 
+	if k := "apm_config.span_metrics"; coreconfig.Datadog.IsSet(k) {
+		rules := make([]*config.SpanMetricRule, 0)
+		if err := coreconfig.Datadog.UnmarshalKey(k, &rules); err != nil {
+			log.Errorf("Bad format for %q it should be of the form '[{\"name\": \"metric_name\",\"service\":\"service\",\"operation\":\"operation\",\"match_tags\":{\"tag\":\"value\"},\"tag_keys\":[\"tag\"],\"include_resource\":false}]', error: %v", k, err)
+		} else {
+			c.SpanMetrics = rules
+		}
+	}
+
 	if coreconfig.Datadog.IsSet("bind_host") || coreconfig.Datadog.IsSet("apm_config.apm_non_local_traffic") {
 		if coreconfig.Datadog.IsSet("bind_host") {
 			host := coreconfig.Datadog.GetString("bind_host")
@@ -344,6 +355,18 @@ func applyDatadogConfig(c *config.AgentConfig) error {
 		if coreconfig.Datadog.IsSet("apm_config.obfuscation.memcached.enabled") {
 			c.Obfuscation.Memcached.Enabled = coreconfig.Datadog.GetBool("apm_config.obfuscation.memcached.enabled")
 		}
+		if coreconfig.Datadog.IsSet("apm_config.obfuscation.cassandra.enabled") {
+			c.Obfuscation.Cassandra.Enabled = coreconfig.Datadog.GetBool("apm_config.obfuscation.cassandra.enabled")
+		}
+		if coreconfig.Datadog.IsSet("apm_config.obfuscation.dynamodb.enabled") {
+			c.Obfuscation.DynamoDB.Enabled = coreconfig.Datadog.GetBool("apm_config.obfuscation.dynamodb.enabled")
+		}
+		if coreconfig.Datadog.IsSet("apm_config.obfuscation.dynamodb.keep_values") {
+			c.Obfuscation.DynamoDB.KeepValues = coreconfig.Datadog.GetStringSlice("apm_config.obfuscation.dynamodb.keep_values")
+		}
+		if coreconfig.Datadog.IsSet("apm_config.obfuscation.dynamodb.obfuscate_sql_values") {
+			c.Obfuscation.DynamoDB.ObfuscateSQLValues = coreconfig.Datadog.GetStringSlice("apm_config.obfuscation.dynamodb.obfuscate_sql_values")
+		}
 		if coreconfig.Datadog.IsSet("apm_config.obfuscation.mongodb.enabled") {
 			c.Obfuscation.Mongo.Enabled = coreconfig.Datadog.GetBool("apm_config.obfuscation.mongodb.enabled")
 		}
@@ -380,6 +403,17 @@ func applyDatadogConfig(c *config.AgentConfig) error {
 		if coreconfig.Datadog.IsSet("apm_config.obfuscation.sql_exec_plan_normalize.obfuscate_sql_values") {
 			c.Obfuscation.SQLExecPlanNormalize.ObfuscateSQLValues = coreconfig.Datadog.GetStringSlice("apm_config.obfuscation.sql_exec_plan_normalize.obfuscate_sql_values")
 		}
+		if k := "apm_config.obfuscation.scrub_rules"; coreconfig.Datadog.IsSet(k) {
+			rules := make([]*config.ScrubRule, 0)
+			if err := coreconfig.Datadog.UnmarshalKey(k, &rules); err != nil {
+				log.Errorf("Bad format for %q it should be of the form '[{\"name\": \"rule_name\",\"keys\":[\"*.password\"],\"pattern\":\"pattern\",\"repl\":\"replace_str\"}]', error: %v", k, err)
+			} else {
+				if err := compileScrubRules(rules); err != nil {
+					osutil.Exitf("scrub_rules: %s", err)
+				}
+				c.Obfuscation.ScrubRules = rules
+			}
+		}
 	}
 
 	if coreconfig.Datadog.IsSet("apm_config.filter_tags.require") {
@@ -513,6 +547,10 @@ func applyDatadogConfig(c *config.AgentConfig) error {
 		c.EVPProxy.MaxPayloadSize = coreconfig.Datadog.GetInt64(k)
 	}
 	c.DebugServerPort = coreconfig.Datadog.GetInt("apm_config.debug.port")
+	c.CaptureDir = coreconfig.Datadog.GetString("apm_config.capture_path")
+	if c.CaptureDir == "" {
+		c.CaptureDir = filepath.Join(coreconfig.Datadog.GetString("run_path"), "apm_capture")
+	}
 	return nil
 }
 
@@ -578,6 +616,50 @@ func compileReplaceRules(rules []*config.ReplaceRule) error {
 	return nil
 }
 
+// scrubBuiltinPatterns holds the regular expressions of the builtin scrub rule value detectors.
+// The "credit_card" detector does not use a regular expression.
+var scrubBuiltinPatterns = map[string]string{
+	"email": `[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`,
+	"jwt":   `eyJ[a-zA-Z0-9_\-]+\.eyJ[a-zA-Z0-9_\-]+\.[a-zA-Z0-9_\-]*`,
+}
+
+func compileScrubRules(rules []*config.ScrubRule) error {
+	for i, r := range rules {
+		if r.Name == "" {
+			r.Name = fmt.Sprintf("rule_%d", i)
+		}
+		if r.Repl == "" {
+			r.Repl = "?"
+		}
+		for _, k := range r.Keys {
+			if _, err := path.Match(k, ""); err != nil {
+				return fmt.Errorf("rule %q: bad key pattern %q: %s", r.Name, k, err)
+			}
+		}
+		switch {
+		case r.Pattern != "" && r.Builtin != "":
+			return fmt.Errorf(`rule %q: "pattern" and "builtin" can not be used together`, r.Name)
+		case r.Pattern != "":
+			re, err := regexp.Compile(r.Pattern)
+			if err != nil {
+				return fmt.Errorf("rule %q: %s", r.Name, err)
+			}
+			r.Re = re
+		case r.Builtin == "credit_card":
+			// detected using the credit card obfuscator
+		case r.Builtin != "":
+			pattern, ok := scrubBuiltinPatterns[r.Builtin]
+			if !ok {
+				return fmt.Errorf(`rule %q: unknown builtin %q, it should be one of "credit_card", "email" or "jwt"`, r.Name, r.Builtin)
+			}
+			r.Re = regexp.MustCompile(pattern)
+		case len(r.Keys) == 0:
+			return fmt.Errorf(`rule %q: at least one of "keys", "pattern" or "builtin" is required`, r.Name)
+		}
+	}
+	return nil
+}
+
 // getDuration returns the duration of the provided value in seconds
 func getDuration(seconds int) time.Duration {
 	return time.Duration(seconds) * time.Second
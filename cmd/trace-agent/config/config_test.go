@@ -54,6 +54,33 @@ func TestParseRepaceRules(t *testing.T) {
 	}
 }
 
+func TestCompileScrubRules(t *testing.T) {
+	rules := []*config.ScrubRule{
+		{Name: "passwords", Keys: []string{"*.password"}},
+		{Keys: []string{"http.url"}, Pattern: "token=[^&]*", Repl: "token=?"},
+		{Name: "emails", Builtin: "email"},
+		{Name: "cards", Builtin: "credit_card"},
+	}
+	assert.NoError(t, compileScrubRules(rules))
+	assert.Nil(t, rules[0].Re)
+	assert.Equal(t, "?", rules[0].Repl)
+	assert.Equal(t, "rule_1", rules[1].Name)
+	assert.Equal(t, "token=[^&]*", rules[1].Re.String())
+	assert.Equal(t, "token=?", rules[1].Repl)
+	assert.True(t, rules[2].Re.MatchString("contact: jane.doe@example.com"))
+	assert.Nil(t, rules[3].Re)
+
+	for _, bad := range []*config.ScrubRule{
+		{Name: "empty"},
+		{Name: "bad-key", Keys: []string{"["}},
+		{Name: "bad-pattern", Pattern: "("},
+		{Name: "bad-builtin", Builtin: "ssn"},
+		{Name: "both", Pattern: "a", Builtin: "email"},
+	} {
+		assert.Error(t, compileScrubRules([]*config.ScrubRule{bad}), bad.Name)
+	}
+}
+
 func TestSplitTag(t *testing.T) {
 	for _, tt := range []struct {
 		tag string
@@ -816,6 +843,22 @@ func TestLoadEnv(t *testing.T) {
 		assert.Contains(cfg.ReplaceTags, rule2)
 	})
 
+	env = "DD_APM_SPAN_METRICS"
+	t.Run(env, func(t *testing.T) {
+		defer cleanConfig()()
+		assert := assert.New(t)
+		t.Setenv(env, `[{"name":"checkout.latency","service":"web","match_tags":{"http.route":"/checkout"},"tag_keys":["customer.tier"],"include_resource":true}]`)
+		cfg, err := LoadConfigFile("./testdata/full.yaml")
+		assert.NoError(err)
+		assert.Equal([]*config.SpanMetricRule{{
+			Name:            "checkout.latency",
+			Service:         "web",
+			MatchTags:       map[string]string{"http.route": "/checkout"},
+			TagKeys:         []string{"customer.tier"},
+			IncludeResource: true,
+		}}, cfg.SpanMetrics)
+	})
+
 	env = "DD_APM_FILTER_TAGS_REQUIRE"
 	t.Run(env, func(t *testing.T) {
 		defer cleanConfig()()
@@ -1061,6 +1104,24 @@ func TestLoadEnv(t *testing.T) {
 		assert.False(cfg.Obfuscation.CreditCards.Luhn)
 	})
 
+	env = "DD_APM_OBFUSCATION_SCRUB_RULES"
+	t.Run(env, func(t *testing.T) {
+		defer cleanConfig()()
+		assert := assert.New(t)
+		t.Setenv(env, `[{"name":"passwords","keys":["*.password"]},{"name":"jwt","builtin":"jwt","repl":"<jwt>"}]`)
+		cfg, err := LoadConfigFile("./testdata/full.yaml")
+		assert.NoError(err)
+		rules := cfg.Obfuscation.ScrubRules
+		if assert.Len(rules, 2) {
+			assert.Equal("passwords", rules[0].Name)
+			assert.Equal([]string{"*.password"}, rules[0].Keys)
+			assert.Equal("?", rules[0].Repl)
+			assert.Equal("jwt", rules[1].Builtin)
+			assert.Equal("<jwt>", rules[1].Repl)
+			assert.NotNil(rules[1].Re)
+		}
+	})
+
 	env = "DD_APM_OBFUSCATION_ELASTICSEARCH_ENABLED"
 	t.Run(env, func(t *testing.T) {
 		defer cleanConfig()()
@@ -1133,6 +1194,28 @@ func TestLoadEnv(t *testing.T) {
 		assert.True(cfg.Obfuscation.Memcached.Enabled)
 	})
 
+	env = "DD_APM_OBFUSCATION_CASSANDRA_ENABLED"
+	t.Run(env, func(t *testing.T) {
+		defer cleanConfig()()
+		assert := assert.New(t)
+		t.Setenv(env, "true")
+		cfg, err := LoadConfigFile("./testdata/full.yaml")
+		assert.NoError(err)
+		assert.True(coreconfig.Datadog.GetBool("apm_config.obfuscation.cassandra.enabled"))
+		assert.True(cfg.Obfuscation.Cassandra.Enabled)
+	})
+
+	env = "DD_APM_OBFUSCATION_DYNAMODB_ENABLED"
+	t.Run(env, func(t *testing.T) {
+		defer cleanConfig()()
+		assert := assert.New(t)
+		t.Setenv(env, "true")
+		cfg, err := LoadConfigFile("./testdata/full.yaml")
+		assert.NoError(err)
+		assert.True(coreconfig.Datadog.GetBool("apm_config.obfuscation.dynamodb.enabled"))
+		assert.True(cfg.Obfuscation.DynamoDB.Enabled)
+	})
+
 	env = "DD_APM_OBFUSCATION_MONGODB_ENABLED"
 	t.Run(env, func(t *testing.T) {
 		defer cleanConfig()()
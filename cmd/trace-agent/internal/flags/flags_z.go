@@ -5,7 +5,10 @@
 
 package flags
 
-import "flag"
+import (
+	"flag"
+	"time"
+)
 
 var (
 	// ConfigPath specifies the path to the configuration file.
@@ -27,6 +30,27 @@ var (
 	// MemProfile specifies the path to output memory profiling information to.
 	// When empty, memory profiling is disabled.
 	MemProfile string
+
+	// Capture asks a running agent to capture incoming trace payloads for the given duration.
+	Capture time.Duration
+
+	// Replay specifies the path of a capture file to replay against a running agent.
+	Replay string
+
+	// ReplaySpeed specifies the speed multiplier used when replaying a capture.
+	// A value of 0 replays payloads as fast as possible.
+	ReplaySpeed float64
+
+	// ReplayTarget specifies the base URL of the trace-agent receiving the replayed payloads.
+	// When empty, the receiver configured in the configuration file is used.
+	ReplayTarget string
+
+	// TailSampling will print the sampling decisions made by a running agent until interrupted.
+	TailSampling bool
+
+	// TailSamplingFilter holds comma separated key=value filters applied to the printed sampling
+	// decisions. Valid keys are trace_id, env, service, resource, sampler and kept.
+	TailSamplingFilter string
 )
 
 // Win holds a set of flags which will be populated only during the Windows build.
@@ -42,6 +66,16 @@ func init() {
 	flag.BoolVar(&Version, "version", false, "Show version information and exit")
 	flag.BoolVar(&Info, "info", false, "Show info about running trace agent process and exit")
 
+	// capture & replay
+	flag.DurationVar(&Capture, "capture", 0, "Capture incoming trace payloads on the running trace agent for the given `duration` and exit")
+	flag.StringVar(&Replay, "replay", "", "Replay the capture `file` against a running trace agent and exit")
+	flag.Float64Var(&ReplaySpeed, "replay-speed", 1, "Replay speed multiplier; 0 replays as fast as possible")
+	flag.StringVar(&ReplayTarget, "replay-target", "", "Base URL of the trace agent to replay to (defaults to the configured receiver)")
+
+	// sampling decisions
+	flag.BoolVar(&TailSampling, "tail-sampling", false, "Print the sampling decisions made by the running trace agent until interrupted")
+	flag.StringVar(&TailSamplingFilter, "tail-sampling-filter", "", "Comma separated `key=value` filters for -tail-sampling (trace_id, env, service, resource, sampler, kept)")
+
 	// profiling
 	flag.StringVar(&CPUProfile, "cpuprofile", "", "Write cpu profile to file")
 	flag.StringVar(&MemProfile, "memprofile", "", "Write memory profile to `file`")
@@ -88,6 +88,27 @@ func Run(ctx context.Context) {
 		return
 	}
 
+	if flags.Capture > 0 {
+		if err := startCapture(cfg, flags.Capture); err != nil {
+			osutil.Exitf("Failed to start capture: %s", err)
+		}
+		return
+	}
+
+	if flags.Replay != "" {
+		if err := replayCapture(ctx, cfg, flags.Replay, flags.ReplaySpeed, flags.ReplayTarget); err != nil {
+			osutil.Exitf("Failed to replay capture: %s", err)
+		}
+		return
+	}
+
+	if flags.TailSampling {
+		if err := tailSamplingDecisions(ctx, cfg, flags.TailSamplingFilter); err != nil {
+			osutil.Exitf("Failed to tail sampling decisions: %s", err)
+		}
+		return
+	}
+
 	telemetryCollector := telemetry.NewCollector(cfg)
 
 	if err := coreconfig.SetupLogger(
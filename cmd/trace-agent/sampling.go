@@ -0,0 +1,108 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/api"
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+)
+
+// samplingPollInterval is the interval at which sampling decisions are fetched from the agent.
+const samplingPollInterval = time.Second
+
+// parseSamplingFilter parses comma separated key=value filters into query parameters.
+func parseSamplingFilter(filter string) (url.Values, error) {
+	q := url.Values{}
+	for _, kv := range strings.Split(filter, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid filter %q, it should be of the form key=value", kv)
+		}
+		switch k {
+		case "trace_id", "env", "service", "resource", "sampler", "kept":
+			q.Set(k, v)
+		default:
+			return nil, fmt.Errorf("unknown filter key %q", k)
+		}
+	}
+	return q, nil
+}
+
+// tailSamplingDecisions prints the sampling decisions made by the running trace-agent
+// and matching filter, until ctx is cancelled.
+func tailSamplingDecisions(ctx context.Context, cfg *config.AgentConfig, filter string) error {
+	q, err := parseSamplingFilter(filter)
+	if err != nil {
+		return err
+	}
+	client := http.Client{Timeout: 5 * time.Second}
+	tick := time.NewTicker(samplingPollInterval)
+	defer tick.Stop()
+	var (
+		since   uint64
+		started bool
+	)
+	for {
+		q.Set("since", strconv.FormatUint(since, 10))
+		resp, err := fetchSamplingDecisions(ctx, &client, cfg.DebugServerPort, q)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		// skip the decisions which were recorded before we started tailing
+		if started {
+			for _, d := range resp.Decisions {
+				fmt.Printf("%s trace_id=%d env=%q service=%q resource=%q priority=%d sampler=%s rate=%g kept=%t\n",
+					d.Time.Format(time.RFC3339Nano), d.TraceID, d.Env, d.Service, d.Resource, d.Priority, d.Sampler, d.Rate, d.Kept)
+			}
+		}
+		since = resp.Next
+		started = true
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-tick.C:
+		}
+	}
+}
+
+func fetchSamplingDecisions(ctx context.Context, client *http.Client, port int, q url.Values) (*api.SamplingDecisionsResponse, error) {
+	url := fmt.Sprintf("http://127.0.0.1:%d/debug/sampling?%s", port, q.Encode())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("could not reach the trace-agent debug server (is the trace-agent running?): %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("could not get sampling decisions: %s", msg)
+	}
+	var out api.SamplingDecisionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
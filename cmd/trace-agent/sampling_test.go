@@ -0,0 +1,28 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package main
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseSamplingFilter(t *testing.T) {
+	q, err := parseSamplingFilter("")
+	assert.NoError(t, err)
+	assert.Empty(t, q)
+
+	q, err = parseSamplingFilter("service=web, kept=false,sampler=errors")
+	assert.NoError(t, err)
+	assert.Equal(t, url.Values{"service": {"web"}, "kept": {"false"}, "sampler": {"errors"}}, q)
+
+	_, err = parseSamplingFilter("service")
+	assert.Error(t, err)
+	_, err = parseSamplingFilter("color=blue")
+	assert.Error(t, err)
+}
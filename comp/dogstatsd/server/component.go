@@ -34,6 +34,9 @@ type Component interface {
 
 	// SetExtraTags sets extra tags. All metrics sent to the DogstatsD will be tagged with them.
 	SetExtraTags(tags []string)
+
+	// ReloadMappingProfiles rebuilds the metric mapper from the current dogstatsd_mapper_profiles.
+	ReloadMappingProfiles() error
 }
 
 // Mock implements mock-specific methods.
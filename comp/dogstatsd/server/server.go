@@ -31,6 +31,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/util"
 	"github.com/DataDog/datadog-agent/pkg/util/hostname"
+	"go.uber.org/atomic"
 	"go.uber.org/fx"
 )
 
@@ -113,7 +114,6 @@ type server struct {
 	Debug                   serverDebug.Component
 
 	tCapture                replay.Component
-	mapper                  *mapper.MetricMapper
 	eolTerminationUDP       bool
 	eolTerminationUDS       bool
 	eolTerminationNamedPipe bool
@@ -123,6 +123,9 @@ type server struct {
 	// package (pkg/trace/log/throttled.go) for a possible throttler implementation.
 	disableVerboseLogs bool
 
+	// mapper is replaced when the mapping profiles are reloaded
+	mapper atomic.Pointer[mapper.MetricMapper]
+
 	// cachedTlmLock must be held when accessing cachedOriginCounters and cachedOrder
 	cachedTlmLock sync.Mutex
 	// cachedOriginCounters caches telemetry counter per origin
@@ -391,19 +394,42 @@ func (s *server) Start(demultiplexer aggregator.Demultiplexer) error {
 	// map some metric name
 	// ----------------------
 
-	cacheSize := s.config.GetInt("dogstatsd_mapper_cache_size")
+	mapperInstance, err := s.buildMapper()
+	if err != nil {
+		s.log.Warnf("Dogstatsd: %v", err)
+	} else {
+		s.mapper.Store(mapperInstance)
+	}
+	return nil
+}
 
+// buildMapper builds the metric mapper of the configured mapping profiles. It
+// returns nil if no mapping profile is configured.
+func (s *server) buildMapper() (*mapper.MetricMapper, error) {
 	mappings, err := config.GetDogstatsdMappingProfiles()
 	if err != nil {
-		s.log.Warnf("Could not parse mapping profiles: %v", err)
-	} else if len(mappings) != 0 {
-		mapperInstance, err := mapper.NewMetricMapper(mappings, cacheSize)
-		if err != nil {
-			s.log.Warnf("Could not create metric mapper: %v", err)
-		} else {
-			s.mapper = mapperInstance
-		}
+		return nil, fmt.Errorf("could not parse mapping profiles: %v", err)
+	}
+	if len(mappings) == 0 {
+		return nil, nil
 	}
+
+	mapperInstance, err := mapper.NewMetricMapper(mappings, s.config.GetInt("dogstatsd_mapper_cache_size"))
+	if err != nil {
+		return nil, fmt.Errorf("could not create metric mapper: %v", err)
+	}
+	return mapperInstance, nil
+}
+
+// ReloadMappingProfiles replaces the metric mapper with one built from the
+// current mapping profiles. The current mapper is kept if they're invalid.
+func (s *server) ReloadMappingProfiles() error {
+	mapperInstance, err := s.buildMapper()
+	if err != nil {
+		return err
+	}
+
+	s.mapper.Store(mapperInstance)
 	return nil
 }
 
@@ -688,8 +714,8 @@ func (s *server) parseMetricMessage(metricSamples []metrics.MetricSample, parser
 		return metricSamples, err
 	}
 
-	if s.mapper != nil {
-		mapResult := s.mapper.Map(sample.name)
+	if metricMapper := s.mapper.Load(); metricMapper != nil {
+		mapResult := metricMapper.Map(sample.name)
 		if mapResult != nil {
 			s.log.Tracef("Dogstatsd mapper: metric mapped from %q to %q with tags %v", sample.name, mapResult.Name, mapResult.Tags)
 			sample.name = mapResult.Name
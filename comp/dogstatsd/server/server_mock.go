@@ -43,3 +43,7 @@ func (s *serverMock) UdsListenerRunning() bool {
 func (s *serverMock) ServerlessFlush() {}
 
 func (s *serverMock) SetExtraTags(tags []string) {}
+
+func (s *serverMock) ReloadMappingProfiles() error {
+	return nil
+}
@@ -718,7 +718,7 @@ func TestNoMappingsConfig(t *testing.T) {
 	defer demux.Stop(false)
 	requireStart(t, s, demux)
 
-	assert.Nil(t, s.mapper)
+	assert.Nil(t, s.mapper.Load())
 
 	parser := newParser(deps.Config, newFloat64ListPool())
 	samples, err = s.parseMetricMessage(samples, parser, []byte("test.metric:666|g"), "", false)
@@ -726,6 +726,47 @@ func TestNoMappingsConfig(t *testing.T) {
 	assert.Len(t, samples, 1)
 }
 
+func TestReloadMappingProfiles(t *testing.T) {
+	port, err := getAvailableUDPPort()
+	require.NoError(t, err)
+
+	deps := fulfillDepsWithConfigYaml(t, "")
+	s := deps.Server.(*server)
+	cw := deps.Config.(config.ConfigWriter)
+	cw.Set("dogstatsd_port", port)
+
+	demux := mockDemultiplexer(deps.Config, deps.Log)
+	defer demux.Stop(false)
+	requireStart(t, s, demux)
+	defer s.Stop()
+
+	assert.Nil(t, s.mapper.Load())
+
+	cw.Set("dogstatsd_mapper_profiles", []map[string]interface{}{
+		{
+			"name":   "test",
+			"prefix": "test.",
+			"mappings": []map[string]interface{}{
+				{"match": "test.job.*", "name": "test.job", "tags": map[string]string{"job_name": "$1"}},
+			},
+		},
+	})
+	require.NoError(t, s.ReloadMappingProfiles())
+	assert.NotNil(t, s.mapper.Load())
+
+	parser := newParser(deps.Config, newFloat64ListPool())
+	samples, err := s.parseMetricMessage(nil, parser, []byte("test.job.backup:1|g"), "", false)
+	require.NoError(t, err)
+	require.Len(t, samples, 1)
+	assert.Equal(t, "test.job", samples[0].Name)
+	assert.Equal(t, []string{"job_name:backup"}, samples[0].Tags)
+
+	// invalid profiles keep the current mapper
+	cw.Set("dogstatsd_mapper_profiles", []map[string]interface{}{{"name": "test"}})
+	assert.Error(t, s.ReloadMappingProfiles())
+	assert.NotNil(t, s.mapper.Load())
+}
+
 type MetricSample struct {
 	Name  string
 	Value float64
@@ -24,6 +24,7 @@ import (
 	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/config/resolver"
 	"github.com/DataDog/datadog-agent/pkg/config/utils"
+	"github.com/DataDog/datadog-agent/pkg/secrets"
 	"github.com/DataDog/datadog-agent/pkg/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/util/filesystem"
 	"github.com/DataDog/datadog-agent/pkg/version"
@@ -330,9 +331,36 @@ func NewDefaultForwarder(config config.Component, log log.Component, options *Op
 		log.Debugf("Outdated files removed: %v", strings.Join(filesRemoved, ", "))
 	}
 
+	// Use the new value of the API keys rotated in the secret backend
+	secrets.RegisterRefreshCallback(f.updateAPIKeys)
+
 	return f
 }
 
+// updateAPIKeys replaces the API keys whose secret changed when the secrets were
+// refreshed by their new value
+func (f *DefaultForwarder) updateAPIKeys(changes []secrets.SecretChange) {
+	updated := false
+	for _, change := range changes {
+		oldKey := pkgconfig.SanitizeAPIKey(change.OldValue)
+		newKey := pkgconfig.SanitizeAPIKey(change.NewValue)
+		for domain, dr := range f.domainResolvers {
+			for _, apiKey := range dr.GetAPIKeys() {
+				if apiKey == oldKey {
+					dr.UpdateAPIKey(oldKey, newKey)
+					f.log.Infof("API key from secret '%s' updated for domain %s", change.Handle, domain)
+					updated = true
+					break
+				}
+			}
+		}
+	}
+
+	if updated {
+		f.healthChecker.updateAPIKeys()
+	}
+}
+
 func getAgentName(options *Options) string {
 	if HasFeature(options.EnabledFeatures, CoreFeatures) {
 		return "core"
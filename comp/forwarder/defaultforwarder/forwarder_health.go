@@ -10,6 +10,7 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sync"
 	"time"
 
 	"github.com/DataDog/datadog-agent/comp/core/log"
@@ -67,6 +68,7 @@ type forwarderHealth struct {
 	timeout               time.Duration
 	domainResolvers       map[string]resolver.DomainResolver
 	keysPerAPIEndpoint    map[string][]string
+	keysLock              sync.Mutex // To control races on keysPerAPIEndpoint when the API keys are updated
 	disableAPIKeyChecking bool
 	validationInterval    time.Duration
 }
@@ -207,11 +209,25 @@ func (fh *forwarderHealth) validateAPIKey(apiKey, domain string) (bool, error) {
 	return false, fmt.Errorf("Unexpected response code from the apikey validation endpoint: %v", resp.StatusCode)
 }
 
+// updateAPIKeys makes the forwarderHealth validate the current API keys of the
+// domain resolvers, after some of them were updated
+func (fh *forwarderHealth) updateAPIKeys() {
+	fh.keysLock.Lock()
+	defer fh.keysLock.Unlock()
+
+	fh.keysPerAPIEndpoint = make(map[string][]string)
+	fh.computeDomainsURL()
+}
+
 func (fh *forwarderHealth) hasValidAPIKey() bool {
 	validKey := false
 	apiError := false
 
-	for domain, apiKeys := range fh.keysPerAPIEndpoint {
+	fh.keysLock.Lock()
+	keysPerAPIEndpoint := fh.keysPerAPIEndpoint
+	fh.keysLock.Unlock()
+
+	for domain, apiKeys := range keysPerAPIEndpoint {
 		for _, apiKey := range apiKeys {
 			v, err := fh.validateAPIKey(apiKey, domain)
 			if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/config"
 	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/config/resolver"
+	"github.com/DataDog/datadog-agent/pkg/secrets"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 	"github.com/DataDog/datadog-agent/pkg/version"
 )
@@ -175,6 +176,29 @@ func TestCreateHTTPTransactions(t *testing.T) {
 	assert.Equal(t, p2, transactions[3].Payload.GetContent())
 }
 
+func TestUpdateAPIKeys(t *testing.T) {
+	mockConfig := pkgconfig.Mock(t)
+	log := fxutil.Test[log.Component](t, log.MockModule)
+	forwarder := NewDefaultForwarder(mockConfig, log, NewOptionsWithResolvers(mockConfig, log, resolver.NewSingleDomainResolvers(keysWithMultipleDomains)))
+
+	forwarder.updateAPIKeys([]secrets.SecretChange{
+		{Handle: "key", Origin: "datadog.yaml", Path: "api_key", OldValue: "api-key-2", NewValue: "rotated-key\n"},
+		{Handle: "other", Origin: "datadog.yaml", Path: "other", OldValue: "unknown", NewValue: "unused"},
+	})
+
+	assert.Equal(t, []string{"api-key-1", "rotated-key"}, forwarder.domainResolvers[testVersionDomain].GetAPIKeys())
+	assert.Equal(t, []string{"api-key-3"}, forwarder.domainResolvers["datadog.bar"].GetAPIKeys())
+
+	endpoint := transaction.Endpoint{Route: "/api/foo", Name: "foo"}
+	p1 := []byte("A payload")
+	transactions := forwarder.createHTTPTransactions(endpoint, transaction.NewBytesPayloadsWithoutMetaData([]*[]byte{&p1}), make(http.Header))
+	apiKeys := []string{}
+	for _, t := range transactions {
+		apiKeys = append(apiKeys, t.Headers.Get("DD-Api-Key"))
+	}
+	assert.ElementsMatch(t, []string{"api-key-1", "rotated-key", "api-key-3"}, apiKeys)
+}
+
 func TestCreateHTTPTransactionsWithMultipleDomains(t *testing.T) {
 	mockConfig := pkgconfig.Mock(t)
 	log := fxutil.Test[log.Component](t, log.MockModule)
@@ -14,6 +14,7 @@ import (
 	"go.uber.org/fx"
 
 	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/config/remote"
 	"github.com/DataDog/datadog-agent/pkg/config/remote/data"
 	"github.com/DataDog/datadog-agent/pkg/config/settings"
@@ -38,6 +39,9 @@ type rcClient struct {
 	m             *sync.Mutex
 	taskProcessed map[string]bool
 	configState   *state.AgentConfigState
+	// fallbackLogLevelSource is the source of the log level overridden by
+	// remote config, restored along with the fallback log level
+	fallbackLogLevelSource *config.Source
 
 	listeners []RCAgentTaskListener
 }
@@ -56,13 +60,16 @@ func newRemoteConfigClient(deps dependencies) (Component, error) {
 		return nil, err
 	}
 
+	levelSource := config.Datadog.GetSource("log_level")
+
 	rc := rcClient{
 		listeners: deps.Listeners,
 		m:         &sync.Mutex{},
 		configState: &state.AgentConfigState{
 			FallbackLogLevel: level.String(),
 		},
-		client: nil,
+		fallbackLogLevelSource: &levelSource,
+		client:                 nil,
 	}
 
 	return rc, nil
@@ -113,7 +120,8 @@ func (rc rcClient) agentConfigUpdateCallback(updates map[string]state.RawConfig)
 		newFallback, err = pkglog.GetLogLevel()
 		if err == nil {
 			rc.configState.FallbackLogLevel = newFallback.String()
-			err = settings.SetRuntimeSetting("log_level", mergedConfig.LogLevel)
+			*rc.fallbackLogLevelSource = config.Datadog.GetSource("log_level")
+			err = settings.SetRuntimeSetting("log_level", mergedConfig.LogLevel, config.SourceRC)
 			rc.configState.LatestLogLevel = mergedConfig.LogLevel
 		}
 	} else {
@@ -121,7 +129,7 @@ func (rc rcClient) agentConfigUpdateCallback(updates map[string]state.RawConfig)
 		currentLogLevel, err = pkglog.GetLogLevel()
 		if err == nil && currentLogLevel.String() == rc.configState.LatestLogLevel {
 			pkglog.Infof("Removing remote-config log level override, falling back to %s", rc.configState.FallbackLogLevel)
-			err = settings.SetRuntimeSetting("log_level", rc.configState.FallbackLogLevel)
+			err = settings.SetRuntimeSetting("log_level", rc.configState.FallbackLogLevel, *rc.fallbackLogLevelSource)
 		}
 	}
 
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/config/remote"
 	"github.com/DataDog/datadog-agent/pkg/config/remote/data"
 	"github.com/DataDog/datadog-agent/pkg/config/settings"
@@ -32,10 +33,15 @@ func (m *mockLogLevelRuntimeSettings) Get() (interface{}, error) {
 }
 
 func (m *mockLogLevelRuntimeSettings) Set(v interface{}) error {
+	return m.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+func (m *mockLogLevelRuntimeSettings) SetWithSource(v interface{}, source config.Source) error {
 	if m.expectedError != nil {
 		return m.expectedError
 	}
 	m.logLevel = v.(string)
+	config.Datadog.SetWithSource("log_level", m.logLevel, source)
 	return nil
 }
 
@@ -77,4 +83,5 @@ func TestAgentConfigCallback(t *testing.T) {
 		"datadog/2/AGENT_CONFIG/configuration_order/configname": configOrder,
 	})
 	assert.Equal(t, "debug", mockSettings.logLevel)
+	assert.Equal(t, config.SourceRC, config.Datadog.GetSource("log_level"))
 }
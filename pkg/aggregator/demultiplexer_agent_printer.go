@@ -12,6 +12,9 @@ import (
 	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/collector/check/stats"
+	"github.com/DataDog/datadog-agent/pkg/metrics"
+	"github.com/DataDog/datadog-agent/pkg/metrics/event"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
 	"github.com/fatih/color"
 	"github.com/olekukonko/tablewriter"
 )
@@ -29,10 +32,37 @@ type eventPlatformDebugEvent struct {
 	UnmarshalledEvent map[string]interface{} `json:",omitempty"`
 }
 
+// CheckSamples holds the series, sketches, service checks and events sent by
+// the checks.
+type CheckSamples struct {
+	Series        metrics.Series
+	Sketches      metrics.SketchSeriesList
+	ServiceChecks servicecheck.ServiceChecks
+	Events        event.Events
+}
+
+// GetCheckSamples flushes the Demultiplexer's check samplers, service checks
+// buffer and events buffers, and returns their content.
+func (p AgentDemultiplexerPrinter) GetCheckSamples() CheckSamples {
+	series, sketches := p.aggregator.GetSeriesAndSketches(time.Now())
+	return CheckSamples{
+		Series:        series,
+		Sketches:      sketches,
+		ServiceChecks: p.aggregator.GetServiceChecks(),
+		Events:        p.aggregator.GetEvents(),
+	}
+}
+
 // PrintMetrics prints metrics aggregator in the Demultiplexer's check samplers (series and sketches),
 // service checks buffer, events buffers.
 func (p AgentDemultiplexerPrinter) PrintMetrics(checkFileOutput *bytes.Buffer, formatTable bool) {
-	series, sketches := p.aggregator.GetSeriesAndSketches(time.Now())
+	p.PrintCheckSamples(p.GetCheckSamples(), checkFileOutput, formatTable)
+}
+
+// PrintCheckSamples prints the given check samples, and the event platform
+// events of the Demultiplexer.
+func (p AgentDemultiplexerPrinter) PrintCheckSamples(samples CheckSamples, checkFileOutput *bytes.Buffer, formatTable bool) {
+	series, sketches := samples.Series, samples.Sketches
 	if len(series) != 0 {
 		fmt.Fprintln(color.Output, fmt.Sprintf("=== %s ===", color.BlueString("Series")))
 
@@ -71,7 +101,7 @@ func (p AgentDemultiplexerPrinter) PrintMetrics(checkFileOutput *bytes.Buffer, f
 		checkFileOutput.WriteString(string(j) + "\n")
 	}
 
-	serviceChecks := p.aggregator.GetServiceChecks()
+	serviceChecks := samples.ServiceChecks
 	if len(serviceChecks) != 0 {
 		fmt.Fprintln(color.Output, fmt.Sprintf("=== %s ===", color.BlueString("Service Checks")))
 
@@ -104,7 +134,7 @@ func (p AgentDemultiplexerPrinter) PrintMetrics(checkFileOutput *bytes.Buffer, f
 		}
 	}
 
-	events := p.aggregator.GetEvents()
+	events := samples.Events
 	if len(events) != 0 {
 		fmt.Fprintln(color.Output, fmt.Sprintf("=== %s ===", color.BlueString("Events")))
 		checkFileOutput.WriteString("=== Events ===\n")
@@ -148,11 +178,15 @@ func (p AgentDemultiplexerPrinter) toDebugEpEvents() map[string][]eventPlatformD
 
 // GetMetricsDataForPrint returns metrics data for series and sketches for printing purpose.
 func (p AgentDemultiplexerPrinter) GetMetricsDataForPrint() map[string]interface{} {
-	aggData := make(map[string]interface{})
+	return p.GetCheckSamplesDataForPrint(p.GetCheckSamples())
+}
 
-	agg := p.Aggregator()
+// GetCheckSamplesDataForPrint returns the given check samples, and the event
+// platform events of the Demultiplexer, for printing purpose.
+func (p AgentDemultiplexerPrinter) GetCheckSamplesDataForPrint(samples CheckSamples) map[string]interface{} {
+	aggData := make(map[string]interface{})
 
-	series, sketches := agg.GetSeriesAndSketches(time.Now())
+	series, sketches := samples.Series, samples.Sketches
 	if len(series) != 0 {
 		metrics := make([]interface{}, len(series))
 		// Workaround to get the sequence of metrics as plain interface{}
@@ -169,14 +203,12 @@ func (p AgentDemultiplexerPrinter) GetMetricsDataForPrint() map[string]interface
 		aggData["sketches"] = sketches
 	}
 
-	serviceChecks := agg.GetServiceChecks()
-	if len(serviceChecks) != 0 {
-		aggData["service_checks"] = serviceChecks
+	if len(samples.ServiceChecks) != 0 {
+		aggData["service_checks"] = samples.ServiceChecks
 	}
 
-	events := agg.GetEvents()
-	if len(events) != 0 {
-		aggData["events"] = events
+	if len(samples.Events) != 0 {
+		aggData["events"] = samples.Events
 	}
 
 	for k, v := range p.toDebugEpEvents() {
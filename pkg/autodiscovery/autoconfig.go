@@ -19,6 +19,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/secrets"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
 	"github.com/DataDog/datadog-agent/pkg/tagger"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
@@ -68,6 +69,11 @@ func NewAutoConfig(scheduler *scheduler.MetaScheduler) *AutoConfig {
 	// We need to listen to the service channels before anything is sent to them
 	go ac.serviceListening()
 
+	// Reschedule the configs using rotated secrets
+	secrets.RegisterRefreshCallback(ac.processRefreshedSecrets)
+	// Reschedule the configs of the checks whose instance defaults changed
+	integration.RegisterInstanceDefaultsCallback(ac.processChangedInstanceDefaults)
+
 	return ac
 }
 
@@ -361,6 +367,36 @@ func (ac *AutoConfig) processRemovedConfigs(configs []integration.Config) {
 	ac.applyChanges(changes)
 }
 
+// processRefreshedSecrets reschedules the configs using the secrets whose value
+// changed when the secrets were refreshed
+func (ac *AutoConfig) processRefreshedSecrets(secretChanges []secrets.SecretChange) {
+	if config.Datadog.GetBool("secret_backend_skip_checks") {
+		return
+	}
+
+	// the secrets used by the configs are registered with their name as origin
+	configNames := map[string]struct{}{}
+	for _, change := range secretChanges {
+		configNames[change.Origin] = struct{}{}
+	}
+
+	changes := ac.cfgMgr.processRefreshedSecrets(configNames)
+	if len(changes.Schedule) > 0 {
+		log.Infof("Rescheduling %d configs whose secrets changed", len(changes.Schedule))
+	}
+	ac.applyChanges(changes)
+}
+
+// processChangedInstanceDefaults reschedules the configs of the checks whose
+// instance defaults changed
+func (ac *AutoConfig) processChangedInstanceDefaults(checkNames map[string]struct{}) {
+	changes := ac.cfgMgr.processChangedInstanceDefaults(checkNames)
+	if len(changes.Schedule) > 0 {
+		log.Infof("Rescheduling %d configs whose instance defaults changed", len(changes.Schedule))
+	}
+	ac.applyChanges(changes)
+}
+
 // MapOverLoadedConfigs calls the given function with the map of all
 // loaded configs (those that would be returned from LoadedConfigs).
 //
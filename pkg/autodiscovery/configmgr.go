@@ -38,6 +38,21 @@ type configManager interface {
 	// interface apply to only one config.
 	processDelConfigs(configs []integration.Config) integration.ConfigChanges
 
+	// processRefreshedSecrets handles a change of the secrets used by the
+	// configs with the given names, rescheduling the configs whose secrets
+	// changed.
+	processRefreshedSecrets(configNames map[string]struct{}) integration.ConfigChanges
+
+	// processChangedInstanceDefaults handles a change of the instance defaults
+	// of the checks with the given names, rescheduling the configs whose
+	// merged instances changed and retrying the ones which couldn't be
+	// scheduled.
+	processChangedInstanceDefaults(checkNames map[string]struct{}) integration.ConfigChanges
+
+	// explainServices explains how the services matching the given identifier
+	// were handled, see AutoConfig.Explain.
+	explainServices(id string) []ServiceExplanation
+
 	// mapOverLoadedConfigs calls the given function with a map of all
 	// loaded configs (those which have been scheduled but not unscheduled).
 	// The call is made with the manager's lock held, so callers should perform
@@ -89,6 +104,11 @@ type reconcilingConfigManager struct {
 	// that service: serviceID -> template digest -> resolved config digest.
 	serviceResolutions map[string]map[string]string
 
+	// decryptedConfigs maps the digest of each non-template config in
+	// activeConfigs to the digest of its scheduled config, in which the secrets
+	// are decrypted.
+	decryptedConfigs map[string]string
+
 	// scheduledConfigs contains an entry for each scheduled config, keyed
 	// by its digest.  This is a mix of resolved templates and non-template
 	// configs.  The returned integration.ConfigChanges from interface
@@ -106,6 +126,7 @@ func newReconcilingConfigManager() configManager {
 		templatesByADID:    newMultimap(),
 		servicesByADID:     newMultimap(),
 		serviceResolutions: map[string]map[string]string{},
+		decryptedConfigs:   map[string]string{},
 		scheduledConfigs:   map[string]integration.Config{},
 	}
 }
@@ -202,12 +223,19 @@ func (cm *reconcilingConfigManager) processNewConfig(config integration.Config)
 			changes.Merge(cm.reconcileService(svcID))
 		}
 	} else {
-		// Secrets always need to be resolved (done in reconcileService if template)
+		// Instance defaults and secrets always need to be resolved (done in
+		// reconcileService if template). The config isn't scheduled without
+		// the instance defaults it extends.
+		if err := config.MergeInstanceDefaults(); err != nil {
+			log.Errorf("Unable to merge the instance defaults of config '%s', dropping check configuration, err: %s", config.Name, err.Error())
+			return integration.ConfigChanges{}
+		}
 		config, err := decryptConfig(config)
 		if err != nil {
 			log.Errorf("Unable to resolve secrets for config '%s', dropping check configuration, err: %s", config.Name, err.Error())
 		}
 
+		cm.decryptedConfigs[digest] = config.Digest()
 		changes.ScheduleConfig(config)
 	}
 
@@ -249,14 +277,14 @@ func (cm *reconcilingConfigManager) processDelConfigs(configs []integration.Conf
 				changes.Merge(cm.reconcileService(svcID))
 			}
 		} else {
-			// Secrets need to be resolved before being unscheduled as otherwise
-			// the computed hashes can be different from the ones computed at schedule time.
-			config, err := decryptConfig(config)
-			if err != nil {
-				log.Errorf("Unable to resolve secrets for config '%s', check may not be unscheduled properly, err: %s", config.Name, err.Error())
+			// The config is unscheduled with the secrets it was scheduled with,
+			// as otherwise the computed hashes can be different from the ones
+			// computed at schedule time. The configs whose instance defaults
+			// couldn't be merged were never scheduled.
+			if scheduledDigest, found := cm.decryptedConfigs[digest]; found {
+				changes.UnscheduleConfig(cm.scheduledConfigs[scheduledDigest])
+				delete(cm.decryptedConfigs, digest)
 			}
-
-			changes.UnscheduleConfig(config)
 		}
 
 		//  4. update scheduledConfigs
@@ -266,6 +294,100 @@ func (cm *reconcilingConfigManager) processDelConfigs(configs []integration.Conf
 	return allChanges
 }
 
+// processRefreshedSecrets implements configManager#processRefreshedSecrets.
+func (cm *reconcilingConfigManager) processRefreshedSecrets(configNames map[string]struct{}) integration.ConfigChanges {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	return cm.applyChanges(cm.resolveConfigsAgain(configNames))
+}
+
+// processChangedInstanceDefaults implements configManager#processChangedInstanceDefaults.
+func (cm *reconcilingConfigManager) processChangedInstanceDefaults(checkNames map[string]struct{}) integration.ConfigChanges {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	return cm.applyChanges(cm.resolveConfigsAgain(checkNames))
+}
+
+// resolveConfigsAgain merges the instance defaults and decrypts the secrets
+// of the configs with the given names again, resolving the templates again for
+// the services they were resolved for. It returns the changes of the configs
+// which resolve differently, and schedules the ones which couldn't be resolved
+// before and now can. The configs which can't be resolved anymore keep their
+// previous resolution.
+//
+// This method must be called with cm.m locked.
+func (cm *reconcilingConfigManager) resolveConfigsAgain(configNames map[string]struct{}) integration.ConfigChanges {
+	var changes integration.ConfigChanges
+
+	// non-template configs are merged and decrypted again
+	for digest, config := range cm.activeConfigs {
+		if _, found := configNames[config.Name]; !found || config.IsTemplate() {
+			continue
+		}
+
+		if err := config.MergeInstanceDefaults(); err != nil {
+			log.Errorf("Unable to merge the instance defaults of config '%s', keeping the previous configuration, err: %s", config.Name, err.Error())
+			continue
+		}
+		decryptedConfig, err := decryptConfig(config)
+		if err != nil {
+			log.Errorf("Unable to resolve secrets for config '%s', keeping the previous secrets, err: %s", config.Name, err.Error())
+			continue
+		}
+
+		// the configs whose instance defaults couldn't be merged were never
+		// scheduled
+		scheduledDigest, scheduled := cm.decryptedConfigs[digest]
+		if scheduled {
+			if decryptedConfig.Digest() == scheduledDigest {
+				continue
+			}
+			changes.UnscheduleConfig(cm.scheduledConfigs[scheduledDigest])
+		}
+		changes.ScheduleConfig(decryptedConfig)
+		cm.decryptedConfigs[digest] = decryptedConfig.Digest()
+	}
+
+	// templates are resolved again for the services they were resolved for
+	for svcID, resolutions := range cm.serviceResolutions {
+		svc := cm.activeServices[svcID].svc
+		for templateDigest, resolvedDigest := range resolutions {
+			tpl := cm.activeConfigs[templateDigest]
+			if _, found := configNames[tpl.Name]; !found {
+				continue
+			}
+
+			resolved, ok := cm.resolveTemplateForService(tpl, svc)
+			if !ok || resolved.Digest() == resolvedDigest {
+				continue
+			}
+			changes.UnscheduleConfig(cm.scheduledConfigs[resolvedDigest])
+			changes.ScheduleConfig(resolved)
+			resolutions[templateDigest] = resolved.Digest()
+		}
+	}
+
+	// and the resolutions of templates which failed are retried
+	matchingServices := map[string]struct{}{}
+	for _, tpl := range cm.activeConfigs {
+		if _, found := configNames[tpl.Name]; !found || !tpl.IsTemplate() {
+			continue
+		}
+		for _, adID := range tpl.ADIdentifiers {
+			for _, svcID := range cm.servicesByADID.get(adID) {
+				matchingServices[svcID] = struct{}{}
+			}
+		}
+	}
+	for svcID := range matchingServices {
+		changes.Merge(cm.reconcileService(svcID))
+	}
+
+	return changes
+}
+
 // mapOverLoadedConfigs implements configManager#mapOverLoadedConfigs.
 func (cm *reconcilingConfigManager) mapOverLoadedConfigs(f func(map[string]integration.Config)) {
 	cm.m.Lock()
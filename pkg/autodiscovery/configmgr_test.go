@@ -162,6 +162,130 @@ func (suite *ConfigManagerSuite) TestNewNonTemplateWithSecretsScheduled() {
 	require.True(suite.T(), strings.Contains(string(changes.Unschedule[0].Instances[0]), "barDecoded"))
 }
 
+// A new, non-template config is scheduled with the instance defaults of its
+// check, and unscheduled with them when deleted
+func (suite *ConfigManagerSuite) TestNewNonTemplateWithInstanceDefaultsScheduled() {
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"non-template": {"tls": {"ssl": true}},
+	})
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	config := integration.Config{Name: "non-template", Instances: []integration.Data{integration.Data("extends: tls\nhost: localhost\n")}}
+	changes := suite.cm.processNewConfig(config)
+	assertConfigsMatch(suite.T(), changes.Schedule, matchName("non-template"))
+	require.Equal(suite.T(), integration.Data("host: localhost\nssl: true\n"), changes.Schedule[0].Instances[0])
+	scheduledDigest := changes.Schedule[0].Digest()
+
+	changes = suite.cm.processDelConfigs([]integration.Config{config})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(scheduledDigest))
+}
+
+// A new, non-template config extending unknown instance defaults is not
+// scheduled, and not unscheduled when deleted
+func (suite *ConfigManagerSuite) TestNewNonTemplateWithUnknownInstanceDefaultsNotScheduled() {
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"non-template": {"tls": {"ssl": true}},
+	})
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	config := integration.Config{Name: "non-template", Instances: []integration.Data{integration.Data("extends: mtls\nhost: localhost\n")}}
+	changes := suite.cm.processNewConfig(config)
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	changes = suite.cm.processRefreshedSecrets(map[string]struct{}{"non-template": {}})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	changes = suite.cm.processDelConfigs([]integration.Config{config})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+}
+
+// A non-template config with secrets is rescheduled when its secrets change, and
+// unscheduled with its new secrets when deleted
+func (suite *ConfigManagerSuite) TestNonTemplateWithRefreshedSecrets() {
+	decrypted := []byte("foo: barDecoded")
+	mockDecrypt := MockSecretDecrypt{suite.T(), []mockSecretScenario{
+		{
+			expectedData:   []byte("foo: ENC[bar]"),
+			expectedOrigin: nonTemplateConfigWithSecrets.Name,
+			returnedData:   decrypted,
+		},
+		{
+			expectedData:   []byte{},
+			expectedOrigin: nonTemplateConfigWithSecrets.Name,
+			returnedData:   []byte{},
+		},
+	}}
+	defer mockDecrypt.install()()
+
+	changes := suite.cm.processNewConfig(deepcopy.Copy(nonTemplateConfigWithSecrets).(integration.Config))
+	assertConfigsMatch(suite.T(), changes.Schedule, matchName(nonTemplateConfigWithSecrets.Name))
+	oldDigest := changes.Schedule[0].Digest()
+
+	// the secrets of another config changed
+	changes = suite.cm.processRefreshedSecrets(map[string]struct{}{"other": {}})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	// the secrets didn't change
+	changes = suite.cm.processRefreshedSecrets(map[string]struct{}{nonTemplateConfigWithSecrets.Name: {}})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	mockDecrypt.scenarios[0].returnedData = []byte("foo: barRotated")
+	changes = suite.cm.processRefreshedSecrets(map[string]struct{}{nonTemplateConfigWithSecrets.Name: {}})
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(oldDigest))
+	assertConfigsMatch(suite.T(), changes.Schedule, matchName(nonTemplateConfigWithSecrets.Name))
+	require.True(suite.T(), strings.Contains(string(changes.Schedule[0].Instances[0]), "barRotated"))
+	newDigest := changes.Schedule[0].Digest()
+
+	changes = suite.cm.processDelConfigs([]integration.Config{deepcopy.Copy(nonTemplateConfigWithSecrets).(integration.Config)})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(newDigest))
+}
+
+// A non-template config is scheduled once the instance defaults it extends are
+// set, rescheduled when they change, and unscheduled with its latest instance
+// defaults when deleted
+func (suite *ConfigManagerSuite) TestNonTemplateWithChangedInstanceDefaults() {
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	config := integration.Config{Name: "non-template", Instances: []integration.Data{integration.Data("extends: tls\nhost: localhost\n")}}
+	changes := suite.cm.processNewConfig(config)
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"non-template": {"tls": {"ssl": true}},
+	})
+	changes = suite.cm.processChangedInstanceDefaults(map[string]struct{}{"non-template": {}})
+	assertConfigsMatch(suite.T(), changes.Schedule, matchName("non-template"))
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+	require.Equal(suite.T(), integration.Data("host: localhost\nssl: true\n"), changes.Schedule[0].Instances[0])
+	oldDigest := changes.Schedule[0].Digest()
+
+	// the instance defaults didn't change
+	changes = suite.cm.processChangedInstanceDefaults(map[string]struct{}{"non-template": {}})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"non-template": {"tls": {"ssl": false}},
+	})
+	changes = suite.cm.processChangedInstanceDefaults(map[string]struct{}{"non-template": {}})
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(oldDigest))
+	assertConfigsMatch(suite.T(), changes.Schedule, matchName("non-template"))
+	require.Equal(suite.T(), integration.Data("host: localhost\nssl: false\n"), changes.Schedule[0].Instances[0])
+	newDigest := changes.Schedule[0].Digest()
+
+	changes = suite.cm.processDelConfigs([]integration.Config{config})
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(newDigest))
+}
+
 // A new template config is not scheduled when there is no matching service, and
 // not unscheduled when removed
 func (suite *ConfigManagerSuite) TestNewTemplateNotScheduled() {
@@ -258,6 +382,41 @@ func (suite *ConfigManagerSuite) TestNewServiceBeforeTemplate_ServiceRemovedFirs
 	assertConfigsMatch(suite.T(), changes.Unschedule)
 }
 
+// A template extending unknown instance defaults is not resolved, but is
+// resolved once they are set and resolved again when they change
+func (suite *ConfigManagerSuite) TestTemplateWithChangedInstanceDefaults() {
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	tpl := integration.Config{Name: "template", Instances: []integration.Data{integration.Data("extends: tls\nhost: '%%host%%'\n")}, ADIdentifiers: []string{"my-service"}}
+	changes := suite.cm.processNewConfig(tpl)
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	changes = suite.cm.processNewService(myService.ADIdentifiers, myService)
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"template": {"tls": {"ssl": true}},
+	})
+	changes = suite.cm.processChangedInstanceDefaults(map[string]struct{}{"template": {}})
+	assertConfigsMatch(suite.T(), changes.Schedule, matchAll(matchName("template"), matchSvc(myService.ID)))
+	assertConfigsMatch(suite.T(), changes.Unschedule)
+	require.Equal(suite.T(), integration.Data("host: myhost\nssl: true\n"), changes.Schedule[0].Instances[0])
+	oldDigest := changes.Schedule[0].Digest()
+
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"template": {"tls": {"ssl": false}},
+	})
+	changes = suite.cm.processChangedInstanceDefaults(map[string]struct{}{"template": {}})
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(oldDigest))
+	assertConfigsMatch(suite.T(), changes.Schedule, matchAll(matchName("template"), matchSvc(myService.ID)))
+	require.Equal(suite.T(), integration.Data("host: myhost\nssl: false\n"), changes.Schedule[0].Instances[0])
+	newDigest := changes.Schedule[0].Digest()
+
+	changes = suite.cm.processDelService(context.TODO(), myService)
+	assertConfigsMatch(suite.T(), changes.Schedule)
+	assertConfigsMatch(suite.T(), changes.Unschedule, matchDigest(newDigest))
+}
+
 // Fuzz the config manager to ensure it doesn't "leak" configs -- that schedule
 // and unschedule calls are always properly paired.
 func (suite *ConfigManagerSuite) TestFuzz() {
@@ -30,13 +30,16 @@ import (
 type variableGetter func(ctx context.Context, key string, svc listeners.Service) (string, error)
 
 var templateVariables = map[string]variableGetter{
-	"host":     getHost,
-	"pid":      getPid,
-	"port":     getPort,
-	"hostname": getHostname,
-	"env":      getEnvvar,
-	"extra":    getAdditionalTplVariables,
-	"kube":     getAdditionalTplVariables,
+	"host":       getHost,
+	"pid":        getPid,
+	"port":       getPort,
+	"hostname":   getHostname,
+	"env":        getEnvvar,
+	"extra":      getAdditionalTplVariables,
+	"kube":       getAdditionalTplVariables,
+	"label":      getLabel,
+	"annotation": getAnnotation,
+	"container":  getContainerEnvvar,
 }
 
 type NoServiceError struct {
@@ -84,6 +87,10 @@ func Resolve(tpl integration.Config, svc listeners.Service) (integration.Config,
 	copy(resolvedConfig.InitConfig, tpl.InitConfig)
 	copy(resolvedConfig.Instances, tpl.Instances)
 
+	if err := resolvedConfig.MergeInstanceDefaults(); err != nil {
+		return resolvedConfig, fmt.Errorf("unable to merge the instance defaults: %w", err)
+	}
+
 	if resolvedConfig.IsCheckConfig() && !svc.IsReady(ctx) {
 		return resolvedConfig, errors.New("unable to resolve, service not ready")
 	}
@@ -603,6 +610,49 @@ func getAdditionalTplVariables(_ context.Context, tplVar string, svc listeners.S
 	return value, nil
 }
 
+// getWorkloadMetadata returns the value of a key of the workload metadata
+// returned by the given getter, if the service is discovered from workloadmeta
+func getWorkloadMetadata(tplVar string, svc listeners.Service, variable string, getter func(listeners.WorkloadMetadataService) map[string]string) (string, error) {
+	if svc == nil {
+		return "", NewNoServiceError(fmt.Sprintf("No service. %%%%%%%%%s_*%%%%%%%% is not allowed", variable))
+	}
+	if len(tplVar) == 0 {
+		return "", fmt.Errorf("%s name is missing, skipping service %s", variable, svc.GetServiceID())
+	}
+
+	metadataSvc, ok := svc.(listeners.WorkloadMetadataService)
+	if !ok {
+		return "", fmt.Errorf("%%%%%s_*%%%% is not supported by service %s", variable, svc.GetServiceID())
+	}
+
+	value, found := getter(metadataSvc)[tplVar]
+	if !found {
+		return "", fmt.Errorf("%s %s not found, skipping service %s", variable, tplVar, svc.GetServiceID())
+	}
+	return value, nil
+}
+
+// getLabel returns a label of the service's pod, or of its container outside
+// of Kubernetes
+func getLabel(_ context.Context, tplVar string, svc listeners.Service) (string, error) {
+	return getWorkloadMetadata(tplVar, svc, "label", listeners.WorkloadMetadataService.GetLabels)
+}
+
+// getAnnotation returns an annotation of the service's pod
+func getAnnotation(_ context.Context, tplVar string, svc listeners.Service) (string, error) {
+	return getWorkloadMetadata(tplVar, svc, "annotation", listeners.WorkloadMetadataService.GetAnnotations)
+}
+
+// getContainerEnvvar returns an environment variable of the service's
+// container. It resolves the template variables prefixed with container_env_.
+func getContainerEnvvar(_ context.Context, tplVar string, svc listeners.Service) (string, error) {
+	envVar, found := strings.CutPrefix(tplVar, "env_")
+	if !found {
+		return "", fmt.Errorf("invalid %%%%container_%s%%%% tag", tplVar)
+	}
+	return getWorkloadMetadata(envVar, svc, "container_env", listeners.WorkloadMetadataService.GetContainerEnv)
+}
+
 // getEnvvar returns a system environment variable if found
 func getEnvvar(_ context.Context, envVar string, svc listeners.Service) (string, error) {
 	if len(envVar) == 0 {
@@ -96,6 +96,28 @@ func (s *dummyService) GetExtraConfig(key string) (string, error) {
 func (s *dummyService) FilterTemplates(map[string]integration.Config) {
 }
 
+type dummyWorkloadService struct {
+	dummyService
+	Labels       map[string]string
+	Annotations  map[string]string
+	ContainerEnv map[string]string
+}
+
+// GetLabels returns dummy labels
+func (s *dummyWorkloadService) GetLabels() map[string]string {
+	return s.Labels
+}
+
+// GetAnnotations returns dummy annotations
+func (s *dummyWorkloadService) GetAnnotations() map[string]string {
+	return s.Annotations
+}
+
+// GetContainerEnv returns dummy environment variables
+func (s *dummyWorkloadService) GetContainerEnv() map[string]string {
+	return s.ContainerEnv
+}
+
 func TestGetFallbackHost(t *testing.T) {
 	ip, err := getFallbackHost(map[string]string{"bridge": "172.17.0.1"})
 	assert.Equal(t, "172.17.0.1", ip)
@@ -606,6 +628,72 @@ func TestResolve(t *testing.T) {
 				ServiceID:     "a5901276aed1",
 			},
 		},
+		{
+			testName: "workload %%label_*%%, %%annotation_*%% and %%container_env_*%%",
+			svc: &dummyWorkloadService{
+				dummyService: dummyService{
+					ID:            "a5901276aed1",
+					ADIdentifiers: []string{"redis"},
+				},
+				Labels:       map[string]string{"app.kubernetes.io/name": "cache"},
+				Annotations:  map[string]string{"example.com/db": "3"},
+				ContainerEnv: map[string]string{"DD_ENV": "prod"},
+			},
+			tpl: integration.Config{
+				Name:          "redis",
+				ADIdentifiers: []string{"redis"},
+				Instances:     []integration.Data{integration.Data("app: %%label_app.kubernetes.io/name%%\ndb: %%annotation_example.com/db%%\nenv: %%container_env_DD_ENV%%")},
+			},
+			out: integration.Config{
+				Name:          "redis",
+				ADIdentifiers: []string{"redis"},
+				Instances:     []integration.Data{integration.Data("app: cache\ndb: 3\nenv: prod\ntags:\n- foo:bar\n")},
+				ServiceID:     "a5901276aed1",
+			},
+		},
+		{
+			testName: "workload %%label_*%% not found",
+			svc: &dummyWorkloadService{
+				dummyService: dummyService{
+					ID:            "a5901276aed1",
+					ADIdentifiers: []string{"redis"},
+				},
+			},
+			tpl: integration.Config{
+				Name:          "redis",
+				ADIdentifiers: []string{"redis"},
+				Instances:     []integration.Data{integration.Data("app: %%label_app%%")},
+			},
+			errorString: "label app not found, skipping service a5901276aed1",
+		},
+		{
+			testName: "invalid %%container_*%%",
+			svc: &dummyWorkloadService{
+				dummyService: dummyService{
+					ID:            "a5901276aed1",
+					ADIdentifiers: []string{"redis"},
+				},
+			},
+			tpl: integration.Config{
+				Name:          "redis",
+				ADIdentifiers: []string{"redis"},
+				Instances:     []integration.Data{integration.Data("env: %%container_DD_ENV%%")},
+			},
+			errorString: "invalid %%container_DD_ENV%% tag",
+		},
+		{
+			testName: "%%annotation_*%% not supported by service",
+			svc: &dummyService{
+				ID:            "a5901276aed1",
+				ADIdentifiers: []string{"redis"},
+			},
+			tpl: integration.Config{
+				Name:          "redis",
+				ADIdentifiers: []string{"redis"},
+				Instances:     []integration.Data{integration.Data("db: %%annotation_example.com/db%%")},
+			},
+			errorString: "%%annotation_*%% is not supported by service a5901276aed1",
+		},
 		{
 			testName: "IPv6 %%host%%",
 			svc: &dummyService{
@@ -781,6 +869,38 @@ func newFakeContainerPorts() []listeners.ContainerPort {
 	}
 }
 
+func TestResolveInstanceDefaults(t *testing.T) {
+	integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{
+		"redisdb": {
+			"default": {"timeout": 5},
+			"tls":     {"port": "%%port%%", "ssl": true},
+		},
+	})
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	svc := &dummyService{
+		ID:            "a5901276aed1",
+		ADIdentifiers: []string{"redis"},
+		Hosts:         map[string]string{"bridge": "127.0.0.1"},
+		Ports:         newFakeContainerPorts(),
+	}
+	tpl := integration.Config{
+		Name:          "redisdb",
+		ADIdentifiers: []string{"redis"},
+		Instances:     []integration.Data{integration.Data("extends: tls\nhost: %%host%%\nssl: false")},
+	}
+	checksum := tpl.Digest()
+
+	resolved, err := Resolve(tpl, svc)
+	assert.NoError(t, err)
+	assert.Equal(t, []integration.Data{integration.Data("host: 127.0.0.1\nport: 3\nssl: false\ntags:\n- foo:bar\ntimeout: 5\n")}, resolved.Instances)
+	assert.Equal(t, checksum, tpl.Digest())
+
+	tpl.Instances = []integration.Data{integration.Data("extends: mtls\nhost: %%host%%")}
+	_, err = Resolve(tpl, svc)
+	assert.EqualError(t, err, `unable to merge the instance defaults: unknown instance defaults "mtls"`)
+}
+
 func BenchmarkResolve(b *testing.B) {
 	// Prepare envvars for test
 	b.Setenv("test_envvar_key", "test_value")
@@ -0,0 +1,192 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package autodiscovery
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/configresolver"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers"
+)
+
+// Template decisions, explaining why a template was or wasn't scheduled for a
+// service
+const (
+	// DecisionScheduled means that the template was resolved for the service
+	// and the resulting config is scheduled
+	DecisionScheduled = "scheduled"
+	// DecisionNoADIdentifierMatch means that none of the AD identifiers of the
+	// template are AD identifiers of the service
+	DecisionNoADIdentifierMatch = "no matching AD identifier"
+	// DecisionFiltered means that the service dropped the template, for
+	// instance because the pod annotations define other checks
+	DecisionFiltered = "filtered out by the service"
+	// DecisionResolveError means that the template variables, or the secrets,
+	// of the template couldn't be resolved for the service
+	DecisionResolveError = "resolution failed"
+	// DecisionNotScheduled means that the template was resolved but the
+	// resulting config isn't scheduled, usually because the resolution failed
+	// when the service was discovered
+	DecisionNotScheduled = "not scheduled"
+)
+
+// ServiceExplanation explains how autodiscovery handled a service: the
+// templates that could have been scheduled for it and what happened to them.
+type ServiceExplanation struct {
+	ServiceID     string                `json:"service_id"`
+	ADIdentifiers []string              `json:"ad_identifiers"`
+	Templates     []TemplateExplanation `json:"templates"`
+}
+
+// TemplateExplanation explains what happened to a template for a service
+type TemplateExplanation struct {
+	Name     string `json:"name"`
+	Provider string `json:"provider"`
+	Source   string `json:"source"`
+	// ADIdentifiers are the AD identifiers of the template and
+	// MatchedADIdentifiers the ones that are also AD identifiers of the
+	// service
+	ADIdentifiers        []string `json:"ad_identifiers"`
+	MatchedADIdentifiers []string `json:"matched_ad_identifiers"`
+	// Resolved is the template resolved for the service, without the secrets
+	// decrypted, if the resolution succeeded
+	Resolved     *integration.Config `json:"resolved,omitempty"`
+	ResolveError string              `json:"resolve_error,omitempty"`
+	Decision     string              `json:"decision"`
+}
+
+// ExplainResponse holds the explanations of the services matching an
+// identifier, along with the errors reported by the config providers, which
+// may explain why a template is missing.
+type ExplainResponse struct {
+	Services       []ServiceExplanation                        `json:"services"`
+	ProviderErrors map[string]map[string]providers.ErrorMsgSet `json:"provider_errors"`
+}
+
+// Explain explains how autodiscovery handled the services matching the given
+// identifier, which is either a service ID, such as "docker://<container ID>"
+// or "kubernetes_pod://<pod UID>", or the ID after the "://" separator.
+func (ac *AutoConfig) Explain(id string) ExplainResponse {
+	return ExplainResponse{
+		Services:       ac.cfgMgr.explainServices(id),
+		ProviderErrors: ac.GetAutodiscoveryErrors(),
+	}
+}
+
+// serviceIDMatches returns whether the given service ID matches the identifier
+// given to AutoConfig.Explain
+func serviceIDMatches(svcID string, id string) bool {
+	return svcID == id || strings.HasSuffix(svcID, "://"+id)
+}
+
+// explainServices implements configManager#explainServices.
+func (cm *reconcilingConfigManager) explainServices(id string) []ServiceExplanation {
+	cm.m.Lock()
+	defer cm.m.Unlock()
+
+	var explanations []ServiceExplanation
+	for svcID, svcAndADIDs := range cm.activeServices {
+		if !serviceIDMatches(svcID, id) {
+			continue
+		}
+
+		adIDs := map[string]struct{}{}
+		for _, adID := range svcAndADIDs.adIDs {
+			adIDs[adID] = struct{}{}
+		}
+
+		// the templates kept by the service, as done in reconcileService
+		matchingTemplates := map[string]integration.Config{}
+		for _, adID := range svcAndADIDs.adIDs {
+			for _, digest := range cm.templatesByADID.get(adID) {
+				matchingTemplates[digest] = cm.activeConfigs[digest]
+			}
+		}
+		keptTemplates := make(map[string]integration.Config, len(matchingTemplates))
+		for digest, tpl := range matchingTemplates {
+			keptTemplates[digest] = tpl
+		}
+		svcAndADIDs.svc.FilterTemplates(keptTemplates)
+
+		explanation := ServiceExplanation{
+			ServiceID:     svcID,
+			ADIdentifiers: svcAndADIDs.adIDs,
+		}
+
+		for digest, tpl := range cm.activeConfigs {
+			if !tpl.IsTemplate() {
+				continue
+			}
+
+			tplExplanation := TemplateExplanation{
+				Name:                 tpl.Name,
+				Provider:             tpl.Provider,
+				Source:               tpl.Source,
+				ADIdentifiers:        tpl.ADIdentifiers,
+				MatchedADIdentifiers: []string{},
+			}
+			for _, adID := range tpl.ADIdentifiers {
+				if _, found := adIDs[adID]; found {
+					tplExplanation.MatchedADIdentifiers = append(tplExplanation.MatchedADIdentifiers, adID)
+				}
+			}
+
+			if _, found := matchingTemplates[digest]; !found {
+				tplExplanation.Decision = DecisionNoADIdentifierMatch
+				explanation.Templates = append(explanation.Templates, tplExplanation)
+				continue
+			}
+			if _, found := keptTemplates[digest]; !found {
+				tplExplanation.Decision = DecisionFiltered
+				explanation.Templates = append(explanation.Templates, tplExplanation)
+				continue
+			}
+
+			// resolve the template again, without decrypting the secrets,
+			// to report the result of the template variables
+			resolved, err := configresolver.Resolve(tpl, svcAndADIDs.svc)
+			if err != nil {
+				tplExplanation.ResolveError = err.Error()
+			} else {
+				tplExplanation.Resolved = &resolved
+			}
+
+			if _, found := cm.serviceResolutions[svcID][digest]; found {
+				tplExplanation.Decision = DecisionScheduled
+			} else if err != nil {
+				tplExplanation.Decision = DecisionResolveError
+			} else {
+				tplExplanation.Decision = DecisionNotScheduled
+				if warnings := errorStats.getResolveWarnings()[tpl.Name]; len(warnings) > 0 {
+					tplExplanation.ResolveError = strings.Join(warnings, "; ")
+				}
+			}
+			explanation.Templates = append(explanation.Templates, tplExplanation)
+		}
+
+		// the templates that could apply to the service are listed first
+		sort.SliceStable(explanation.Templates, func(i, j int) bool {
+			a, b := explanation.Templates[i], explanation.Templates[j]
+			if aMatched, bMatched := len(a.MatchedADIdentifiers) > 0, len(b.MatchedADIdentifiers) > 0; aMatched != bMatched {
+				return aMatched
+			}
+			if a.Name != b.Name {
+				return a.Name < b.Name
+			}
+			return a.Source < b.Source
+		})
+
+		explanations = append(explanations, explanation)
+	}
+
+	sort.Slice(explanations, func(i, j int) bool {
+		return explanations[i].ServiceID < explanations[j].ServiceID
+	})
+
+	return explanations
+}
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package autodiscovery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+)
+
+func TestExplainServices(t *testing.T) {
+	cm := newReconcilingConfigManager()
+
+	svc := &dummyService{ID: "docker://abc", ADIdentifiers: []string{"redis", "docker://abc"}, Hosts: map[string]string{"bridge": "127.0.0.1"}}
+	svc.filterTemplates = func(configs map[string]integration.Config) {
+		for digest, config := range configs {
+			if strings.HasSuffix(config.Name, "-drop") {
+				delete(configs, digest)
+			}
+		}
+	}
+	cm.processNewService(svc.ADIdentifiers, svc)
+
+	cm.processNewConfig(integration.Config{Name: "redisdb", ADIdentifiers: []string{"redis"}, Instances: []integration.Data{integration.Data("host: %%host%%")}, Provider: "file"})
+	cm.processNewConfig(integration.Config{Name: "redisdb-port", ADIdentifiers: []string{"redis"}, Instances: []integration.Data{integration.Data("port: %%port%%")}})
+	cm.processNewConfig(integration.Config{Name: "redisdb-drop", ADIdentifiers: []string{"redis"}, Instances: []integration.Data{integration.Data("{}")}})
+	cm.processNewConfig(integration.Config{Name: "nginx", ADIdentifiers: []string{"nginx"}, Instances: []integration.Data{integration.Data("{}")}})
+	cm.processNewConfig(integration.Config{Name: "non-template"})
+
+	assert.Empty(t, cm.explainServices("def"))
+	assert.Len(t, cm.explainServices("docker://abc"), 1)
+
+	explanations := cm.explainServices("abc")
+	require.Len(t, explanations, 1)
+	explanation := explanations[0]
+	assert.Equal(t, "docker://abc", explanation.ServiceID)
+	assert.Equal(t, []string{"redis", "docker://abc"}, explanation.ADIdentifiers)
+
+	// the matching templates come first, sorted by name
+	require.Len(t, explanation.Templates, 4)
+
+	redisdb := explanation.Templates[0]
+	assert.Equal(t, "redisdb", redisdb.Name)
+	assert.Equal(t, "file", redisdb.Provider)
+	assert.Equal(t, []string{"redis"}, redisdb.MatchedADIdentifiers)
+	assert.Equal(t, DecisionScheduled, redisdb.Decision)
+	require.NotNil(t, redisdb.Resolved)
+	assert.Equal(t, "host: 127.0.0.1\n", string(redisdb.Resolved.Instances[0]))
+	assert.Empty(t, redisdb.ResolveError)
+
+	drop := explanation.Templates[1]
+	assert.Equal(t, "redisdb-drop", drop.Name)
+	assert.Equal(t, DecisionFiltered, drop.Decision)
+	assert.Nil(t, drop.Resolved)
+
+	port := explanation.Templates[2]
+	assert.Equal(t, "redisdb-port", port.Name)
+	assert.Equal(t, DecisionResolveError, port.Decision)
+	assert.Equal(t, "no port found for container docker://abc - ignoring it", port.ResolveError)
+	assert.Nil(t, port.Resolved)
+
+	nginx := explanation.Templates[3]
+	assert.Equal(t, "nginx", nginx.Name)
+	assert.Empty(t, nginx.MatchedADIdentifiers)
+	assert.Equal(t, DecisionNoADIdentifierMatch, nginx.Decision)
+}
@@ -113,6 +113,13 @@ type CommonInstanceConfig struct {
 	Name                  string   `yaml:"name"`
 	Namespace             string   `yaml:"namespace"`
 	NoIndex               bool     `yaml:"no_index"`
+	ScheduleOffset        int      `yaml:"schedule_offset,omitempty"`
+	ScheduleJitter        int      `yaml:"schedule_jitter,omitempty"`
+	Schedule              string   `yaml:"schedule,omitempty"`
+	OverlapPolicy         string   `yaml:"overlap_policy,omitempty"`
+	RunTimeout            *int     `yaml:"run_timeout,omitempty"`
+	BackoffFailures       *int     `yaml:"backoff_failures,omitempty"`
+	MaxBackoff            *int     `yaml:"max_backoff,omitempty"`
 }
 
 // CommonGlobalConfig holds the reserved fields for the yaml init_config data
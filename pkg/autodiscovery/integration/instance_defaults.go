@@ -0,0 +1,251 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package integration
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// ExtendsKey is the instance key listing the named instance defaults the
+	// instance extends
+	ExtendsKey = "extends"
+
+	// DefaultInstanceDefaults is the name of the instance defaults every
+	// instance of a check extends
+	DefaultInstanceDefaults = "default"
+)
+
+// InstanceDefaults are the named instance defaults of a check, indexed by name
+type InstanceDefaults map[string]RawMap
+
+// InstanceDefaultsCallback is called with the names of the checks whose
+// instance defaults changed
+type InstanceDefaultsCallback func(checkNames map[string]struct{})
+
+var (
+	instanceDefaultsMu sync.RWMutex
+	// instanceDefaults are the named instance defaults, indexed by check name
+	instanceDefaults = map[string]InstanceDefaults{}
+
+	instanceDefaultsCallbacksMu sync.Mutex
+	instanceDefaultsCallbacks   []InstanceDefaultsCallback
+)
+
+// RegisterInstanceDefaultsCallback registers a callback called with the names
+// of the checks whose instance defaults changed, every time they're set
+func RegisterInstanceDefaultsCallback(callback InstanceDefaultsCallback) {
+	instanceDefaultsCallbacksMu.Lock()
+	defer instanceDefaultsCallbacksMu.Unlock()
+	instanceDefaultsCallbacks = append(instanceDefaultsCallbacks, callback)
+}
+
+// SetInstanceDefaults replaces the named instance defaults of every check,
+// indexed by check name. The callbacks registered with
+// RegisterInstanceDefaultsCallback are then called with the names of the
+// checks whose instance defaults changed.
+func SetInstanceDefaults(defaults map[string]InstanceDefaults) {
+	instanceDefaultsMu.Lock()
+	changedChecks := map[string]struct{}{}
+	for checkName, checkDefaults := range defaults {
+		if !reflect.DeepEqual(checkDefaults, instanceDefaults[checkName]) {
+			changedChecks[checkName] = struct{}{}
+		}
+	}
+	for checkName := range instanceDefaults {
+		if _, found := defaults[checkName]; !found {
+			changedChecks[checkName] = struct{}{}
+		}
+	}
+	instanceDefaults = defaults
+	instanceDefaultsMu.Unlock()
+
+	if len(changedChecks) == 0 {
+		return
+	}
+
+	instanceDefaultsCallbacksMu.Lock()
+	callbacks := append([]InstanceDefaultsCallback{}, instanceDefaultsCallbacks...)
+	instanceDefaultsCallbacksMu.Unlock()
+
+	// the callbacks are called without holding instanceDefaultsMu, as they are
+	// expected to merge the instance defaults again
+	for _, callback := range callbacks {
+		callback(changedChecks)
+	}
+}
+
+// GetInstanceDefaults returns the named instance defaults of a check
+func GetInstanceDefaults(checkName string) InstanceDefaults {
+	instanceDefaultsMu.RLock()
+	defer instanceDefaultsMu.RUnlock()
+	return instanceDefaults[checkName]
+}
+
+// MergeInstanceDefaults merges the instances of the config with the named
+// instance defaults of its check
+func (c *Config) MergeInstanceDefaults() error {
+	defaults := GetInstanceDefaults(c.Name)
+
+	// we cannot update in place as, being a slice, it would modify the
+	// instances of the copies of the config as well
+	instances := make([]Data, 0, len(c.Instances))
+	for _, inputInstance := range c.Instances {
+		instance := make(Data, len(inputInstance))
+		copy(instance, inputInstance)
+		if err := instance.MergeInstanceDefaults(defaults); err != nil {
+			return err
+		}
+		instances = append(instances, instance)
+	}
+	c.Instances = instances
+
+	return nil
+}
+
+// MergeInstanceDefaults merges the instance with the "default" instance
+// defaults, then with the ones listed under its "extends" key, in this order.
+// The values of the instance take precedence over the defaults: maps are
+// merged recursively, the tags are appended and the other values are
+// overridden. The instance is left untouched when it extends no defaults.
+func (c *Data) MergeInstanceDefaults(defaults InstanceDefaults) error {
+	// Percent character is not allowed in unquoted yaml strings, so the
+	// template variables of templates are escaped while merging.
+	rawConfig := RawMap{}
+	err := yaml.Unmarshal([]byte(strings.ReplaceAll(string(*c), "%%", "‰")), &rawConfig)
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	if _, found := defaults[DefaultInstanceDefaults]; found {
+		names = append(names, DefaultInstanceDefaults)
+	}
+	if extends, found := rawConfig[ExtendsKey]; found {
+		extendedNames, err := getExtendedNames(extends)
+		if err != nil {
+			return err
+		}
+		names = append(names, extendedNames...)
+		delete(rawConfig, ExtendsKey)
+	} else if len(names) == 0 {
+		return nil
+	}
+
+	merged := RawMap{}
+	for _, name := range names {
+		instanceDefaults, found := defaults[name]
+		if !found {
+			return fmt.Errorf("unknown instance defaults %q", name)
+		}
+		merged = mergeRawMaps(merged, instanceDefaults, true)
+	}
+	merged = mergeRawMaps(merged, rawConfig, true)
+
+	out, err := yaml.Marshal(&merged)
+	if err != nil {
+		return err
+	}
+	*c = Data(strings.ReplaceAll(string(out), "‰", "%%"))
+
+	return nil
+}
+
+// getExtendedNames returns the names listed under the "extends" key of an
+// instance, either a single name or a list of names
+func getExtendedNames(extends interface{}) ([]string, error) {
+	switch extends := extends.(type) {
+	case string:
+		return []string{extends}, nil
+	case []interface{}:
+		names := make([]string, 0, len(extends))
+		for _, name := range extends {
+			nameStr, ok := name.(string)
+			if !ok {
+				return nil, fmt.Errorf("invalid instance defaults name %v in %q: a string is expected", name, ExtendsKey)
+			}
+			names = append(names, nameStr)
+		}
+		return names, nil
+	}
+	return nil, fmt.Errorf("invalid %q value %v: a name or a list of names is expected", ExtendsKey, extends)
+}
+
+// mergeRawMaps returns the merge of two maps, the values of the override map
+// taking precedence. The maps are merged recursively and, at the top level,
+// the tags are appended.
+func mergeRawMaps(base RawMap, override RawMap, topLevel bool) RawMap {
+	merged := make(RawMap, len(base)+len(override))
+	for key, value := range base {
+		merged[key] = value
+	}
+
+	for key, value := range override {
+		baseValue, found := merged[key]
+		if !found {
+			merged[key] = value
+			continue
+		}
+
+		baseMap, baseIsMap := asRawMap(baseValue)
+		overrideMap, overrideIsMap := asRawMap(value)
+		if baseIsMap && overrideIsMap {
+			merged[key] = mergeRawMaps(baseMap, overrideMap, false)
+			continue
+		}
+
+		baseTags, baseIsList := baseValue.([]interface{})
+		overrideTags, overrideIsList := value.([]interface{})
+		if topLevel && key == "tags" && baseIsList && overrideIsList {
+			merged[key] = appendUniqueTags(baseTags, overrideTags)
+			continue
+		}
+
+		merged[key] = value
+	}
+
+	return merged
+}
+
+// asRawMap returns the value as a map, as decoded by the YAML parser
+func asRawMap(value interface{}) (RawMap, bool) {
+	switch value := value.(type) {
+	case RawMap:
+		return value, true
+	case map[interface{}]interface{}:
+		return value, true
+	case map[string]interface{}:
+		// the maps set in the agent configuration have string keys
+		rawMap := make(RawMap, len(value))
+		for k, v := range value {
+			rawMap[k] = v
+		}
+		return rawMap, true
+	}
+	return nil, false
+}
+
+// appendUniqueTags appends the tags that are not already present
+func appendUniqueTags(tags []interface{}, others []interface{}) []interface{} {
+	seen := make(map[string]struct{}, len(tags)+len(others))
+	result := make([]interface{}, 0, len(tags)+len(others))
+	for _, list := range [][]interface{}{tags, others} {
+		for _, tag := range list {
+			tagStr := fmt.Sprint(tag)
+			if _, found := seen[tagStr]; found {
+				continue
+			}
+			seen[tagStr] = struct{}{}
+			result = append(result, tag)
+		}
+	}
+	return result
+}
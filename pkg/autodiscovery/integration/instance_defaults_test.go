@@ -0,0 +1,153 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package integration
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	yaml "gopkg.in/yaml.v2"
+)
+
+func testInstanceDefaults(t *testing.T) InstanceDefaults {
+	defaults := InstanceDefaults{}
+	require.NoError(t, yaml.Unmarshal([]byte(`
+default:
+  tags: ["team:db"]
+  timeout: 5
+tls:
+  timeout: 10
+  tls:
+    verify: true
+    ca_cert: /etc/ssl/ca.pem
+`), &defaults))
+	return defaults
+}
+
+func TestDataMergeInstanceDefaults(t *testing.T) {
+	defaults := testInstanceDefaults(t)
+
+	tests := []struct {
+		name     string
+		instance string
+		expected string
+		err      string
+	}{
+		{
+			name:     "default only",
+			instance: "host: localhost\ntags: [\"env:prod\", \"team:db\"]",
+			expected: "host: localhost\ntags: [\"team:db\", \"env:prod\"]\ntimeout: 5",
+		},
+		{
+			name:     "extends a single name",
+			instance: "host: localhost\nextends: tls\ntls:\n  verify: false",
+			expected: "host: localhost\ntags: [\"team:db\"]\ntimeout: 10\ntls:\n  verify: false\n  ca_cert: /etc/ssl/ca.pem",
+		},
+		{
+			name:     "extends a list of names",
+			instance: "extends: [tls]\ntimeout: 1",
+			expected: "tags: [\"team:db\"]\ntimeout: 1\ntls:\n  verify: true\n  ca_cert: /etc/ssl/ca.pem",
+		},
+		{
+			name:     "template variables",
+			instance: "extends: tls\nhost: %%host%%\nport: \"%%port%%\"",
+			expected: "host: %%host%%\nport: \"%%port%%\"\ntags: [\"team:db\"]\ntimeout: 10\ntls:\n  verify: true\n  ca_cert: /etc/ssl/ca.pem",
+		},
+		{
+			name:     "unknown name",
+			instance: "extends: mtls",
+			err:      `unknown instance defaults "mtls"`,
+		},
+		{
+			name:     "invalid extends",
+			instance: "extends: {tls: true}",
+			err:      `invalid "extends" value map[tls:true]: a name or a list of names is expected`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			instance := Data(test.instance)
+			err := instance.MergeInstanceDefaults(defaults)
+			if test.err != "" {
+				assert.EqualError(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+
+			// template variables are left unquoted
+			var actual, expected RawMap
+			require.NoError(t, yaml.Unmarshal([]byte(strings.ReplaceAll(string(instance), "%%", "‰")), &actual))
+			require.NoError(t, yaml.Unmarshal([]byte(strings.ReplaceAll(test.expected, "%%", "‰")), &expected))
+			assert.Equal(t, expected, actual)
+		})
+	}
+
+	// the defaults are left untouched
+	assert.Equal(t, testInstanceDefaults(t), defaults)
+}
+
+func TestDataMergeInstanceDefaultsNoDefaults(t *testing.T) {
+	instance := Data("host: localhost\n")
+	require.NoError(t, instance.MergeInstanceDefaults(nil))
+	assert.Equal(t, Data("host: localhost\n"), instance)
+}
+
+func TestConfigMergeInstanceDefaults(t *testing.T) {
+	SetInstanceDefaults(map[string]InstanceDefaults{"redisdb": testInstanceDefaults(t)})
+	defer SetInstanceDefaults(map[string]InstanceDefaults{})
+
+	instance := Data("extends: tls\nhost: localhost\n")
+	config := Config{Name: "redisdb", Instances: []Data{instance}}
+	require.NoError(t, config.MergeInstanceDefaults())
+	assert.Equal(t, Data("extends: tls\nhost: localhost\n"), instance)
+	assert.Contains(t, string(config.Instances[0]), "ca_cert: /etc/ssl/ca.pem")
+	assert.NotContains(t, string(config.Instances[0]), "extends")
+
+	config = Config{Name: "mysql", Instances: []Data{Data("extends: tls\n")}}
+	assert.EqualError(t, config.MergeInstanceDefaults(), `unknown instance defaults "tls"`)
+}
+
+func TestSetInstanceDefaultsCallback(t *testing.T) {
+	defer SetInstanceDefaults(map[string]InstanceDefaults{})
+
+	var changedChecks []map[string]struct{}
+	RegisterInstanceDefaultsCallback(func(checkNames map[string]struct{}) {
+		changedChecks = append(changedChecks, checkNames)
+	})
+
+	SetInstanceDefaults(map[string]InstanceDefaults{
+		"redisdb": testInstanceDefaults(t),
+		"mysql":   testInstanceDefaults(t),
+	})
+	require.Len(t, changedChecks, 1)
+	assert.Equal(t, map[string]struct{}{"redisdb": {}, "mysql": {}}, changedChecks[0])
+
+	// only the checks whose instance defaults changed or were removed are notified
+	changedDefaults := testInstanceDefaults(t)
+	changedDefaults["default"]["timeout"] = 20
+	SetInstanceDefaults(map[string]InstanceDefaults{
+		"redisdb": changedDefaults,
+	})
+	require.Len(t, changedChecks, 2)
+	assert.Equal(t, map[string]struct{}{"redisdb": {}, "mysql": {}}, changedChecks[1])
+
+	SetInstanceDefaults(map[string]InstanceDefaults{
+		"redisdb": changedDefaults,
+		"mysql":   testInstanceDefaults(t),
+	})
+	require.Len(t, changedChecks, 3)
+	assert.Equal(t, map[string]struct{}{"mysql": {}}, changedChecks[2])
+
+	// nothing changed
+	SetInstanceDefaults(map[string]InstanceDefaults{
+		"redisdb": changedDefaults,
+		"mysql":   testInstanceDefaults(t),
+	})
+	assert.Len(t, changedChecks, 3)
+}
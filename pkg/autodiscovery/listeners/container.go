@@ -115,14 +115,18 @@ func (l *ContainerListener) createContainerService(entity workloadmeta.Entity) {
 			containerImg.RawName,
 			container.Labels,
 		),
-		ports:    ports,
-		pid:      container.PID,
-		hostname: container.Hostname,
+		ports:        ports,
+		pid:          container.PID,
+		hostname:     container.Hostname,
+		labels:       container.Labels,
+		containerEnv: container.EnvVars,
 	}
 
 	if pod != nil {
 		svc.hosts = map[string]string{"pod": pod.IP}
 		svc.ready = pod.Ready
+		svc.labels = pod.Labels
+		svc.annotations = pod.Annotations
 
 		svc.metricsExcluded = l.IsExcluded(
 			containers.MetricsFilter,
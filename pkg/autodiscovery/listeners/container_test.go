@@ -260,9 +260,10 @@ func TestCreateContainerService(t *testing.T) {
 							"gcr.io/foobar",
 							"foobar",
 						},
-						hosts: map[string]string{"pod": pod.IP},
-						ports: []ContainerPort{},
-						ready: pod.Ready,
+						hosts:       map[string]string{"pod": pod.IP},
+						ports:       []ContainerPort{},
+						ready:       pod.Ready,
+						annotations: pod.Annotations,
 					},
 				},
 			},
@@ -93,6 +93,8 @@ func (l *KubeletListener) createPodService(
 		hosts:         map[string]string{"pod": pod.IP},
 		ports:         ports,
 		ready:         true,
+		labels:        pod.Labels,
+		annotations:   pod.Annotations,
 	}
 
 	svcID := buildSvcID(pod.GetID())
@@ -157,7 +159,10 @@ func (l *KubeletListener) createContainerService(
 			"namespace": pod.Namespace,
 			"pod_uid":   pod.ID,
 		},
-		hosts: map[string]string{"pod": pod.IP},
+		hosts:        map[string]string{"pod": pod.IP},
+		labels:       pod.Labels,
+		annotations:  pod.Annotations,
+		containerEnv: container.EnvVars,
 
 		// Exclude non-running containers (including init containers)
 		// from metrics collection but keep them for collecting logs.
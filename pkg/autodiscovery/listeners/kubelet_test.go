@@ -406,8 +406,9 @@ func TestKubeletCreateContainerService(t *testing.T) {
 						hosts: map[string]string{
 							"pod": "127.0.0.1",
 						},
-						ports:      []ContainerPort{},
-						checkNames: []string{"customcheck"},
+						ports:       []ContainerPort{},
+						checkNames:  []string{"customcheck"},
+						annotations: podWithAnnotations.Annotations,
 						extraConfig: map[string]string{
 							"namespace": podNamespace,
 							"pod_name":  podName,
@@ -450,8 +451,9 @@ func TestKubeletCreateContainerService(t *testing.T) {
 						hosts: map[string]string{
 							"pod": "127.0.0.1",
 						},
-						ports:      []ContainerPort{},
-						checkNames: []string{"customcheck"},
+						ports:       []ContainerPort{},
+						checkNames:  []string{"customcheck"},
+						annotations: podWithMetricsExcludeAnnotation.Annotations,
 						extraConfig: map[string]string{
 							"namespace": podNamespace,
 							"pod_name":  podName,
@@ -484,8 +486,9 @@ func TestKubeletCreateContainerService(t *testing.T) {
 						hosts: map[string]string{
 							"pod": "127.0.0.1",
 						},
-						ports:      []ContainerPort{},
-						checkNames: []string{"customcheck"},
+						ports:       []ContainerPort{},
+						checkNames:  []string{"customcheck"},
+						annotations: podWithLogsExcludeAnnotation.Annotations,
 						extraConfig: map[string]string{
 							"namespace": podNamespace,
 							"pod_name":  podName,
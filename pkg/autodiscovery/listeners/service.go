@@ -32,11 +32,15 @@ type service struct {
 	ready           bool
 	checkNames      []string
 	extraConfig     map[string]string
+	labels          map[string]string
+	annotations     map[string]string
+	containerEnv    map[string]string
 	metricsExcluded bool
 	logsExcluded    bool
 }
 
 var _ Service = &service{}
+var _ WorkloadMetadataService = &service{}
 
 // GetServiceID returns the AD entity ID of the service.
 func (s *service) GetServiceID() string {
@@ -203,6 +207,23 @@ func (s *service) GetExtraConfig(key string) (string, error) {
 	return result, nil
 }
 
+// GetLabels returns the labels of the service's pod, or of its container when
+// it doesn't belong to a pod.
+func (s *service) GetLabels() map[string]string {
+	return s.labels
+}
+
+// GetAnnotations returns the annotations of the service's pod.
+func (s *service) GetAnnotations() map[string]string {
+	return s.annotations
+}
+
+// GetContainerEnv returns the environment variables of the service's
+// container. Only the variables collected by workloadmeta are available.
+func (s *service) GetContainerEnv() map[string]string {
+	return s.containerEnv
+}
+
 // svcEqual checks that two Services are equal to each other by doing a deep
 // equality check on data returned by most of Service's methods. Methods not
 // checked are HasFilter and GetExtraConfig.
@@ -254,5 +275,18 @@ func svcEqual(a, b Service) bool {
 		return false
 	}
 
+	// the workload metadata can be used by template variables, so the
+	// configs need to be resolved again when it changes
+	metaA, okA := a.(WorkloadMetadataService)
+	metaB, okB := b.(WorkloadMetadataService)
+	if okA != okB {
+		return false
+	}
+	if okA && (!reflect.DeepEqual(metaA.GetLabels(), metaB.GetLabels()) ||
+		!reflect.DeepEqual(metaA.GetAnnotations(), metaB.GetAnnotations()) ||
+		!reflect.DeepEqual(metaA.GetContainerEnv(), metaB.GetContainerEnv())) {
+		return false
+	}
+
 	return a.IsReady(ctx) == b.IsReady(ctx)
 }
@@ -47,6 +47,16 @@ type Service interface {
 	FilterTemplates(map[string]integration.Config)
 }
 
+// WorkloadMetadataService is implemented by the services discovered from
+// workloadmeta entities. It gives access to the metadata of the workload, used
+// by the %%label_*%%, %%annotation_*%% and %%container_env_*%% template
+// variables.
+type WorkloadMetadataService interface {
+	GetLabels() map[string]string       // labels of the pod, or of the container outside of Kubernetes
+	GetAnnotations() map[string]string  // annotations of the pod
+	GetContainerEnv() map[string]string // environment variables of the container collected by workloadmeta
+}
+
 // ServiceListener monitors running services and triggers check (un)scheduling
 //
 // It holds a cache of running services, listens to new/killed services and
@@ -37,18 +37,21 @@ type configFormat struct {
 }
 
 type configPkg struct {
-	confs    []integration.Config
-	defaults []integration.Config
-	others   []integration.Config
+	confs            []integration.Config
+	defaults         []integration.Config
+	others           []integration.Config
+	instanceDefaults integration.InstanceDefaults
 }
 
 type configEntry struct {
-	conf       integration.Config
-	name       string
-	isDefault  bool
-	isMetric   bool
-	isLogsOnly bool
-	err        error
+	conf               integration.Config
+	name               string
+	isDefault          bool
+	isMetric           bool
+	isLogsOnly         bool
+	isInstanceDefaults bool
+	instanceDefaults   integration.InstanceDefaults
+	err                error
 }
 
 var reader *configFilesReader
@@ -165,6 +168,7 @@ func (r *configFilesReader) read(keep FilterFunc) ([]integration.Config, map[str
 	configs := []integration.Config{}
 	configNames := make(map[string]struct{}) // use this map as a python set
 	defaultConfigs := []integration.Config{}
+	instanceDefaults := getConfiguredInstanceDefaults()
 
 	for _, path := range r.paths {
 		log.Infof("Searching for configuration files at: %s", path)
@@ -191,12 +195,22 @@ func (r *configFilesReader) read(keep FilterFunc) ([]integration.Config, map[str
 					configs = append(configs, dirConfigs.confs...)
 					configNames[dirConfigs.confs[0].Name] = struct{}{}
 				}
+				if len(dirConfigs.instanceDefaults) > 0 {
+					integrationName := strings.TrimSuffix(fileEntry.Name(), ".d")
+					if instanceDefaults[integrationName] == nil {
+						instanceDefaults[integrationName] = integration.InstanceDefaults{}
+					}
+					// the instance defaults of conf.d take precedence over the ones of datadog.yaml
+					for name, defaults := range dirConfigs.instanceDefaults {
+						instanceDefaults[integrationName][name] = defaults
+					}
+				}
 				continue
 			}
 			var entry configEntry
 			entry, integrationErrors = collectEntry(fileEntry, path, "", integrationErrors)
-			// we don't collect metric files from the root dir (which check is it for? that's nonsensical!)
-			if entry.err != nil || entry.isMetric {
+			// we don't collect metric and instance defaults files from the root dir (which check is it for? that's nonsensical!)
+			if entry.err != nil || entry.isMetric || entry.isInstanceDefaults {
 				// logging is handled in collectEntry
 				continue
 			}
@@ -229,9 +243,22 @@ func (r *configFilesReader) read(keep FilterFunc) ([]integration.Config, map[str
 		}
 	}
 
+	integration.SetInstanceDefaults(instanceDefaults)
+
 	return configs, integrationErrors
 }
 
+// getConfiguredInstanceDefaults returns the named instance defaults of the
+// checks set in the agent configuration, indexed by check name
+func getConfiguredInstanceDefaults() map[string]integration.InstanceDefaults {
+	instanceDefaults := map[string]integration.InstanceDefaults{}
+	if err := config.Datadog.UnmarshalKey("autoconfig_instance_defaults", &instanceDefaults); err != nil {
+		log.Errorf("Invalid autoconfig_instance_defaults, ignoring them: %s", err)
+		return map[string]integration.InstanceDefaults{}
+	}
+	return instanceDefaults
+}
+
 // collectEntry collects a file entry and return it's configuration if valid
 // the integrationName can be manually provided else it'll use the filename
 func collectEntry(file os.DirEntry, path string, integrationName string, integrationErrors map[string]string) (configEntry, map[string]string) {
@@ -256,6 +283,22 @@ func collectEntry(file os.DirEntry, path string, integrationName string, integra
 		entry.isMetric = true
 	}
 
+	if fileName == "instance_defaults.yaml" || fileName == "instance_defaults.yml" {
+		entry.isInstanceDefaults = true
+		entry.name = integrationName
+
+		var err error
+		entry.instanceDefaults, err = GetInstanceDefaultsFromFile(absPath)
+		if err != nil {
+			log.Warnf("%s is not a valid instance defaults file: %s", absPath, err)
+			entry.err = errors.New("Invalid instance defaults file format")
+			return entry, integrationErrors
+		}
+
+		log.Debug("Found valid instance defaults in file:", absPath)
+		return entry, integrationErrors
+	}
+
 	if ext == defaultExt {
 		entry.isDefault = true
 		ext = filepath.Ext(strings.TrimSuffix(fileName, defaultExt))
@@ -299,20 +342,21 @@ func collectDir(parentPath string, folder os.DirEntry, integrationErrors map[str
 	configs := []integration.Config{}
 	defaultConfigs := []integration.Config{}
 	otherConfigs := []integration.Config{}
+	instanceDefaults := integration.InstanceDefaults{}
 	const dirExt string = ".d"
 	dirPath := filepath.Join(parentPath, folder.Name())
 
 	if filepath.Ext(folder.Name()) != dirExt {
 		// the name of this directory isn't in the form `integrationName.d`, skip it
 		log.Debugf("Not a config folder, skipping directory: %s", dirPath)
-		return configPkg{configs, defaultConfigs, otherConfigs}, integrationErrors
+		return configPkg{configs, defaultConfigs, otherConfigs, instanceDefaults}, integrationErrors
 	}
 
 	// search for yaml files within this directory
 	subEntries, err := os.ReadDir(dirPath)
 	if err != nil {
 		log.Warnf("Skipping config directory %s: %s", dirPath, err)
-		return configPkg{configs, defaultConfigs, otherConfigs}, integrationErrors
+		return configPkg{configs, defaultConfigs, otherConfigs, instanceDefaults}, integrationErrors
 	}
 
 	// strip the trailing `.d`
@@ -329,7 +373,11 @@ func collectDir(parentPath string, folder os.DirEntry, integrationErrors map[str
 			}
 			// determine if a check has to be run by default by
 			// searching for integration.yaml.default files
-			if entry.isDefault {
+			if entry.isInstanceDefaults {
+				for name, defaults := range entry.instanceDefaults {
+					instanceDefaults[name] = defaults
+				}
+			} else if entry.isDefault {
 				defaultConfigs = append(defaultConfigs, entry.conf)
 			} else if entry.isMetric || entry.isLogsOnly {
 				otherConfigs = append(otherConfigs, entry.conf)
@@ -339,7 +387,7 @@ func collectDir(parentPath string, folder os.DirEntry, integrationErrors map[str
 		}
 	}
 
-	return configPkg{confs: configs, defaults: defaultConfigs, others: otherConfigs}, integrationErrors
+	return configPkg{confs: configs, defaults: defaultConfigs, others: otherConfigs, instanceDefaults: instanceDefaults}, integrationErrors
 }
 
 // GetIntegrationConfigFromFile returns an instance of integration.Config if `fpath` points to a valid config file
@@ -363,6 +411,31 @@ func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, error
 		log.Warnf("reading config file %v: %v\n", fpath, strictErr)
 	}
 
+	return buildIntegrationConfig(name, "file:"+fpath, cf)
+}
+
+// GetInstanceDefaultsFromFile returns the named instance defaults of a check
+// if `fpath` points to a valid instance defaults file
+func GetInstanceDefaultsFromFile(fpath string) (integration.InstanceDefaults, error) {
+	instanceDefaults := integration.InstanceDefaults{}
+
+	yamlFile, err := os.ReadFile(fpath)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(yamlFile, &instanceDefaults); err != nil {
+		return nil, err
+	}
+
+	return instanceDefaults, nil
+}
+
+// buildIntegrationConfig returns an instance of integration.Config built from
+// a parsed configuration, read from the given source
+func buildIntegrationConfig(name string, source string, cf configFormat) (integration.Config, error) {
+	conf := integration.Config{Name: name}
+
 	// If no valid instances were found & this is neither a metrics file, nor a logs file
 	// this is not a valid configuration file
 	if cf.MetricConfig == nil && cf.LogsConfig == nil && len(cf.Instances) < 1 {
@@ -386,7 +459,7 @@ func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, error
 			tags := config.GetGlobalConfiguredTags(false)
 			err := dataConf.MergeAdditionalTags(tags)
 			if err != nil {
-				log.Debugf("Could not add agent-level tags to instance of %v: %v", fpath, err)
+				log.Debugf("Could not add agent-level tags to instance of %v: %v", source, err)
 			}
 		}
 		conf.Instances = append(conf.Instances, dataConf)
@@ -429,9 +502,9 @@ func GetIntegrationConfigFromFile(name, fpath string) (integration.Config, error
 		}
 	}
 
-	conf.Source = "file:" + fpath
+	conf.Source = source
 
-	return conf, err
+	return conf, nil
 }
 
 func containsString(slice []string, str string) bool {
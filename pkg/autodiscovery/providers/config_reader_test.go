@@ -100,6 +100,42 @@ func TestReadConfigFiles(t *testing.T) {
 	require.Equal(t, configs[0].Name, "baz")
 }
 
+func TestReadInstanceDefaults(t *testing.T) {
+	tempDir := t.TempDir()
+	checkDir := path.Join(tempDir, "redisdb.d")
+	require.NoError(t, os.Mkdir(checkDir, 0o770))
+	require.NoError(t, os.WriteFile(path.Join(checkDir, "instance_defaults.yaml"), []byte(`
+tls:
+  ssl: true
+`), 0o660))
+	require.NoError(t, os.WriteFile(path.Join(tempDir, "instance_defaults.yaml"), []byte(`
+tls:
+  ssl: false
+`), 0o660))
+
+	mockConfig := config.Mock(t)
+	mockConfig.Set("autoconfig_instance_defaults", map[string]interface{}{
+		"redisdb": map[string]interface{}{
+			"default": map[string]interface{}{"timeout": 5},
+			"tls":     map[string]interface{}{"ssl": false},
+		},
+	})
+	defer integration.SetInstanceDefaults(map[string]integration.InstanceDefaults{})
+
+	ResetReader([]string{tempDir})
+	configs, errors, err := ReadConfigFiles(GetAll)
+	require.Nil(t, err)
+	assert.Empty(t, configs)
+	assert.Empty(t, errors)
+
+	// the instance defaults of conf.d take precedence over the ones of
+	// datadog.yaml, and the ones of the root directory are ignored
+	defaults := integration.GetInstanceDefaults("redisdb")
+	assert.Equal(t, integration.RawMap{"timeout": 5}, defaults["default"])
+	assert.Equal(t, integration.RawMap{"ssl": true}, defaults["tls"])
+	assert.Nil(t, integration.GetInstanceDefaults(""))
+}
+
 func TestReadConfigFilesCache(t *testing.T) {
 	testFileContent := `
 init_config:
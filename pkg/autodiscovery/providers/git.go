@@ -0,0 +1,441 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	gitCommandTimeout = 5 * time.Minute
+	gitCheckoutsDir   = "git-config-provider"
+)
+
+var (
+	gitProviders   = map[*GitConfigProvider]struct{}{}
+	gitProvidersMu sync.RWMutex
+)
+
+// GitRepositoryStatus is the status of a repository synced by a git config
+// provider, reported by `agent status` and `agent configcheck`
+type GitRepositoryStatus struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch,omitempty"`
+	// Commit is the commit the configs are currently collected from
+	Commit            string `json:"commit"`
+	SignatureVerified bool   `json:"signature_verified"`
+	LastSync          string `json:"last_sync,omitempty"`
+	LastError         string `json:"last_error,omitempty"`
+}
+
+// GitConfigProvider implements the ConfigProvider interface. It collects the
+// integration configs committed to a git repository, laid out as in the conf.d
+// directory, either from a local checkout or from a remote repository fetched
+// into the run path at every poll.
+type GitConfigProvider struct {
+	repository string
+	// displayedRepository is the repository without the credentials its URL
+	// may hold, to be used wherever the repository is displayed
+	displayedRepository string
+	branch              string
+	dir                 string
+	// localPath is the path of the repository when it's a local checkout,
+	// otherwise checkoutPath is the path of the bare clone of the remote
+	// repository
+	localPath    string
+	checkoutPath string
+
+	verifySignatures   bool
+	allowedSignersFile string
+
+	// syncMu serializes the syncs of the repository, which run git commands
+	// and can take up to gitCommandTimeout
+	syncMu  sync.Mutex
+	configs []integration.Config
+
+	// statusMu protects the fields below, which are written while holding
+	// syncMu too, so that the status can be read while a sync is in progress
+
+	// commit is the commit of the last collected configs
+	commit       string
+	lastSync     time.Time
+	lastErr      error
+	configErrors map[string]ErrorMsgSet
+	statusMu     sync.RWMutex
+}
+
+// NewGitConfigProvider creates a new GitConfigProvider syncing the repository
+// set as template_url, which is either the path of a local checkout or the URL
+// of a remote repository, and reading the configs in its template_dir
+// directory.
+func NewGitConfigProvider(providerConfig *config.ConfigurationProviders) (ConfigProvider, error) {
+	if providerConfig == nil || providerConfig.TemplateURL == "" {
+		return nil, errors.New("the template_url of the git config provider is not set")
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return nil, fmt.Errorf("the git config provider requires the git command: %s", err)
+	}
+
+	p := &GitConfigProvider{
+		repository:          providerConfig.TemplateURL,
+		displayedRepository: stripURLCredentials(providerConfig.TemplateURL),
+		branch:              providerConfig.Branch,
+		dir:                 path.Clean(strings.Trim(filepath.ToSlash(providerConfig.TemplateDir), "/")),
+		verifySignatures:    providerConfig.VerifySignatures,
+		allowedSignersFile:  providerConfig.AllowedSignersFile,
+		configErrors:        make(map[string]ErrorMsgSet),
+	}
+
+	if info, err := os.Stat(providerConfig.TemplateURL); err == nil && info.IsDir() {
+		p.localPath = providerConfig.TemplateURL
+	} else {
+		// each remote repository has its own clone, so that several providers
+		// can sync different repositories
+		h := sha256.Sum256([]byte(providerConfig.TemplateURL))
+		p.checkoutPath = filepath.Join(config.Datadog.GetString("run_path"), gitCheckoutsDir, fmt.Sprintf("%x", h[:8]))
+	}
+
+	gitProvidersMu.Lock()
+	gitProviders[p] = struct{}{}
+	gitProvidersMu.Unlock()
+
+	return p, nil
+}
+
+// stripURLCredentials returns the repository URL without its user
+// information, which may hold credentials. The local paths and the scp-like
+// addresses are returned as is.
+func stripURLCredentials(repository string) string {
+	u, err := url.Parse(repository)
+	if err != nil || u.User == nil {
+		return repository
+	}
+	u.User = nil
+	return u.String()
+}
+
+// stripCredentials strips the credentials of the repository URL from the error
+// of a git command, as git may print the URL in its errors
+func (p *GitConfigProvider) stripCredentials(err error) error {
+	if p.repository == p.displayedRepository {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), p.repository, p.displayedRepository))
+}
+
+// git runs a git command in the repository and returns its output
+func (p *GitConfigProvider) git(ctx context.Context, args ...string) ([]byte, error) {
+	repoPath := p.localPath
+	if repoPath == "" {
+		repoPath = p.checkoutPath
+	}
+	return runGit(ctx, append([]string{"-C", repoPath}, args...)...)
+}
+
+// runGit runs a git command, returning its standard error as the error message
+// when it fails
+func runGit(ctx context.Context, args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, gitCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	// git must never prompt for credentials, it would block the poller
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git %s: %s", gitSubcommand(args), msg)
+		}
+		return nil, fmt.Errorf("git %s: %s", gitSubcommand(args), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// gitSubcommand returns the subcommand of the given git arguments, to name the
+// command in errors without leaking the credentials a repository URL may hold
+func gitSubcommand(args []string) string {
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "-C", "-c":
+			i++
+		default:
+			return args[i]
+		}
+	}
+	return ""
+}
+
+// fetch fetches the branch of the remote repository, cloning it first if
+// needed, and returns the ref to read the fetched commit from
+func (p *GitConfigProvider) fetch(ctx context.Context) (string, error) {
+	if _, err := os.Stat(filepath.Join(p.checkoutPath, "HEAD")); err != nil {
+		if err := os.MkdirAll(filepath.Dir(p.checkoutPath), 0755); err != nil {
+			return "", err
+		}
+		// a previous clone may have been interrupted
+		_ = os.RemoveAll(p.checkoutPath)
+		if _, err := runGit(ctx, "clone", "--quiet", "--bare", "--", p.repository, p.checkoutPath); err != nil {
+			return "", p.stripCredentials(err)
+		}
+	}
+
+	ref := p.branch
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if _, err := p.git(ctx, "fetch", "--quiet", "--force", "origin", ref); err != nil {
+		return "", p.stripCredentials(err)
+	}
+	return "FETCH_HEAD", nil
+}
+
+// resolveCommit returns the latest commit of the branch of the repository,
+// after verifying its signature if needed
+func (p *GitConfigProvider) resolveCommit(ctx context.Context) (string, error) {
+	ref := p.branch
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if p.localPath == "" {
+		fetched, err := p.fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+		ref = fetched
+	}
+
+	out, err := p.git(ctx, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s in %s: %s", ref, p.displayedRepository, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	if p.verifySignatures && commit != p.commit {
+		args := []string{}
+		if p.allowedSignersFile != "" {
+			args = append(args, "-c", "gpg.ssh.allowedSignersFile="+p.allowedSignersFile)
+		}
+		args = append(args, "verify-commit", commit)
+		if _, err := p.git(ctx, args...); err != nil {
+			return "", fmt.Errorf("the signature of commit %s of %s can't be verified, keeping the configs of the previous commit: %s", commit, p.displayedRepository, err)
+		}
+	}
+
+	return commit, nil
+}
+
+// sync returns the commit to collect the configs from, and records the outcome
+// of the sync for the status. It must be called while holding syncMu.
+func (p *GitConfigProvider) sync(ctx context.Context) (string, error) {
+	commit, err := p.resolveCommit(ctx)
+	p.setLastSync(err)
+	return commit, err
+}
+
+// setLastSync records the outcome of the last sync for the status
+func (p *GitConfigProvider) setLastSync(err error) {
+	p.statusMu.Lock()
+	defer p.statusMu.Unlock()
+
+	p.lastSync = time.Now()
+	p.lastErr = err
+}
+
+// Collect retrieves the configs of the latest commit of the repository, or
+// returns the configs collected last time if the commit didn't change
+func (p *GitConfigProvider) Collect(ctx context.Context) ([]integration.Config, error) {
+	p.syncMu.Lock()
+	defer p.syncMu.Unlock()
+
+	commit, err := p.sync(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if commit == p.commit {
+		return p.configs, nil
+	}
+
+	configs, configErrors, err := p.readConfigs(ctx, commit)
+	if err != nil {
+		p.setLastSync(err)
+		return nil, fmt.Errorf("unable to read the configs of commit %s of %s: %s", commit, p.displayedRepository, err)
+	}
+
+	log.Infof("Collected %d configs from commit %s of %s", len(configs), commit, p.displayedRepository)
+	p.configs = configs
+	p.statusMu.Lock()
+	p.commit = commit
+	p.configErrors = configErrors
+	p.statusMu.Unlock()
+	return configs, nil
+}
+
+// readConfigs reads the configs committed to the template_dir directory of
+// the repository at the given commit. The files that can't be parsed are
+// reported as errors, indexed by their path.
+func (p *GitConfigProvider) readConfigs(ctx context.Context, commit string) ([]integration.Config, map[string]ErrorMsgSet, error) {
+	out, err := p.git(ctx, "ls-tree", "-r", "-z", "--name-only", commit, "--", p.dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	configs := []integration.Config{}
+	configErrors := make(map[string]ErrorMsgSet)
+	for _, fpath := range strings.Split(string(out), "\x00") {
+		name := gitConfigName(p.dir, fpath)
+		if name == "" {
+			continue
+		}
+
+		conf, err := p.readConfig(ctx, commit, name, fpath)
+		if err != nil {
+			log.Warnf("Unable to parse the config %s from %s: %s", fpath, p.displayedRepository, err)
+			configErrors[fpath] = ErrorMsgSet{err.Error(): struct{}{}}
+			continue
+		}
+		configs = append(configs, conf)
+	}
+
+	return configs, configErrors, nil
+}
+
+// readConfig parses the config file at the given path and commit
+func (p *GitConfigProvider) readConfig(ctx context.Context, commit, name, fpath string) (integration.Config, error) {
+	content, err := p.git(ctx, "cat-file", "blob", commit+":"+fpath)
+	if err != nil {
+		return integration.Config{Name: name}, err
+	}
+
+	cf := configFormat{}
+	if err := yaml.Unmarshal(content, &cf); err != nil {
+		return integration.Config{Name: name}, err
+	}
+
+	// the source doesn't include the commit, so that the configs are only
+	// rescheduled when their content changes
+	return buildIntegrationConfig(name, names.Git+":"+p.displayedRepository+":"+fpath, cf)
+}
+
+// gitConfigName returns the name of the check configured by the file at the
+// given path, following the layout of the conf.d directory: either
+// <dir>/<check>.yaml or <dir>/<check>.d/<file>.yaml. It returns an empty name
+// for the files that aren't configs.
+func gitConfigName(dir, fpath string) string {
+	ext := path.Ext(fpath)
+	if ext != ".yaml" && ext != ".yml" {
+		return ""
+	}
+
+	rel := fpath
+	if dir != "." {
+		rel = strings.TrimPrefix(fpath, dir+"/")
+	}
+
+	switch parts := strings.Split(rel, "/"); len(parts) {
+	case 1:
+		return strings.TrimSuffix(parts[0], ext)
+	case 2:
+		if strings.HasSuffix(parts[0], ".d") {
+			return strings.TrimSuffix(parts[0], ".d")
+		}
+	}
+	return ""
+}
+
+// IsUpToDate checks whether the latest commit of the repository is the commit
+// of the last collected configs
+func (p *GitConfigProvider) IsUpToDate(ctx context.Context) (bool, error) {
+	p.syncMu.Lock()
+	defer p.syncMu.Unlock()
+
+	commit, err := p.sync(ctx)
+	if err != nil {
+		return false, err
+	}
+	return commit == p.commit, nil
+}
+
+// String returns a string representation of the GitConfigProvider
+func (p *GitConfigProvider) String() string {
+	return names.Git
+}
+
+// GetConfigErrors returns the errors of the configs that couldn't be parsed on
+// the last Collect call
+func (p *GitConfigProvider) GetConfigErrors() map[string]ErrorMsgSet {
+	p.statusMu.RLock()
+	defer p.statusMu.RUnlock()
+
+	configErrors := make(map[string]ErrorMsgSet, len(p.configErrors))
+	for name, errors := range p.configErrors {
+		configErrors[name] = errors
+	}
+	return configErrors
+}
+
+// Status returns the status of the repository synced by the provider. It
+// doesn't wait for a sync in progress.
+func (p *GitConfigProvider) Status() GitRepositoryStatus {
+	p.statusMu.RLock()
+	defer p.statusMu.RUnlock()
+
+	status := GitRepositoryStatus{
+		Repository:        p.displayedRepository,
+		Branch:            p.branch,
+		Commit:            p.commit,
+		SignatureVerified: p.verifySignatures && p.commit != "",
+	}
+	if !p.lastSync.IsZero() {
+		status.LastSync = p.lastSync.Format(time.RFC3339)
+	}
+	if p.lastErr != nil {
+		status.LastError = p.lastErr.Error()
+	}
+	return status
+}
+
+// GetGitRepositoriesStatus returns the status of the repositories synced by the
+// git config providers, sorted by repository
+func GetGitRepositoriesStatus() []GitRepositoryStatus {
+	gitProvidersMu.RLock()
+	defer gitProvidersMu.RUnlock()
+
+	statuses := make([]GitRepositoryStatus, 0, len(gitProviders))
+	for p := range gitProviders {
+		statuses = append(statuses, p.Status())
+	}
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Repository < statuses[j].Repository
+	})
+	return statuses
+}
+
+func init() {
+	RegisterProvider(names.GitRegisterName, NewGitConfigProvider)
+}
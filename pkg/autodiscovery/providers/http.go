@@ -0,0 +1,280 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	yaml "gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers/names"
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	httpRequestTimeout  = 10 * time.Second
+	httpMaxResponseSize = 10 * 1024 * 1024
+)
+
+// httpTemplate is the format of a template returned by the endpoint of the
+// HTTP config provider. It's the format of the configuration files of the
+// file config provider, along with the name of the check.
+type httpTemplate struct {
+	Name         string `yaml:"name"`
+	configFormat `yaml:",inline"`
+}
+
+// httpPayload is the format of the response of the endpoint of the HTTP config
+// provider, in JSON or YAML
+type httpPayload struct {
+	Configs []httpTemplate `yaml:"configs"`
+}
+
+// HTTPConfigProvider implements the ConfigProvider interface. It polls an HTTP
+// endpoint returning integration configs and templates, using conditional
+// requests so that the configs are only parsed again when they change.
+type HTTPConfigProvider struct {
+	url      string
+	headers  map[string]string
+	username string
+	password string
+	client   *http.Client
+
+	// etag and lastModified are the validators of the last response, sent
+	// with the next request
+	etag         string
+	lastModified string
+	// body is a response fetched by IsUpToDate, to be parsed by the next
+	// Collect call
+	body []byte
+	// configs are the configs parsed from the last response
+	configs []integration.Config
+
+	configErrors map[string]ErrorMsgSet
+	sync.RWMutex
+}
+
+// NewHTTPConfigProvider creates a new HTTPConfigProvider polling the endpoint
+// set as template_url. The requests are authenticated with the token, as a
+// bearer token, or with the username and password, and the headers of the
+// provider's configuration, which can use secrets.
+func NewHTTPConfigProvider(providerConfig *config.ConfigurationProviders) (ConfigProvider, error) {
+	if providerConfig == nil || providerConfig.TemplateURL == "" {
+		return nil, errors.New("the template_url of the http config provider is not set")
+	}
+
+	tlsConfig, err := buildHTTPProviderTLSConfig(providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to set up TLS for the http config provider: %s", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	headers := make(map[string]string, len(providerConfig.Headers)+1)
+	if providerConfig.Token != "" {
+		headers["Authorization"] = "Bearer " + providerConfig.Token
+	}
+	for name, value := range providerConfig.Headers {
+		headers[name] = value
+	}
+
+	return &HTTPConfigProvider{
+		url:          providerConfig.TemplateURL,
+		headers:      headers,
+		username:     providerConfig.Username,
+		password:     providerConfig.Password,
+		client:       &http.Client{Timeout: httpRequestTimeout, Transport: transport},
+		configErrors: make(map[string]ErrorMsgSet),
+	}, nil
+}
+
+// buildHTTPProviderTLSConfig returns the TLS configuration of the HTTP config
+// provider, using the CA and client certificate files of its configuration
+func buildHTTPProviderTLSConfig(providerConfig *config.ConfigurationProviders) (*tls.Config, error) {
+	tlsConfig := &tls.Config{}
+
+	if providerConfig.CAFile != "" {
+		caCert, err := os.ReadFile(providerConfig.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in %s", providerConfig.CAFile)
+		}
+	}
+
+	if providerConfig.CertFile != "" || providerConfig.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(providerConfig.CertFile, providerConfig.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// fetch sends a conditional request to the endpoint, and returns whether the
+// response changed since the last one along with its body
+func (p *HTTPConfigProvider) fetch(ctx context.Context) (bool, []byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	req.Header.Set("Accept", "application/json, application/yaml")
+	if p.username != "" && p.password != "" {
+		req.SetBasicAuth(p.username, p.password)
+	}
+	for name, value := range p.headers {
+		req.Header.Set(name, value)
+	}
+	if p.etag != "" {
+		req.Header.Set("If-None-Match", p.etag)
+	}
+	if p.lastModified != "" {
+		req.Header.Set("If-Modified-Since", p.lastModified)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return false, nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return false, nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, nil, fmt.Errorf("unexpected status code from %s: %d", p.url, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, httpMaxResponseSize+1))
+	if err != nil {
+		return false, nil, err
+	}
+	if len(body) > httpMaxResponseSize {
+		return false, nil, fmt.Errorf("the response from %s exceeds the maximum size of %d bytes", p.url, httpMaxResponseSize)
+	}
+
+	p.etag = resp.Header.Get("ETag")
+	p.lastModified = resp.Header.Get("Last-Modified")
+	return true, body, nil
+}
+
+// Collect retrieves the configs from the endpoint, or returns the configs
+// collected last time if they didn't change
+func (p *HTTPConfigProvider) Collect(ctx context.Context) ([]integration.Config, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	body := p.body
+	p.body = nil
+	if body == nil {
+		changed, fetched, err := p.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !changed {
+			return p.configs, nil
+		}
+		body = fetched
+	}
+
+	configs, configErrors, err := parseHTTPPayload(body, p.url)
+	if err != nil {
+		// the validators are reset so that the configs are fetched again
+		p.etag, p.lastModified = "", ""
+		return nil, fmt.Errorf("unable to parse the configs from %s: %s", p.url, err)
+	}
+
+	p.configs = configs
+	p.configErrors = configErrors
+	return configs, nil
+}
+
+// parseHTTPPayload parses the configs returned by the endpoint. The templates
+// that can't be parsed are reported as errors, indexed by their name.
+func parseHTTPPayload(body []byte, url string) ([]integration.Config, map[string]ErrorMsgSet, error) {
+	var payload httpPayload
+	// JSON is a subset of YAML, so both formats are parsed as YAML
+	if err := yaml.Unmarshal(body, &payload); err != nil {
+		return nil, nil, err
+	}
+
+	configs := make([]integration.Config, 0, len(payload.Configs))
+	configErrors := make(map[string]ErrorMsgSet)
+	for idx, tpl := range payload.Configs {
+		name := tpl.Name
+		if name == "" {
+			configErrors[fmt.Sprintf("configs[%d]", idx)] = ErrorMsgSet{"the name of the check is not set": struct{}{}}
+			continue
+		}
+
+		conf, err := buildIntegrationConfig(name, names.HTTP+":"+url, tpl.configFormat)
+		if err != nil {
+			log.Warnf("Unable to parse the config %s from %s: %s", name, url, err)
+			if _, found := configErrors[name]; !found {
+				configErrors[name] = ErrorMsgSet{}
+			}
+			configErrors[name][err.Error()] = struct{}{}
+			continue
+		}
+		configs = append(configs, conf)
+	}
+
+	return configs, configErrors, nil
+}
+
+// IsUpToDate sends a conditional request to the endpoint to check whether the
+// configs changed since the last call to Collect
+func (p *HTTPConfigProvider) IsUpToDate(ctx context.Context) (bool, error) {
+	p.Lock()
+	defer p.Unlock()
+
+	changed, body, err := p.fetch(ctx)
+	if err != nil {
+		return false, err
+	}
+	if changed {
+		p.body = body
+	}
+	return !changed, nil
+}
+
+// String returns a string representation of the HTTPConfigProvider
+func (p *HTTPConfigProvider) String() string {
+	return names.HTTP
+}
+
+// GetConfigErrors returns the errors of the configs that couldn't be parsed on
+// the last Collect call
+func (p *HTTPConfigProvider) GetConfigErrors() map[string]ErrorMsgSet {
+	p.RLock()
+	defer p.RUnlock()
+
+	configErrors := make(map[string]ErrorMsgSet, len(p.configErrors))
+	for name, errors := range p.configErrors {
+		configErrors[name] = errors
+	}
+	return configErrors
+}
+
+func init() {
+	RegisterProvider(names.HTTPRegisterName, NewHTTPConfigProvider)
+}
@@ -0,0 +1,138 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+)
+
+const httpTestPayload = `
+configs:
+  - name: redisdb
+    ad_identifiers:
+      - redis
+    init_config:
+    instances:
+      - host: "%%host%%"
+        port: 6379
+  - name: http_check
+    instances:
+      - url: http://example.com
+  - name: invalid
+  - ad_identifiers:
+      - nginx
+`
+
+const httpTestJSONPayload = `{"configs": [{"name": "redisdb", "ad_identifiers": ["redis"], "instances": [{"host": "%%host%%"}]}]}`
+
+func TestHTTPConfigProvider(t *testing.T) {
+	payload := httpTestPayload
+	etag := `"v1"`
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, "Bearer some_token", r.Header.Get("Authorization"))
+		assert.Equal(t, "some_value", r.Header.Get("X-Catalog-Key"))
+
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPConfigProvider(&config.ConfigurationProviders{
+		TemplateURL: server.URL,
+		Token:       "some_token",
+		Headers:     map[string]string{"X-Catalog-Key": "some_value"},
+	})
+	require.NoError(t, err)
+	p := provider.(*HTTPConfigProvider)
+	ctx := context.Background()
+
+	configs, err := p.Collect(ctx)
+	require.NoError(t, err)
+	require.Len(t, configs, 2)
+	assert.Equal(t, "redisdb", configs[0].Name)
+	assert.Equal(t, []string{"redis"}, configs[0].ADIdentifiers)
+	assert.Equal(t, "host: '%%host%%'\nport: 6379\n", string(configs[0].Instances[0]))
+	assert.Equal(t, "http:"+server.URL, configs[0].Source)
+	assert.True(t, configs[0].IsTemplate())
+	assert.Equal(t, "http_check", configs[1].Name)
+	assert.False(t, configs[1].IsTemplate())
+
+	configErrors := p.GetConfigErrors()
+	assert.Len(t, configErrors, 2)
+	assert.Contains(t, configErrors["invalid"], "Configuration file contains no valid instances")
+	assert.Contains(t, configErrors["configs[3]"], "the name of the check is not set")
+
+	// the configs didn't change
+	upToDate, err := p.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.True(t, upToDate)
+	configs, err = p.Collect(ctx)
+	require.NoError(t, err)
+	assert.Len(t, configs, 2)
+
+	// the configs changed, and are fetched by IsUpToDate
+	payload = httpTestJSONPayload
+	etag = `"v2"`
+	upToDate, err = p.IsUpToDate(ctx)
+	require.NoError(t, err)
+	assert.False(t, upToDate)
+	requestsBeforeCollect := requests
+	configs, err = p.Collect(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, requestsBeforeCollect, requests)
+	require.Len(t, configs, 1)
+	assert.Equal(t, "redisdb", configs[0].Name)
+	assert.Equal(t, "host: '%%host%%'\n", string(configs[0].Instances[0]))
+	assert.Empty(t, p.GetConfigErrors())
+
+	// invalid payloads are reported, and fetched again
+	payload = "configs: {"
+	etag = `"v3"`
+	_, err = p.Collect(ctx)
+	assert.Error(t, err)
+	assert.Empty(t, p.etag)
+}
+
+func TestHTTPConfigProviderBasicAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != "user" || password != "pass" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(httpTestJSONPayload))
+	}))
+	defer server.Close()
+
+	provider, err := NewHTTPConfigProvider(&config.ConfigurationProviders{TemplateURL: server.URL, Username: "user", Password: "pass"})
+	require.NoError(t, err)
+	configs, err := provider.(*HTTPConfigProvider).Collect(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, configs, 1)
+
+	provider, err = NewHTTPConfigProvider(&config.ConfigurationProviders{TemplateURL: server.URL, Username: "user", Password: "wrong"})
+	require.NoError(t, err)
+	_, err = provider.(*HTTPConfigProvider).Collect(context.Background())
+	assert.EqualError(t, err, "unexpected status code from "+server.URL+": 401")
+
+	_, err = NewHTTPConfigProvider(&config.ConfigurationProviders{})
+	assert.Error(t, err)
+}
@@ -84,6 +84,7 @@ type cliParams struct {
 	discoveryRetryInterval    uint
 	discoveryMinInstances     uint
 	generateIntegrationTraces bool
+	dumpFile                  string
 }
 
 type GlobalParams struct {
@@ -138,6 +139,7 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 	cmd.Flags().UintVarP(&cliParams.discoveryTimeout, "discovery-timeout", "", 5, "max retry duration until Autodiscovery resolves the check template (in seconds)")
 	cmd.Flags().UintVarP(&cliParams.discoveryRetryInterval, "discovery-retry-interval", "", 1, "(unused)")
 	cmd.Flags().UintVarP(&cliParams.discoveryMinInstances, "discovery-min-instances", "", 1, "minimum number of config instances to be discovered before running the check(s)")
+	cmd.Flags().StringVarP(&cliParams.dumpFile, "dump", "", "", "write the series, service checks and events of the check to this file, to be compared with the check-diff command")
 
 	pkgconfig.Datadog.BindPFlag("cmd.check.fullsketches", cmd.Flags().Lookup("full-sketches")) //nolint:errcheck
 
@@ -181,6 +183,10 @@ func run(log log.Component, config config.Component, sysprobeconfig sysprobeconf
 		return nil
 	}
 
+	if cliParams.dumpFile != "" && cliParams.profileMemory {
+		return errors.New("the --dump and --profile-memory options can't be used together")
+	}
+
 	// Always disable SBOM collection in `check` command to avoid BoltDB flock issue
 	// and consuming CPU & Memory for asynchronous scans that would not be shown in `agent check` output.
 	pkgconfig.Datadog.Set("sbom.host.enabled", "false")
@@ -368,6 +374,7 @@ func run(log log.Component, config config.Component, sysprobeconfig sysprobeconf
 
 	var checkFileOutput bytes.Buffer
 	var instancesData []interface{}
+	var checkSamples []aggregator.CheckSamples
 	printer := aggregator.AgentDemultiplexerPrinter{AgentDemultiplexer: demux}
 	for _, c := range cs {
 		s := runCheck(cliParams, c, printer)
@@ -376,7 +383,9 @@ func run(log log.Component, config config.Component, sysprobeconfig sysprobeconf
 		time.Sleep(time.Duration(cliParams.checkDelay) * time.Millisecond)
 
 		if cliParams.formatJSON {
-			aggregatorData := printer.GetMetricsDataForPrint()
+			samples := printer.GetCheckSamples()
+			checkSamples = append(checkSamples, samples)
+			aggregatorData := printer.GetCheckSamplesDataForPrint(samples)
 			var collectorData map[string]interface{}
 
 			collectorJSON, _ := status.GetCheckStatusJSON(c, s)
@@ -454,7 +463,9 @@ func run(log log.Component, config config.Component, sysprobeconfig sysprobeconf
 				return fmt.Errorf("no diff data found in %s", profileDataDir)
 			}
 		} else {
-			printer.PrintMetrics(&checkFileOutput, cliParams.formatTable)
+			samples := printer.GetCheckSamples()
+			checkSamples = append(checkSamples, samples)
+			printer.PrintCheckSamples(samples, &checkFileOutput, cliParams.formatTable)
 
 			p := func(data string) {
 				fmt.Println(data)
@@ -501,6 +512,15 @@ func run(log log.Component, config config.Component, sysprobeconfig sysprobeconf
 		writeCheckToFile(cliParams.checkName, &checkFileOutput)
 	}
 
+	if cliParams.dumpFile != "" {
+		if err := writeCheckDump(cliParams.dumpFile, newCheckDump(cliParams.checkName, checkSamples)); err != nil {
+			return fmt.Errorf("unable to write the check dump: %v", err)
+		}
+		if !cliParams.formatJSON {
+			fmt.Printf("Check dump written to %s\n", cliParams.dumpFile)
+		}
+	}
+
 	if cliParams.generateIntegrationTraces {
 		pkgconfig.Datadog.Set("integration_tracing", previousIntegrationTracing)
 		pkgconfig.Datadog.Set("integration_tracing_exhaustive", previousIntegrationTracingExhaustive)
@@ -525,10 +545,13 @@ func runCheck(cliParams *cliParams, c check.Check, demux aggregator.Demultiplexe
 	}
 	for i := 0; i < times; i++ {
 		t0 := time.Now()
+		meter := stats.StartResourceUsageMeter()
 		err := c.Run()
+		usage := meter.Stop()
 		warnings := c.GetWarnings()
 		sStats, _ := c.GetSenderStats()
 		s.Add(time.Since(t0), err, warnings, sStats)
+		s.AddResourceUsage(usage)
 		if pause > 0 && i < times-1 {
 			time.Sleep(time.Duration(pause) * time.Millisecond)
 		}
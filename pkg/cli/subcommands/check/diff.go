@@ -0,0 +1,363 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+)
+
+// diffCliParams are the command-line arguments for the check-diff subcommand
+type diffCliParams struct {
+	tolerance  float64
+	formatJSON bool
+}
+
+// MakeDiffCommand returns a `check-diff` command, which compares two dumps
+// written by `check --dump`.
+func MakeDiffCommand() *cobra.Command {
+	cliParams := &diffCliParams{}
+	cmd := &cobra.Command{
+		Use:   "check-diff <old_dump> <new_dump>",
+		Short: "Compare two dumps of the output of a check",
+		Long: `Use this to compare the dumps written by 'check --dump', for instance by two versions of the agent.
+It reports the new and removed metrics, the changes of their tags and types, the value changes
+above the tolerance and the changes of the service checks and events. It exits with an error
+when the dumps differ.`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			oldDump, err := readCheckDump(args[0])
+			if err != nil {
+				return err
+			}
+			newDump, err := readCheckDump(args[1])
+			if err != nil {
+				return err
+			}
+
+			diff := diffCheckDumps(oldDump, newDump, cliParams.tolerance)
+			if cliParams.formatJSON {
+				j, _ := json.MarshalIndent(diff, "", "  ")
+				fmt.Println(string(j))
+			} else {
+				diff.print(color.Output)
+			}
+
+			if !diff.empty() {
+				cmd.SilenceUsage = true
+				return errors.New("the check dumps differ")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().Float64VarP(&cliParams.tolerance, "tolerance", "", 0, "relative value change under which values are considered equal, 0.1 ignores changes under 10%")
+	cmd.Flags().BoolVarP(&cliParams.formatJSON, "json", "", false, "format the differences as json")
+
+	return cmd
+}
+
+// checkDumpDiff holds the differences between two check dumps
+type checkDumpDiff struct {
+	Old                  dumpSummary          `json:"old"`
+	New                  dumpSummary          `json:"new"`
+	AddedMetrics         []string             `json:"added_metrics"`
+	RemovedMetrics       []string             `json:"removed_metrics"`
+	TagChanges           []tagChange          `json:"tag_changes"`
+	AddedContexts        []string             `json:"added_contexts"`
+	RemovedContexts      []string             `json:"removed_contexts"`
+	TypeChanges          []typeChange         `json:"type_changes"`
+	ValueChanges         []valueChange        `json:"value_changes"`
+	AddedServiceChecks   []string             `json:"added_service_checks"`
+	RemovedServiceChecks []string             `json:"removed_service_checks"`
+	ServiceCheckChanges  []serviceCheckChange `json:"service_check_changes"`
+	AddedEvents          []string             `json:"added_events"`
+	RemovedEvents        []string             `json:"removed_events"`
+}
+
+// dumpSummary describes a compared check dump
+type dumpSummary struct {
+	AgentVersion  string `json:"agent_version"`
+	Check         string `json:"check"`
+	Series        int    `json:"series"`
+	ServiceChecks int    `json:"service_checks"`
+	Events        int    `json:"events"`
+}
+
+func summarizeCheckDump(dump *checkDump) dumpSummary {
+	return dumpSummary{
+		AgentVersion:  dump.AgentVersion,
+		Check:         dump.Check,
+		Series:        len(dump.Series),
+		ServiceChecks: len(dump.ServiceChecks),
+		Events:        len(dump.Events),
+	}
+}
+
+// tagChange holds the tag keys added to, or removed from, a metric sent in both
+// dumps
+type tagChange struct {
+	Metric      string   `json:"metric"`
+	AddedKeys   []string `json:"added_keys"`
+	RemovedKeys []string `json:"removed_keys"`
+}
+
+type typeChange struct {
+	Context string `json:"context"`
+	Old     string `json:"old"`
+	New     string `json:"new"`
+}
+
+// valueChange holds the change of the last value of a series
+type valueChange struct {
+	Context string  `json:"context"`
+	Old     float64 `json:"old"`
+	New     float64 `json:"new"`
+}
+
+type serviceCheckChange struct {
+	Context    string `json:"context"`
+	OldStatus  string `json:"old_status"`
+	NewStatus  string `json:"new_status"`
+	NewMessage string `json:"new_message"`
+}
+
+// diffCheckDumps compares two check dumps. Values whose relative change is at
+// most tolerance are considered equal.
+func diffCheckDumps(oldDump, newDump *checkDump, tolerance float64) *checkDumpDiff {
+	diff := &checkDumpDiff{
+		Old: summarizeCheckDump(oldDump),
+		New: summarizeCheckDump(newDump),
+	}
+
+	diff.diffSeries(oldDump.Series, newDump.Series, tolerance)
+	diff.diffServiceChecks(oldDump.ServiceChecks, newDump.ServiceChecks)
+
+	oldEvents := map[string]struct{}{}
+	for _, e := range oldDump.Events {
+		oldEvents[e.context()] = struct{}{}
+	}
+	newEvents := map[string]struct{}{}
+	for _, e := range newDump.Events {
+		newEvents[e.context()] = struct{}{}
+	}
+	diff.AddedEvents = missingKeys(newEvents, oldEvents)
+	diff.RemovedEvents = missingKeys(oldEvents, newEvents)
+
+	return diff
+}
+
+func (d *checkDumpDiff) diffSeries(oldSeries, newSeries []dumpedSerie, tolerance float64) {
+	oldMetrics, oldContexts := indexSeries(oldSeries)
+	newMetrics, newContexts := indexSeries(newSeries)
+
+	d.AddedMetrics = missingKeys(newMetrics, oldMetrics)
+	d.RemovedMetrics = missingKeys(oldMetrics, newMetrics)
+
+	for _, name := range sortedKeys(newMetrics) {
+		if _, ok := oldMetrics[name]; !ok {
+			continue
+		}
+		oldKeys, newKeys := oldMetrics[name], newMetrics[name]
+		added, removed := missingKeys(newKeys, oldKeys), missingKeys(oldKeys, newKeys)
+		if len(added) != 0 || len(removed) != 0 {
+			d.TagChanges = append(d.TagChanges, tagChange{Metric: name, AddedKeys: added, RemovedKeys: removed})
+		}
+	}
+
+	for _, context := range sortedKeys(newContexts) {
+		newSerie := newContexts[context]
+		oldSerie, ok := oldContexts[context]
+		if !ok {
+			// the contexts of new metrics are already reported by AddedMetrics
+			if _, ok := oldMetrics[newSerie.Name]; ok {
+				d.AddedContexts = append(d.AddedContexts, context)
+			}
+			continue
+		}
+		if oldSerie.Type != newSerie.Type {
+			d.TypeChanges = append(d.TypeChanges, typeChange{Context: context, Old: oldSerie.Type, New: newSerie.Type})
+		}
+		if len(oldSerie.Values) == 0 || len(newSerie.Values) == 0 {
+			continue
+		}
+		oldValue, newValue := oldSerie.Values[len(oldSerie.Values)-1], newSerie.Values[len(newSerie.Values)-1]
+		if valueChanged(oldValue, newValue, tolerance) {
+			d.ValueChanges = append(d.ValueChanges, valueChange{Context: context, Old: oldValue, New: newValue})
+		}
+	}
+	for _, context := range sortedKeys(oldContexts) {
+		if _, ok := newContexts[context]; ok {
+			continue
+		}
+		if _, ok := newMetrics[oldContexts[context].Name]; ok {
+			d.RemovedContexts = append(d.RemovedContexts, context)
+		}
+	}
+}
+
+func (d *checkDumpDiff) diffServiceChecks(oldChecks, newChecks []dumpedServiceCheck) {
+	oldIndex := make(map[string]dumpedServiceCheck, len(oldChecks))
+	for _, sc := range oldChecks {
+		oldIndex[sc.context()] = sc
+	}
+	newIndex := make(map[string]dumpedServiceCheck, len(newChecks))
+	for _, sc := range newChecks {
+		newIndex[sc.context()] = sc
+	}
+
+	d.AddedServiceChecks = missingKeys(newIndex, oldIndex)
+	d.RemovedServiceChecks = missingKeys(oldIndex, newIndex)
+	for _, context := range sortedKeys(newIndex) {
+		oldCheck, ok := oldIndex[context]
+		if !ok {
+			continue
+		}
+		if newCheck := newIndex[context]; oldCheck.Status != newCheck.Status {
+			d.ServiceCheckChanges = append(d.ServiceCheckChanges, serviceCheckChange{
+				Context:    context,
+				OldStatus:  oldCheck.Status,
+				NewStatus:  newCheck.Status,
+				NewMessage: newCheck.Message,
+			})
+		}
+	}
+}
+
+// indexSeries returns the tag keys of each metric and the last series of each
+// context
+func indexSeries(series []dumpedSerie) (map[string]map[string]struct{}, map[string]dumpedSerie) {
+	metrics := map[string]map[string]struct{}{}
+	contexts := make(map[string]dumpedSerie, len(series))
+	for _, s := range series {
+		keys, ok := metrics[s.Name]
+		if !ok {
+			keys = map[string]struct{}{}
+			metrics[s.Name] = keys
+		}
+		for _, tag := range s.Tags {
+			keys[strings.SplitN(tag, ":", 2)[0]] = struct{}{}
+		}
+		contexts[s.context()] = s
+	}
+	return metrics, contexts
+}
+
+func valueChanged(oldValue, newValue, tolerance float64) bool {
+	if oldValue == newValue {
+		return false
+	}
+	if oldValue == 0 {
+		return true
+	}
+	return math.Abs(newValue-oldValue)/math.Abs(oldValue) > tolerance
+}
+
+// empty returns whether the dumps have no differences
+func (d *checkDumpDiff) empty() bool {
+	return len(d.AddedMetrics) == 0 && len(d.RemovedMetrics) == 0 && len(d.TagChanges) == 0 &&
+		len(d.AddedContexts) == 0 && len(d.RemovedContexts) == 0 && len(d.TypeChanges) == 0 &&
+		len(d.ValueChanges) == 0 && len(d.AddedServiceChecks) == 0 && len(d.RemovedServiceChecks) == 0 &&
+		len(d.ServiceCheckChanges) == 0 && len(d.AddedEvents) == 0 && len(d.RemovedEvents) == 0
+}
+
+func (d *checkDumpDiff) print(w io.Writer) {
+	if d.Old.Check != d.New.Check {
+		fmt.Fprintln(w, color.YellowString("Comparing dumps of different checks: %s and %s", d.Old.Check, d.New.Check))
+	}
+	for _, s := range []dumpSummary{d.Old, d.New} {
+		fmt.Fprintf(w, "Agent %s: %d series, %d service checks, %d events\n", s.AgentVersion, s.Series, s.ServiceChecks, s.Events)
+	}
+	if d.empty() {
+		fmt.Fprintln(w, color.GreenString("No differences"))
+		return
+	}
+
+	added := func(s string) { fmt.Fprintln(w, color.GreenString("+ %s", s)) }
+	removed := func(s string) { fmt.Fprintln(w, color.RedString("- %s", s)) }
+	changed := func(format string, a ...interface{}) { fmt.Fprintln(w, color.YellowString("~ "+format, a...)) }
+
+	if len(d.AddedMetrics)+len(d.RemovedMetrics)+len(d.TagChanges)+len(d.AddedContexts)+len(d.RemovedContexts)+len(d.TypeChanges)+len(d.ValueChanges) != 0 {
+		fmt.Fprintln(w, fmt.Sprintf("=== %s ===", color.BlueString("Metrics")))
+		for _, name := range d.AddedMetrics {
+			added(name)
+		}
+		for _, name := range d.RemovedMetrics {
+			removed(name)
+		}
+		for _, c := range d.TagChanges {
+			changed("%s: tag keys added %v, removed %v", c.Metric, c.AddedKeys, c.RemovedKeys)
+		}
+		for _, context := range d.AddedContexts {
+			added(context)
+		}
+		for _, context := range d.RemovedContexts {
+			removed(context)
+		}
+		for _, c := range d.TypeChanges {
+			changed("%s: type %s -> %s", c.Context, c.Old, c.New)
+		}
+		for _, c := range d.ValueChanges {
+			if c.Old == 0 {
+				changed("%s: %v -> %v", c.Context, c.Old, c.New)
+			} else {
+				changed("%s: %v -> %v (%+.2f%%)", c.Context, c.Old, c.New, (c.New-c.Old)/math.Abs(c.Old)*100)
+			}
+		}
+	}
+
+	if len(d.AddedServiceChecks)+len(d.RemovedServiceChecks)+len(d.ServiceCheckChanges) != 0 {
+		fmt.Fprintln(w, fmt.Sprintf("=== %s ===", color.BlueString("Service Checks")))
+		for _, context := range d.AddedServiceChecks {
+			added(context)
+		}
+		for _, context := range d.RemovedServiceChecks {
+			removed(context)
+		}
+		for _, c := range d.ServiceCheckChanges {
+			changed("%s: %s -> %s %s", c.Context, c.OldStatus, c.NewStatus, c.NewMessage)
+		}
+	}
+
+	if len(d.AddedEvents)+len(d.RemovedEvents) != 0 {
+		fmt.Fprintln(w, fmt.Sprintf("=== %s ===", color.BlueString("Events")))
+		for _, context := range d.AddedEvents {
+			added(context)
+		}
+		for _, context := range d.RemovedEvents {
+			removed(context)
+		}
+	}
+}
+
+// missingKeys returns the sorted keys of m which are not in other
+func missingKeys[V1, V2 any](m map[string]V1, other map[string]V2) []string {
+	var keys []string
+	for k := range m {
+		if _, ok := other[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffCheckDumps(t *testing.T) {
+	oldDump := &checkDump{
+		Version:      checkDumpVersion,
+		AgentVersion: "7.47.0",
+		Check:        "zk",
+		Series: []dumpedSerie{
+			{Name: "zk.bytes", Type: "rate", Tags: []string{"env:prod"}, Values: []float64{100}},
+			{Name: "zk.connections", Type: "gauge", Tags: []string{"env:prod", "role:leader"}, Values: []float64{10}},
+			{Name: "zk.latency", Type: "gauge", Tags: []string{"env:prod"}, Values: []float64{4}},
+			{Name: "zk.outstanding", Type: "gauge", Tags: []string{"env:prod"}, Values: []float64{1}},
+		},
+		ServiceChecks: []dumpedServiceCheck{
+			{Name: "zk.can_connect", Tags: []string{"env:prod"}, Status: "OK"},
+			{Name: "zk.mode", Status: "OK"},
+		},
+		Events: []dumpedEvent{
+			{Title: "leader elected", SourceTypeName: "zookeeper", AlertType: "info"},
+		},
+	}
+	newDump := &checkDump{
+		Version:      checkDumpVersion,
+		AgentVersion: "7.48.0",
+		Check:        "zk",
+		Series: []dumpedSerie{
+			{Name: "zk.bytes", Type: "rate", Tags: []string{"env:prod"}, Values: []float64{105}},
+			{Name: "zk.connections", Type: "gauge", Tags: []string{"env:prod", "role:leader", "version:3.8"}, Values: []float64{10}},
+			{Name: "zk.latency", Type: "distribution", Tags: []string{"env:prod"}, Values: []float64{8}},
+			{Name: "zk.watches", Type: "gauge", Tags: []string{"env:prod"}, Values: []float64{0}},
+		},
+		ServiceChecks: []dumpedServiceCheck{
+			{Name: "zk.can_connect", Tags: []string{"env:prod"}, Status: "CRITICAL", Message: "connection refused"},
+		},
+		Events: []dumpedEvent{
+			{Title: "leader elected", SourceTypeName: "zookeeper", AlertType: "info"},
+		},
+	}
+
+	diff := diffCheckDumps(oldDump, newDump, 0.1)
+	assert.False(t, diff.empty())
+	assert.Equal(t, "7.47.0", diff.Old.AgentVersion)
+	assert.Equal(t, 4, diff.New.Series)
+	assert.Equal(t, []string{"zk.watches"}, diff.AddedMetrics)
+	assert.Equal(t, []string{"zk.outstanding"}, diff.RemovedMetrics)
+	assert.Equal(t, []tagChange{{Metric: "zk.connections", AddedKeys: []string{"version"}}}, diff.TagChanges)
+	assert.Equal(t, []string{"zk.connections{env:prod,role:leader,version:3.8}"}, diff.AddedContexts)
+	assert.Equal(t, []string{"zk.connections{env:prod,role:leader}"}, diff.RemovedContexts)
+	assert.Equal(t, []typeChange{{Context: "zk.latency{env:prod}", Old: "gauge", New: "distribution"}}, diff.TypeChanges)
+	// the change of zk.bytes is under the tolerance
+	assert.Equal(t, []valueChange{{Context: "zk.latency{env:prod}", Old: 4, New: 8}}, diff.ValueChanges)
+	assert.Empty(t, diff.AddedServiceChecks)
+	assert.Equal(t, []string{"zk.mode{}"}, diff.RemovedServiceChecks)
+	assert.Equal(t, []serviceCheckChange{{Context: "zk.can_connect{env:prod}", OldStatus: "OK", NewStatus: "CRITICAL", NewMessage: "connection refused"}}, diff.ServiceCheckChanges)
+	assert.Empty(t, diff.AddedEvents)
+	assert.Empty(t, diff.RemovedEvents)
+
+	var out bytes.Buffer
+	diff.print(&out)
+	assert.Contains(t, out.String(), "+ zk.watches")
+	assert.Contains(t, out.String(), "- zk.outstanding")
+	assert.Contains(t, out.String(), "~ zk.latency{env:prod}: 4 -> 8 (+100.00%)")
+
+	diff = diffCheckDumps(oldDump, oldDump, 0)
+	assert.True(t, diff.empty())
+}
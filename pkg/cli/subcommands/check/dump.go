@@ -0,0 +1,201 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator"
+	"github.com/DataDog/datadog-agent/pkg/version"
+)
+
+// checkDumpVersion is the version of the format of the check dumps, to be
+// increased on breaking changes.
+const checkDumpVersion = 1
+
+const distributionType = "distribution"
+
+// checkDump is the content of the file written by `check --dump`. Its content is
+// sorted and has no timestamps so that dumps of different runs, or of different
+// versions of the agent, can be compared.
+type checkDump struct {
+	Version       int                  `json:"version"`
+	AgentVersion  string               `json:"agent_version"`
+	Check         string               `json:"check"`
+	Series        []dumpedSerie        `json:"series"`
+	ServiceChecks []dumpedServiceCheck `json:"service_checks"`
+	Events        []dumpedEvent        `json:"events"`
+}
+
+// dumpedSerie is a series, or a distribution, sent by a check. The values of a
+// distribution are the averages of its points.
+type dumpedSerie struct {
+	Name   string    `json:"name"`
+	Type   string    `json:"type"`
+	Host   string    `json:"host"`
+	Tags   []string  `json:"tags"`
+	Values []float64 `json:"values"`
+}
+
+type dumpedServiceCheck struct {
+	Name    string   `json:"name"`
+	Host    string   `json:"host"`
+	Tags    []string `json:"tags"`
+	Status  string   `json:"status"`
+	Message string   `json:"message"`
+}
+
+type dumpedEvent struct {
+	Title          string   `json:"title"`
+	Text           string   `json:"text"`
+	Host           string   `json:"host"`
+	Tags           []string `json:"tags"`
+	Priority       string   `json:"priority,omitempty"`
+	AlertType      string   `json:"alert_type,omitempty"`
+	AggregationKey string   `json:"aggregation_key,omitempty"`
+	SourceTypeName string   `json:"source_type_name,omitempty"`
+	EventType      string   `json:"event_type,omitempty"`
+}
+
+// context returns the identifier of the series, made of its name, host and tags
+func (s *dumpedSerie) context() string {
+	return contextString(s.Name, s.Host, s.Tags)
+}
+
+func (s *dumpedServiceCheck) context() string {
+	return contextString(s.Name, s.Host, s.Tags)
+}
+
+func (e *dumpedEvent) context() string {
+	return contextString(e.Title, e.Host, append([]string{"source:" + e.SourceTypeName, "alert_type:" + e.AlertType}, e.Tags...))
+}
+
+func contextString(name, host string, tags []string) string {
+	context := name + "{" + strings.Join(tags, ",") + "}"
+	if host != "" {
+		context += "@" + host
+	}
+	return context
+}
+
+// normalizeTags returns a sorted copy of tags without duplicates
+func normalizeTags(tags []string) []string {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	sort.Strings(normalized)
+	return normalized
+}
+
+// newCheckDump builds the dump of the samples sent by a check
+func newCheckDump(checkName string, samples []aggregator.CheckSamples) *checkDump {
+	dump := &checkDump{
+		Version:       checkDumpVersion,
+		AgentVersion:  version.AgentVersion,
+		Check:         checkName,
+		Series:        []dumpedSerie{},
+		ServiceChecks: []dumpedServiceCheck{},
+		Events:        []dumpedEvent{},
+	}
+
+	for _, s := range samples {
+		for _, serie := range s.Series {
+			values := make([]float64, 0, len(serie.Points))
+			for _, p := range serie.Points {
+				values = append(values, p.Value)
+			}
+			dump.Series = append(dump.Series, dumpedSerie{
+				Name:   serie.Name,
+				Type:   serie.MType.String(),
+				Host:   serie.Host,
+				Tags:   normalizeTags(serie.Tags.UnsafeToReadOnlySliceString()),
+				Values: values,
+			})
+		}
+		for _, sketch := range s.Sketches {
+			values := make([]float64, 0, len(sketch.Points))
+			for _, p := range sketch.Points {
+				values = append(values, p.Sketch.Basic.Avg)
+			}
+			dump.Series = append(dump.Series, dumpedSerie{
+				Name:   sketch.Name,
+				Type:   distributionType,
+				Host:   sketch.Host,
+				Tags:   normalizeTags(sketch.Tags.UnsafeToReadOnlySliceString()),
+				Values: values,
+			})
+		}
+		for _, sc := range s.ServiceChecks {
+			dump.ServiceChecks = append(dump.ServiceChecks, dumpedServiceCheck{
+				Name:    sc.CheckName,
+				Host:    sc.Host,
+				Tags:    normalizeTags(sc.Tags),
+				Status:  sc.Status.String(),
+				Message: sc.Message,
+			})
+		}
+		for _, e := range s.Events {
+			dump.Events = append(dump.Events, dumpedEvent{
+				Title:          e.Title,
+				Text:           e.Text,
+				Host:           e.Host,
+				Tags:           normalizeTags(e.Tags),
+				Priority:       string(e.Priority),
+				AlertType:      string(e.AlertType),
+				AggregationKey: e.AggregationKey,
+				SourceTypeName: e.SourceTypeName,
+				EventType:      e.EventType,
+			})
+		}
+	}
+
+	sort.SliceStable(dump.Series, func(i, j int) bool {
+		if dump.Series[i].Name != dump.Series[j].Name {
+			return dump.Series[i].Name < dump.Series[j].Name
+		}
+		return dump.Series[i].context() < dump.Series[j].context()
+	})
+	sort.SliceStable(dump.ServiceChecks, func(i, j int) bool {
+		return dump.ServiceChecks[i].context() < dump.ServiceChecks[j].context()
+	})
+	sort.SliceStable(dump.Events, func(i, j int) bool {
+		return dump.Events[i].context() < dump.Events[j].context()
+	})
+	return dump
+}
+
+func writeCheckDump(path string, dump *checkDump) error {
+	content, err := json.MarshalIndent(dump, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(content, '\n'), 0644)
+}
+
+func readCheckDump(path string) (*checkDump, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var dump checkDump
+	if err := json.Unmarshal(content, &dump); err != nil {
+		return nil, fmt.Errorf("unable to parse the check dump %s: %v", path, err)
+	}
+	if dump.Version != checkDumpVersion {
+		return nil, fmt.Errorf("unsupported version %d of the check dump %s, expected %d", dump.Version, path, checkDumpVersion)
+	}
+	return &dump, nil
+}
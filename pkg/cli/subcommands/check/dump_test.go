@@ -0,0 +1,71 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/DataDog/opentelemetry-mapping-go/pkg/quantile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator"
+	"github.com/DataDog/datadog-agent/pkg/metrics"
+	"github.com/DataDog/datadog-agent/pkg/metrics/event"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+	"github.com/DataDog/datadog-agent/pkg/tagset"
+)
+
+func TestNewCheckDump(t *testing.T) {
+	sketch := &quantile.Sketch{}
+	sketch.Insert(quantile.Default(), 1, 2, 3)
+
+	samples := []aggregator.CheckSamples{
+		{
+			Series: metrics.Series{
+				{Name: "zk.connections", Host: "h", MType: metrics.APIGaugeType, Tags: tagset.CompositeTagsFromSlice([]string{"role:leader", "env:prod", "env:prod"}), Points: []metrics.Point{{Ts: 1, Value: 5}}},
+				{Name: "zk.bytes", Host: "h", MType: metrics.APIRateType, Points: []metrics.Point{{Ts: 1, Value: 1}, {Ts: 2, Value: 2}}},
+			},
+			Sketches: metrics.SketchSeriesList{
+				{Name: "zk.latency", Host: "h", Tags: tagset.CompositeTagsFromSlice([]string{"env:prod"}), Points: []metrics.SketchPoint{{Ts: 1, Sketch: sketch}}},
+			},
+			ServiceChecks: servicecheck.ServiceChecks{
+				{CheckName: "zk.can_connect", Host: "h", Ts: 1, Status: servicecheck.ServiceCheckOK, Tags: []string{"port:2181", "host:a"}},
+			},
+		},
+		{
+			Series: metrics.Series{
+				{Name: "zk.connections", Host: "h", MType: metrics.APIGaugeType, Tags: tagset.CompositeTagsFromSlice([]string{"role:follower"}), Points: []metrics.Point{{Ts: 1, Value: 3}}},
+			},
+			Events: event.Events{
+				{Title: "leader elected", Text: "new leader", Ts: 1, Host: "h", AlertType: event.EventAlertTypeInfo, SourceTypeName: "zookeeper"},
+			},
+		},
+	}
+
+	dump := newCheckDump("zk", samples)
+	assert.Equal(t, checkDumpVersion, dump.Version)
+	assert.Equal(t, "zk", dump.Check)
+	assert.Equal(t, []dumpedSerie{
+		{Name: "zk.bytes", Type: "rate", Host: "h", Tags: []string{}, Values: []float64{1, 2}},
+		{Name: "zk.connections", Type: "gauge", Host: "h", Tags: []string{"env:prod", "role:leader"}, Values: []float64{5}},
+		{Name: "zk.connections", Type: "gauge", Host: "h", Tags: []string{"role:follower"}, Values: []float64{3}},
+		{Name: "zk.latency", Type: "distribution", Host: "h", Tags: []string{"env:prod"}, Values: []float64{2}},
+	}, dump.Series)
+	assert.Equal(t, []dumpedServiceCheck{
+		{Name: "zk.can_connect", Host: "h", Tags: []string{"host:a", "port:2181"}, Status: "OK"},
+	}, dump.ServiceChecks)
+	assert.Equal(t, []dumpedEvent{
+		{Title: "leader elected", Text: "new leader", Host: "h", Tags: []string{}, AlertType: "info", SourceTypeName: "zookeeper"},
+	}, dump.Events)
+
+	path := filepath.Join(t.TempDir(), "zk.json")
+	require.NoError(t, writeCheckDump(path, dump))
+	read, err := readCheckDump(path)
+	require.NoError(t, err)
+	assert.Equal(t, dump, read)
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	"github.com/DataDog/datadog-agent/comp/core/log"
 	"github.com/DataDog/datadog-agent/pkg/api/util"
+	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/config/settings"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
 
@@ -27,6 +28,9 @@ type cliParams struct {
 
 	// args are the positional command line args
 	args []string
+
+	// withSources shows the source of the value of every setting
+	withSources bool
 }
 
 type GlobalParams struct {
@@ -63,6 +67,7 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 		Long:  ``,
 		RunE:  oneShotRunE(showRuntimeConfiguration),
 	}
+	cmd.Flags().BoolVar(&cliParams.withSources, "with-sources", false, "show the source of every setting: default, file, environment-variable, remote-config, runtime-setting or agent-runtime")
 
 	listRuntimeCmd := &cobra.Command{
 		Use:   "list-runtime",
@@ -88,6 +93,17 @@ func MakeCommand(globalParamsGetter func() GlobalParams) *cobra.Command {
 	}
 	cmd.AddCommand(getCmd)
 
+	validateCmd := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate a configuration file against the known settings and their types",
+		Long: `Validate a configuration file, by default the one loaded by the agent, against the known settings
+and the types of their default values. Unknown settings, along with the closest known setting,
+settings with a value of the wrong type and deprecated settings are reported.`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: oneShotRunE(validateConfig),
+	}
+	cmd.AddCommand(validateCmd)
+
 	return cmd
 }
 
@@ -102,7 +118,12 @@ func showRuntimeConfiguration(log log.Component, config config.Component, cliPar
 		return err
 	}
 
-	runtimeConfig, err := c.FullConfig()
+	var runtimeConfig string
+	if cliParams.withSources {
+		runtimeConfig, err = c.FullConfigWithSources()
+	} else {
+		runtimeConfig, err = c.FullConfig()
+	}
 	if err != nil {
 		return err
 	}
@@ -191,3 +212,30 @@ func getConfigValue(log log.Component, config config.Component, cliParams *cliPa
 
 	return nil
 }
+
+func validateConfig(log log.Component, config config.Component, cliParams *cliParams) error {
+	path := config.ConfigFileUsed()
+	if len(cliParams.args) == 1 {
+		path = cliParams.args[0]
+	}
+	if path == "" {
+		return fmt.Errorf("no configuration file to validate")
+	}
+
+	validationErrors, err := pkgconfig.ValidateConfigFile(config, path)
+	if err != nil {
+		return fmt.Errorf("unable to validate %s: %w", path, err)
+	}
+
+	if len(validationErrors) == 0 {
+		fmt.Printf("%s is valid\n", path)
+		return nil
+	}
+
+	fmt.Printf("=== %d issue(s) found in %s ===\n", len(validationErrors), path)
+	for _, validationErr := range validationErrors {
+		fmt.Printf("  %s\n", validationErr)
+	}
+
+	return fmt.Errorf("%s is not valid", path)
+}
@@ -32,6 +32,23 @@ func TestConfigCommand(t *testing.T) {
 		})
 }
 
+func TestConfigWithSourcesCommand(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			return GlobalParams{}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"config", "--with-sources"},
+		showRuntimeConfiguration,
+		func(cliParams *cliParams, coreParams core.BundleParams) {
+			require.Equal(t, []string{}, cliParams.args)
+			require.Equal(t, true, cliParams.withSources)
+		})
+}
+
 func TestConfigListRuntimeCommand(t *testing.T) {
 	commands := []*cobra.Command{
 		MakeCommand(func() GlobalParams {
@@ -82,3 +99,20 @@ func TestConfigGetCommand(t *testing.T) {
 			require.Equal(t, false, coreParams.ConfigLoadSecrets())
 		})
 }
+
+func TestConfigValidateCommand(t *testing.T) {
+	commands := []*cobra.Command{
+		MakeCommand(func() GlobalParams {
+			return GlobalParams{}
+		}),
+	}
+
+	fxutil.TestOneShotSubcommand(t,
+		commands,
+		[]string{"config", "validate", "/etc/datadog-agent/datadog.yaml"},
+		validateConfig,
+		func(cliParams *cliParams, coreParams core.BundleParams) {
+			require.Equal(t, []string{"/etc/datadog-agent/datadog.yaml"}, cliParams.args)
+			require.Equal(t, false, coreParams.ConfigLoadSecrets())
+		})
+}
@@ -0,0 +1,126 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"fmt"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	ddconfig "github.com/DataDog/datadog-agent/pkg/config"
+)
+
+// maxBackoffExponent bounds the exponent of the backoff, to prevent overflows
+const maxBackoffExponent = 30
+
+// RunTimeoutError is the error of the runs which didn't complete before the run
+// timeout of the check
+type RunTimeoutError struct {
+	Timeout time.Duration
+}
+
+func (e RunTimeoutError) Error() string {
+	return fmt.Sprintf("the check run didn't complete within its timeout of %v", e.Timeout)
+}
+
+// FailurePolicy holds the options applying to the runs of a check instance which
+// fail or time out, set by the check_run_timeout, check_backoff_failures and
+// check_max_backoff agent options, overridden by the run_timeout,
+// backoff_failures and max_backoff options of the instance configuration.
+type FailurePolicy struct {
+	// RunTimeout is the time after which the worker stops waiting for a run of
+	// the check, which is then considered as failed. 0 disables the timeout.
+	RunTimeout time.Duration
+	// BackoffFailures is the number of consecutive failed runs after which the
+	// interval between the runs of the check doubles after every failed run,
+	// until a run succeeds. 0 disables the backoff.
+	BackoffFailures int
+	// MaxBackoff is the maximum interval between the runs of a check backing off
+	MaxBackoff time.Duration
+}
+
+// FailurePolicyOptions are the failure policy options of a check instance,
+// overriding the agent options when they're set
+type FailurePolicyOptions struct {
+	RunTimeout      *int
+	BackoffFailures *int
+	MaxBackoff      *int
+}
+
+// GetFailurePolicyOptions parses the failure policy options of a check instance
+func GetFailurePolicyOptions(c Info) (FailurePolicyOptions, error) {
+	commonOptions := integration.CommonInstanceConfig{}
+	if err := yaml.Unmarshal([]byte(c.InstanceConfig()), &commonOptions); err != nil {
+		return FailurePolicyOptions{}, fmt.Errorf("invalid instance section: %s", err)
+	}
+
+	return FailurePolicyOptions{
+		RunTimeout:      commonOptions.RunTimeout,
+		BackoffFailures: commonOptions.BackoffFailures,
+		MaxBackoff:      commonOptions.MaxBackoff,
+	}, nil
+}
+
+// FailurePolicy returns the failure policy set by the agent options, overridden
+// by the options of the instance. The agent options are read on every call, as
+// they can be reloaded.
+func (o FailurePolicyOptions) FailurePolicy() (FailurePolicy, error) {
+	policy := FailurePolicy{
+		RunTimeout:      time.Duration(ddconfig.Datadog.GetInt("check_run_timeout")) * time.Second,
+		BackoffFailures: ddconfig.Datadog.GetInt("check_backoff_failures"),
+		MaxBackoff:      time.Duration(ddconfig.Datadog.GetInt("check_max_backoff")) * time.Second,
+	}
+
+	if o.RunTimeout != nil {
+		policy.RunTimeout = time.Duration(*o.RunTimeout) * time.Second
+	}
+	if o.BackoffFailures != nil {
+		policy.BackoffFailures = *o.BackoffFailures
+	}
+	if o.MaxBackoff != nil {
+		policy.MaxBackoff = time.Duration(*o.MaxBackoff) * time.Second
+	}
+
+	if policy.RunTimeout < 0 || policy.BackoffFailures < 0 || policy.MaxBackoff < 0 {
+		return policy, fmt.Errorf("run_timeout, backoff_failures and max_backoff must be positive")
+	}
+	return policy, nil
+}
+
+// GetFailurePolicy returns the failure policy of a check instance
+func GetFailurePolicy(c Info) (FailurePolicy, error) {
+	options, err := GetFailurePolicyOptions(c)
+	if err != nil {
+		policy, _ := FailurePolicyOptions{}.FailurePolicy()
+		return policy, err
+	}
+	return options.FailurePolicy()
+}
+
+// BackoffRuns returns the number of scheduled runs of a check to skip after its
+// last consecutive failed runs, so that the interval between its runs doubles
+// after every failed run past BackoffFailures, without exceeding MaxBackoff.
+func (p FailurePolicy) BackoffRuns(interval time.Duration, failures int) int {
+	if p.BackoffFailures == 0 || failures < p.BackoffFailures || interval <= 0 {
+		return 0
+	}
+
+	exponent := failures - p.BackoffFailures + 1
+	if exponent > maxBackoffExponent {
+		exponent = maxBackoffExponent
+	}
+	runs := 1<<exponent - 1
+
+	if maxRuns := int(p.MaxBackoff/interval) - 1; runs > maxRuns {
+		runs = maxRuns
+	}
+	if runs < 0 {
+		return 0
+	}
+	return runs
+}
@@ -0,0 +1,48 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ddconfig "github.com/DataDog/datadog-agent/pkg/config"
+)
+
+func TestGetFailurePolicy(t *testing.T) {
+	ddconfig.Datadog.Set("check_backoff_failures", 5)
+	defer ddconfig.Datadog.Set("check_backoff_failures", 0)
+
+	policy, err := GetFailurePolicy(&scheduleTestCheck{instance: "host: localhost"})
+	require.NoError(t, err)
+	assert.Equal(t, FailurePolicy{BackoffFailures: 5, MaxBackoff: 600 * time.Second}, policy)
+
+	policy, err = GetFailurePolicy(&scheduleTestCheck{instance: "run_timeout: 10\nbackoff_failures: 0\nmax_backoff: 60"})
+	require.NoError(t, err)
+	assert.Equal(t, FailurePolicy{RunTimeout: 10 * time.Second, MaxBackoff: 60 * time.Second}, policy)
+
+	_, err = GetFailurePolicy(&scheduleTestCheck{instance: "run_timeout: -1"})
+	assert.Error(t, err)
+}
+
+func TestFailurePolicyBackoffRuns(t *testing.T) {
+	policy := FailurePolicy{BackoffFailures: 3, MaxBackoff: 2 * time.Minute}
+
+	for failures, expected := range []int{0, 0, 0, 1, 3, 7, 7, 7} {
+		assert.Equal(t, expected, policy.BackoffRuns(15*time.Second, failures), "failures: %d", failures)
+	}
+	assert.Equal(t, 7, policy.BackoffRuns(15*time.Second, 1000))
+
+	// the interval is already above the max backoff
+	assert.Equal(t, 0, policy.BackoffRuns(5*time.Minute, 5))
+
+	// the backoff is disabled
+	policy.BackoffFailures = 0
+	assert.Equal(t, 0, policy.BackoffRuns(15*time.Second, 5))
+}
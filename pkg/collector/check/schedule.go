@@ -0,0 +1,98 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+)
+
+// OverlapPolicy defines what happens when a check is due to run while its
+// previous run is still in progress
+type OverlapPolicy string
+
+const (
+	// OverlapPolicySkip skips the run, this is the default
+	OverlapPolicySkip OverlapPolicy = "skip"
+	// OverlapPolicyQueue runs the check again as soon as its previous run
+	// completes. At most one run is queued.
+	OverlapPolicyQueue OverlapPolicy = "queue"
+)
+
+// Schedule holds the scheduling options of a check instance, set by the
+// schedule_offset, schedule_jitter, schedule and overlap_policy options of its
+// configuration.
+type Schedule struct {
+	// Interval is the collection interval of the check, ignored when Cron is set
+	Interval time.Duration
+	// Offset delays the first run of the check, the following runs keep the same
+	// pace
+	Offset time.Duration
+	// Jitter adds a random delay, up to its value, to Offset
+	Jitter time.Duration
+	// Cron is a cron expression replacing the collection interval
+	Cron string
+	// OverlapPolicy applies when the check is due while it's still running
+	OverlapPolicy OverlapPolicy
+}
+
+// GetSchedule returns the scheduling options of a check instance
+func GetSchedule(c Info) (Schedule, error) {
+	schedule := Schedule{
+		Interval:      c.Interval(),
+		OverlapPolicy: OverlapPolicySkip,
+	}
+
+	commonOptions := integration.CommonInstanceConfig{}
+	if err := yaml.Unmarshal([]byte(c.InstanceConfig()), &commonOptions); err != nil {
+		return schedule, fmt.Errorf("invalid instance section: %s", err)
+	}
+
+	if commonOptions.ScheduleOffset < 0 || commonOptions.ScheduleJitter < 0 {
+		return schedule, fmt.Errorf("schedule_offset and schedule_jitter must be positive")
+	}
+	schedule.Offset = time.Duration(commonOptions.ScheduleOffset) * time.Second
+	schedule.Jitter = time.Duration(commonOptions.ScheduleJitter) * time.Second
+	schedule.Cron = strings.TrimSpace(commonOptions.Schedule)
+
+	switch policy := OverlapPolicy(commonOptions.OverlapPolicy); policy {
+	case "":
+	case OverlapPolicySkip, OverlapPolicyQueue:
+		schedule.OverlapPolicy = policy
+	default:
+		return schedule, fmt.Errorf("invalid overlap_policy %q, expected %q or %q", policy, OverlapPolicySkip, OverlapPolicyQueue)
+	}
+
+	return schedule, nil
+}
+
+// IsDefault returns whether the check uses none of the scheduling options
+func (s Schedule) IsDefault() bool {
+	return s.Offset == 0 && s.Jitter == 0 && s.Cron == "" && s.OverlapPolicy == OverlapPolicySkip
+}
+
+// String returns a human readable description of the schedule
+func (s Schedule) String() string {
+	var parts []string
+	if s.Cron != "" {
+		parts = append(parts, fmt.Sprintf("cron %q", s.Cron))
+	} else {
+		parts = append(parts, fmt.Sprintf("every %v", s.Interval))
+	}
+	if s.Offset != 0 {
+		parts = append(parts, fmt.Sprintf("offset %v", s.Offset))
+	}
+	if s.Jitter != 0 {
+		parts = append(parts, fmt.Sprintf("jitter up to %v", s.Jitter))
+	}
+	parts = append(parts, fmt.Sprintf("%s overlapping runs", s.OverlapPolicy))
+	return strings.Join(parts, ", ")
+}
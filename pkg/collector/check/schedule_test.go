@@ -0,0 +1,54 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package check
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
+)
+
+type scheduleTestCheck struct {
+	instance string
+}
+
+func (c *scheduleTestCheck) String() string          { return "test" }
+func (c *scheduleTestCheck) Interval() time.Duration { return 15 * time.Second }
+func (c *scheduleTestCheck) ID() checkid.ID          { return "test:123" }
+func (c *scheduleTestCheck) Version() string         { return "" }
+func (c *scheduleTestCheck) ConfigSource() string    { return "" }
+func (c *scheduleTestCheck) InitConfig() string      { return "" }
+func (c *scheduleTestCheck) InstanceConfig() string  { return c.instance }
+
+func TestGetSchedule(t *testing.T) {
+	schedule, err := GetSchedule(&scheduleTestCheck{instance: "host: localhost"})
+	require.NoError(t, err)
+	assert.True(t, schedule.IsDefault())
+	assert.Equal(t, Schedule{Interval: 15 * time.Second, OverlapPolicy: OverlapPolicySkip}, schedule)
+	assert.Equal(t, "every 15s, skip overlapping runs", schedule.String())
+
+	schedule, err = GetSchedule(&scheduleTestCheck{instance: "schedule_offset: 30\nschedule_jitter: 10\noverlap_policy: queue"})
+	require.NoError(t, err)
+	assert.False(t, schedule.IsDefault())
+	assert.Equal(t, 30*time.Second, schedule.Offset)
+	assert.Equal(t, 10*time.Second, schedule.Jitter)
+	assert.Equal(t, OverlapPolicyQueue, schedule.OverlapPolicy)
+	assert.Equal(t, "every 15s, offset 30s, jitter up to 10s, queue overlapping runs", schedule.String())
+
+	schedule, err = GetSchedule(&scheduleTestCheck{instance: "schedule: '0 * * * *'"})
+	require.NoError(t, err)
+	assert.Equal(t, "0 * * * *", schedule.Cron)
+	assert.Equal(t, `cron "0 * * * *", skip overlapping runs`, schedule.String())
+
+	_, err = GetSchedule(&scheduleTestCheck{instance: "overlap_policy: wait"})
+	assert.Error(t, err)
+	_, err = GetSchedule(&scheduleTestCheck{instance: "schedule_jitter: -1"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package stats
+
+import (
+	"runtime"
+	"runtime/metrics"
+	"sync"
+	"time"
+)
+
+const goHeapAllocsMetric = "/gc/heap/allocs:bytes"
+
+var (
+	allocationCounters     []func() uint64
+	allocationCountersLock sync.RWMutex
+)
+
+// ResourceUsage holds the resources used by a check run
+type ResourceUsage struct {
+	// CPUTime is the CPU time of the thread running the check, which includes
+	// the time spent in the Python interpreter for Python checks but not the
+	// time of the goroutines started by the check
+	CPUTime time.Duration
+	// AllocatedBytes is the memory allocated by the agent while the check was
+	// running, in the Go heap and in the allocation counters registered with
+	// RegisterAllocationCounter. It includes the allocations of the other checks
+	// running at the same time.
+	AllocatedBytes uint64
+}
+
+// RegisterAllocationCounter registers a function returning the total number of
+// bytes allocated outside of the Go heap, such as by the Python interpreter, to
+// be included in the ResourceUsage of the check runs
+func RegisterAllocationCounter(counter func() uint64) {
+	allocationCountersLock.Lock()
+	defer allocationCountersLock.Unlock()
+
+	allocationCounters = append(allocationCounters, counter)
+}
+
+// ResourceUsageMeter measures the resources used by a check run
+type ResourceUsageMeter struct {
+	startCPUTime   time.Duration
+	cpuTimeOK      bool
+	startAllocated uint64
+}
+
+// StartResourceUsageMeter starts measuring the resources used by a check run.
+// The calling goroutine is locked to its thread until Stop is called, which must
+// be done by the same goroutine.
+func StartResourceUsageMeter() *ResourceUsageMeter {
+	runtime.LockOSThread()
+
+	m := &ResourceUsageMeter{startAllocated: allocatedBytes()}
+	m.startCPUTime, m.cpuTimeOK = threadCPUTime()
+	return m
+}
+
+// Stop returns the resources used since the meter started
+func (m *ResourceUsageMeter) Stop() ResourceUsage {
+	var usage ResourceUsage
+	if m.cpuTimeOK {
+		if cpuTime, ok := threadCPUTime(); ok && cpuTime > m.startCPUTime {
+			usage.CPUTime = cpuTime - m.startCPUTime
+		}
+	}
+	runtime.UnlockOSThread()
+
+	if allocated := allocatedBytes(); allocated > m.startAllocated {
+		usage.AllocatedBytes = allocated - m.startAllocated
+	}
+	return usage
+}
+
+// allocatedBytes returns the total number of bytes allocated by the agent
+func allocatedBytes() uint64 {
+	sample := []metrics.Sample{{Name: goHeapAllocsMetric}}
+	metrics.Read(sample)
+
+	var allocated uint64
+	if sample[0].Value.Kind() == metrics.KindUint64 {
+		allocated = sample[0].Value.Uint64()
+	}
+
+	allocationCountersLock.RLock()
+	defer allocationCountersLock.RUnlock()
+	for _, counter := range allocationCounters {
+		allocated += counter()
+	}
+	return allocated
+}
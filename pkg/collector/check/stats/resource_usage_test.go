@@ -0,0 +1,42 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package stats
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var allocationSink []byte
+
+func TestResourceUsageMeter(t *testing.T) {
+	meter := StartResourceUsageMeter()
+	for start := time.Now(); time.Since(start) < 50*time.Millisecond; {
+		allocationSink = make([]byte, 1024)
+	}
+	usage := meter.Stop()
+
+	if runtime.GOOS == "linux" || runtime.GOOS == "windows" || runtime.GOOS == "darwin" {
+		assert.Greater(t, usage.CPUTime, time.Duration(0))
+		assert.LessOrEqual(t, usage.CPUTime, time.Second)
+	}
+	assert.GreaterOrEqual(t, usage.AllocatedBytes, uint64(1024))
+}
+
+func TestResourceUsageMeterAllocationCounter(t *testing.T) {
+	var pythonAllocated uint64
+	RegisterAllocationCounter(func() uint64 { return pythonAllocated })
+	defer func() { allocationCounters = nil }()
+
+	meter := StartResourceUsageMeter()
+	pythonAllocated += 1 << 30
+	usage := meter.Stop()
+
+	assert.GreaterOrEqual(t, usage.AllocatedBytes, uint64(1<<30))
+}
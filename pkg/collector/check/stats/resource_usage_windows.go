@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package stats
+
+import (
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+var procGetThreadTimes = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetThreadTimes")
+
+// threadCPUTime returns the CPU time, user and kernel, of the calling thread
+func threadCPUTime() (time.Duration, bool) {
+	var creationTime, exitTime, kernelTime, userTime windows.Filetime
+	r, _, _ := procGetThreadTimes.Call(
+		uintptr(windows.CurrentThread()),
+		uintptr(unsafe.Pointer(&creationTime)),
+		uintptr(unsafe.Pointer(&exitTime)),
+		uintptr(unsafe.Pointer(&kernelTime)),
+		uintptr(unsafe.Pointer(&userTime)),
+	)
+	if r == 0 {
+		return 0, false
+	}
+	// FILETIMEs are in 100-nanosecond intervals
+	ticks := uint64(kernelTime.HighDateTime)<<32 | uint64(kernelTime.LowDateTime)
+	ticks += uint64(userTime.HighDateTime)<<32 | uint64(userTime.LowDateTime)
+	return time.Duration(ticks * 100), true
+}
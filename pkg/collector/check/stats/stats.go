@@ -48,6 +48,10 @@ var (
 		[]string{"check_name"}, "Histogram buckets count")
 	tlmExecutionTime = telemetry.NewGauge("checks", "execution_time",
 		[]string{"check_name"}, "Check execution time")
+	tlmCPUTime = telemetry.NewCounter("checks", "cpu_time",
+		[]string{"check_name"}, "Check CPU time in milliseconds")
+	tlmAllocatedBytes = telemetry.NewCounter("checks", "allocated_bytes",
+		[]string{"check_name"}, "Bytes allocated while the check was running")
 )
 
 // SenderStats contains statistics showing the count of various types of telemetry sent by a check sender
@@ -103,6 +107,20 @@ type Stats struct {
 	LastError                string    // error that occurred in the last run, if any
 	LastWarnings             []string  // warnings that occurred in the last run, if any
 	UpdateTimestamp          int64     // latest update to this instance, unix timestamp in seconds
+	Schedule                 string    // description of the scheduling options of the instance, if any is set
+	SkippedRuns              uint64    // runs skipped because the previous run was still in progress
+	QueuedRuns               uint64    // runs queued because the previous run was still in progress
+	LastCPUTime              int64     // CPU time of the most recent run, in microseconds
+	AverageCPUTime           int64     // average CPU time of the runs, in microseconds
+	TotalCPUTime             int64     // CPU time of all the runs, in microseconds
+	LastAllocatedBytes       uint64    // memory allocated during the most recent run
+	TotalAllocatedBytes      uint64    // memory allocated during all the runs
+	resourceUsageRuns        uint64    // number of runs whose resource usage was measured
+	TimedOutRuns             uint64    // runs which didn't complete within the run timeout
+	StuckRuns                int64     // timed out runs still in progress
+	ConsecutiveFailures      int64     // failed runs since the last successful one
+	BackoffRemainingRuns     int64     // scheduled runs left to skip as the check is backing off
+	BackoffSkippedRuns       uint64    // scheduled runs skipped as the check was backing off
 	m                        sync.Mutex
 	telemetry                bool // do we want telemetry on this Check
 }
@@ -225,6 +243,68 @@ func (cs *Stats) Add(t time.Duration, err error, warnings []error, metricStats S
 	}
 }
 
+// AddResourceUsage tracks the resources used by a run
+func (cs *Stats) AddResourceUsage(usage ResourceUsage) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	cpuTime := usage.CPUTime.Microseconds()
+	cs.resourceUsageRuns++
+	cs.LastCPUTime = cpuTime
+	cs.TotalCPUTime += cpuTime
+	cs.AverageCPUTime = cs.TotalCPUTime / int64(cs.resourceUsageRuns)
+	cs.LastAllocatedBytes = usage.AllocatedBytes
+	cs.TotalAllocatedBytes += usage.AllocatedBytes
+	if cs.telemetry {
+		tlmCPUTime.Add(float64(cpuTime)/1e3, cs.CheckName)
+		tlmAllocatedBytes.Add(float64(usage.AllocatedBytes), cs.CheckName)
+	}
+}
+
+// AddRunFailures tracks the consecutive failed runs of the check and the number
+// of its scheduled runs to skip as it's backing off, after a run
+func (cs *Stats) AddRunFailures(failures, backoffRuns int, timedOut bool) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	cs.ConsecutiveFailures = int64(failures)
+	cs.BackoffRemainingRuns = int64(backoffRuns)
+	if timedOut {
+		cs.TimedOutRuns++
+	}
+}
+
+// AddStuckRun adds delta to the number of timed out runs of the check still in
+// progress
+func (cs *Stats) AddStuckRun(delta int) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	cs.StuckRuns += int64(delta)
+}
+
+// AddBackoffRun tracks a scheduled run skipped as the check is backing off
+func (cs *Stats) AddBackoffRun(remainingRuns int) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	cs.BackoffRemainingRuns = int64(remainingRuns)
+	cs.BackoffSkippedRuns++
+}
+
+// AddOverlappingRun tracks a run which was due while the previous run was still
+// in progress, and which was either queued or skipped
+func (cs *Stats) AddOverlappingRun(queued bool) {
+	cs.m.Lock()
+	defer cs.m.Unlock()
+
+	if queued {
+		cs.QueuedRuns++
+	} else {
+		cs.SkippedRuns++
+	}
+}
+
 type aggStats struct {
 	EventPlatformEvents       map[string]interface{}
 	EventPlatformEventsErrors map[string]interface{}
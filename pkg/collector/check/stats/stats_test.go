@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -139,3 +140,28 @@ func TestTranslateEventPlatformEventTypes(t *testing.T) {
 	assert.True(t, assert.ObjectsAreEqual(expected, result))
 	assert.EqualValues(t, expected, result)
 }
+
+func TestAddResourceUsage(t *testing.T) {
+	mockConfig := agentConfig.Mock(t)
+	mockConfig.Set("telemetry.enabled", true)
+	mockConfig.Set("telemetry.checks", "*")
+
+	stats := NewStats(&mockCheck{id: "resourceCheckID", stringVal: "resourceCheck"})
+
+	stats.AddResourceUsage(ResourceUsage{CPUTime: 30 * time.Millisecond, AllocatedBytes: 1024})
+	stats.AddResourceUsage(ResourceUsage{CPUTime: 10 * time.Millisecond, AllocatedBytes: 2048})
+
+	assert.Equal(t, int64(10000), stats.LastCPUTime)
+	assert.Equal(t, int64(20000), stats.AverageCPUTime)
+	assert.Equal(t, int64(40000), stats.TotalCPUTime)
+	assert.Equal(t, uint64(2048), stats.LastAllocatedBytes)
+	assert.Equal(t, uint64(3072), stats.TotalAllocatedBytes)
+
+	tlmData, err := getTelemetryData()
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Contains(t, tlmData, "checks__cpu_time{check_name=\"resourceCheck\"} 40")
+	assert.Contains(t, tlmData, "checks__allocated_bytes{check_name=\"resourceCheck\"} 3072")
+}
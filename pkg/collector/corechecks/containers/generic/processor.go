@@ -150,6 +150,8 @@ func (p *Processor) processContainer(sender sender.Sender, tags []string, contai
 		p.sendMetric(sender.Rate, "container.cpu.throttled", containerStats.CPU.ThrottledTime, tags)
 		p.sendMetric(sender.Rate, "container.cpu.throttled.periods", containerStats.CPU.ThrottledPeriods, tags)
 		p.sendMetric(sender.Rate, "container.cpu.partial_stall", containerStats.CPU.PartialStallTime, tags)
+		p.sendMetric(sender.Rate, "container.cpu.full_stall", containerStats.CPU.FullStallTime, tags)
+		p.sendPSIMetrics(sender, "container.cpu.pressure", containerStats.CPU.PSISome, containerStats.CPU.PSIFull, tags)
 		// Convert CPU Limit to nanoseconds to allow easy percentage computation in the App.
 		if containerStats.CPU.Limit != nil {
 			p.sendMetric(sender.Gauge, "container.cpu.limit", pointer.Ptr(*containerStats.CPU.Limit*float64(time.Second/100)), tags)
@@ -170,6 +172,8 @@ func (p *Processor) processContainer(sender sender.Sender, tags []string, contai
 		p.sendMetric(sender.Gauge, "container.memory.commit", containerStats.Memory.CommitBytes, tags)
 		p.sendMetric(sender.Gauge, "container.memory.commit.peak", containerStats.Memory.CommitPeakBytes, tags)
 		p.sendMetric(sender.Rate, "container.memory.partial_stall", containerStats.Memory.PartialStallTime, tags)
+		p.sendMetric(sender.Rate, "container.memory.full_stall", containerStats.Memory.FullStallTime, tags)
+		p.sendPSIMetrics(sender, "container.memory.pressure", containerStats.Memory.PSISome, containerStats.Memory.PSIFull, tags)
 	}
 
 	if containerStats.IO != nil {
@@ -189,6 +193,8 @@ func (p *Processor) processContainer(sender sender.Sender, tags []string, contai
 		}
 
 		p.sendMetric(sender.Rate, "container.io.partial_stall", containerStats.IO.PartialStallTime, tags)
+		p.sendMetric(sender.Rate, "container.io.full_stall", containerStats.IO.FullStallTime, tags)
+		p.sendPSIMetrics(sender, "container.io.pressure", containerStats.IO.PSISome, containerStats.IO.PSIFull, tags)
 	}
 
 	if containerStats.PID != nil {
@@ -199,6 +205,17 @@ func (p *Processor) processContainer(sender sender.Sender, tags []string, contai
 	return nil
 }
 
+func (p *Processor) sendPSIMetrics(sender sender.Sender, prefix string, some, full *metrics.PSIStats, tags []string) {
+	for kind, stats := range map[string]*metrics.PSIStats{"some": some, "full": full} {
+		if stats == nil {
+			continue
+		}
+		p.sendMetric(sender.Gauge, prefix+"."+kind+".avg10", stats.Avg10, tags)
+		p.sendMetric(sender.Gauge, prefix+"."+kind+".avg60", stats.Avg60, tags)
+		p.sendMetric(sender.Gauge, prefix+"."+kind+".avg300", stats.Avg300, tags)
+	}
+}
+
 func (p *Processor) sendMetric(senderFunc func(string, float64, string, []string), metricName string, value *float64, tags []string) {
 	if value == nil {
 		return
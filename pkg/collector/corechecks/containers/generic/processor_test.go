@@ -36,8 +36,8 @@ func TestProcessorRunFullStatsLinux(t *testing.T) {
 	assert.ErrorIs(t, err, nil)
 
 	expectedTags := []string{"runtime:docker"}
-	mockSender.AssertNumberOfCalls(t, "Rate", 20)
-	mockSender.AssertNumberOfCalls(t, "Gauge", 15)
+	mockSender.AssertNumberOfCalls(t, "Rate", 22)
+	mockSender.AssertNumberOfCalls(t, "Gauge", 24)
 
 	mockSender.AssertMetricInRange(t, "Gauge", "container.uptime", 0, 600, "", expectedTags)
 	mockSender.AssertMetric(t, "Rate", "container.cpu.usage", 100, "", expectedTags)
@@ -46,6 +46,9 @@ func TestProcessorRunFullStatsLinux(t *testing.T) {
 	mockSender.AssertMetric(t, "Rate", "container.cpu.throttled", 100, "", expectedTags)
 	mockSender.AssertMetric(t, "Rate", "container.cpu.throttled.periods", 0, "", expectedTags)
 	mockSender.AssertMetric(t, "Rate", "container.cpu.partial_stall", 96000, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.cpu.pressure.some.avg10", 1.5, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.cpu.pressure.some.avg60", 1.0, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.cpu.pressure.some.avg300", 0.5, "", expectedTags)
 	mockSender.AssertMetric(t, "Gauge", "container.cpu.limit", 500000000, "", expectedTags)
 
 	mockSender.AssertMetric(t, "Gauge", "container.memory.usage", 42000, "", expectedTags)
@@ -58,8 +61,13 @@ func TestProcessorRunFullStatsLinux(t *testing.T) {
 	mockSender.AssertMetric(t, "Gauge", "container.memory.swap", 0, "", expectedTags)
 	mockSender.AssertMetric(t, "Gauge", "container.memory.oom_events", 10, "", expectedTags)
 	mockSender.AssertMetric(t, "Rate", "container.memory.partial_stall", 97000, "", expectedTags)
+	mockSender.AssertMetric(t, "Rate", "container.memory.full_stall", 47000, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.memory.pressure.some.avg10", 2.5, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.memory.pressure.full.avg10", 0.5, "", expectedTags)
+	mockSender.AssertMetric(t, "Gauge", "container.memory.pressure.full.avg300", 0.1, "", expectedTags)
 
 	mockSender.AssertMetric(t, "Rate", "container.io.partial_stall", 98000, "", expectedTags)
+	mockSender.AssertMetric(t, "Rate", "container.io.full_stall", 48000, "", expectedTags)
 	expectedFooTags := taggerUtils.ConcatenateStringTags(expectedTags, "device:/dev/foo", "device_name:/dev/foo")
 	mockSender.AssertMetric(t, "Rate", "container.io.read", 100, "", expectedFooTags)
 	mockSender.AssertMetric(t, "Rate", "container.io.read.operations", 10, "", expectedFooTags)
@@ -0,0 +1,309 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package net
+
+import (
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	httpCheckName = "http_check"
+
+	httpCanConnectServiceCheck = "http.can_connect"
+	httpSSLCertServiceCheck    = "http.ssl_cert"
+
+	// maxHTTPContentSize is the maximum size of the response body read for content matching
+	maxHTTPContentSize = 10 * 1024 * 1024
+	// maxHTTPContentInMessage is the maximum size of the content included in service check messages
+	maxHTTPContentInMessage = 200
+)
+
+// for testing purpose
+var httpCheckNow = time.Now
+
+// HTTPCheck probes an HTTP(S) endpoint and reports its availability, its response
+// time and the expiration of its TLS certificate.
+type HTTPCheck struct {
+	core.CheckBase
+	cfg    *httpConfig
+	client *http.Client
+}
+
+type httpInstanceConfig struct {
+	Name                       string            `yaml:"name"`
+	URL                        string            `yaml:"url"`
+	Method                     string            `yaml:"method"`
+	Data                       string            `yaml:"data"`
+	Headers                    map[string]string `yaml:"headers"`
+	Username                   string            `yaml:"username"`
+	Password                   string            `yaml:"password"`
+	Timeout                    float64           `yaml:"timeout"`
+	HTTPResponseStatusCode     string            `yaml:"http_response_status_code"`
+	ContentMatch               string            `yaml:"content_match"`
+	ReverseContentMatch        bool              `yaml:"reverse_content_match"`
+	IncludeContent             bool              `yaml:"include_content"`
+	AllowRedirects             *bool             `yaml:"allow_redirects"`
+	SkipProxy                  bool              `yaml:"skip_proxy"`
+	TLSVerify                  *bool             `yaml:"tls_verify"`
+	TLSCACert                  string            `yaml:"tls_ca_cert"`
+	TLSCert                    string            `yaml:"tls_cert"`
+	TLSPrivateKey              string            `yaml:"tls_private_key"`
+	CheckCertificateExpiration *bool             `yaml:"check_certificate_expiration"`
+	DaysWarning                float64           `yaml:"days_warning"`
+	DaysCritical               float64           `yaml:"days_critical"`
+}
+
+type httpConfig struct {
+	instance     httpInstanceConfig
+	statusCode   *regexp.Regexp
+	contentMatch *regexp.Regexp
+	tags         []string
+}
+
+func (c *HTTPCheck) String() string {
+	return "http_check"
+}
+
+func (c *httpConfig) parse(data []byte) error {
+	if err := yaml.Unmarshal(data, &c.instance); err != nil {
+		return err
+	}
+	if c.instance.URL == "" {
+		return errors.New("url is required")
+	}
+	if !strings.HasPrefix(c.instance.URL, "http://") && !strings.HasPrefix(c.instance.URL, "https://") {
+		return fmt.Errorf("url %s should start with http:// or https://", c.instance.URL)
+	}
+	if c.instance.Method == "" {
+		c.instance.Method = http.MethodGet
+	}
+	c.instance.Method = strings.ToUpper(c.instance.Method)
+	if c.instance.Timeout <= 0 {
+		c.instance.Timeout = 10
+	}
+	if c.instance.HTTPResponseStatusCode == "" {
+		c.instance.HTTPResponseStatusCode = `(1|2|3)\d\d`
+	}
+	if c.instance.DaysWarning == 0 {
+		c.instance.DaysWarning = 14
+	}
+	if c.instance.DaysCritical == 0 {
+		c.instance.DaysCritical = 7
+	}
+	if c.instance.DaysCritical > c.instance.DaysWarning {
+		return fmt.Errorf("days_critical (%v) should not be greater than days_warning (%v)", c.instance.DaysCritical, c.instance.DaysWarning)
+	}
+
+	var err error
+	if c.statusCode, err = regexp.Compile("^(?:" + c.instance.HTTPResponseStatusCode + ")$"); err != nil {
+		return fmt.Errorf("invalid http_response_status_code: %s", err)
+	}
+	if c.instance.ContentMatch != "" {
+		if c.contentMatch, err = regexp.Compile(c.instance.ContentMatch); err != nil {
+			return fmt.Errorf("invalid content_match: %s", err)
+		}
+	}
+
+	c.tags = []string{"url:" + c.instance.URL}
+	if c.instance.Name != "" {
+		c.tags = append(c.tags, "instance:"+c.instance.Name)
+	}
+	return nil
+}
+
+func boolOrDefault(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
+
+// Configure configure the data from the yaml
+func (c *HTTPCheck) Configure(integrationConfigDigest uint64, data integration.Data, initConfig integration.Data, source string) error {
+	cfg := new(httpConfig)
+	if err := cfg.parse(data); err != nil {
+		log.Errorf("Error parsing configuration file: %s", err)
+		return err
+	}
+
+	c.BuildID(integrationConfigDigest, data, initConfig)
+	c.cfg = cfg
+
+	transport := httputils.CreateHTTPTransport()
+	if cfg.instance.SkipProxy {
+		transport.Proxy = nil
+	}
+	tlsConfig := transport.TLSClientConfig.Clone()
+	tlsConfig.InsecureSkipVerify = !boolOrDefault(cfg.instance.TLSVerify, true)
+	if err := httputils.LoadClientTLSFiles(tlsConfig, cfg.instance.TLSCACert, cfg.instance.TLSCert, cfg.instance.TLSPrivateKey); err != nil {
+		return err
+	}
+	transport.TLSClientConfig = tlsConfig
+	// the connection is not reused between runs so that the TLS handshake,
+	// and thus the certificate, is checked on every run
+	transport.DisableKeepAlives = true
+
+	c.client = &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(cfg.instance.Timeout * float64(time.Second)),
+	}
+	if !boolOrDefault(cfg.instance.AllowRedirects, true) {
+		c.client.CheckRedirect = func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		}
+	}
+
+	return c.CommonConfigure(integrationConfigDigest, initConfig, data, source)
+}
+
+// Run runs the check
+func (c *HTTPCheck) Run() error {
+	sender, err := c.GetSender()
+	if err != nil {
+		return err
+	}
+	defer sender.Commit()
+
+	inst := c.cfg.instance
+	var body io.Reader
+	if inst.Data != "" {
+		body = strings.NewReader(inst.Data)
+	}
+	req, err := http.NewRequest(inst.Method, inst.URL, body)
+	if err != nil {
+		return err
+	}
+	for k, v := range inst.Headers {
+		if strings.EqualFold(k, "Host") {
+			req.Host = v
+			continue
+		}
+		req.Header.Set(k, v)
+	}
+	if inst.Username != "" {
+		req.SetBasicAuth(inst.Username, inst.Password)
+	}
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		c.reportCanConnect(sender, servicecheck.ServiceCheckCritical, err.Error())
+		var certErr x509.CertificateInvalidError
+		var authErr x509.UnknownAuthorityError
+		var hostErr x509.HostnameError
+		if errors.As(err, &certErr) || errors.As(err, &authErr) || errors.As(err, &hostErr) {
+			sender.ServiceCheck(httpSSLCertServiceCheck, servicecheck.ServiceCheckCritical, "", c.cfg.tags, err.Error())
+		}
+		return nil
+	}
+	defer resp.Body.Close()
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPContentSize))
+	elapsed := time.Since(start)
+	if err != nil {
+		c.reportCanConnect(sender, servicecheck.ServiceCheckCritical, fmt.Sprintf("Unable to read the response: %s", err))
+		return nil
+	}
+	sender.Gauge("network.http.response_time", elapsed.Seconds(), "", c.cfg.tags)
+
+	status, message := c.checkResponse(resp, content)
+	c.reportCanConnect(sender, status, message)
+
+	if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 && boolOrDefault(inst.CheckCertificateExpiration, true) {
+		c.reportCertificateExpiration(sender, resp.TLS.PeerCertificates[0])
+	}
+	return nil
+}
+
+// checkResponse returns the status of the http.can_connect service check for the
+// given response, along with its message.
+func (c *HTTPCheck) checkResponse(resp *http.Response, content []byte) (servicecheck.ServiceCheckStatus, string) {
+	inst := c.cfg.instance
+	if !c.cfg.statusCode.MatchString(fmt.Sprint(resp.StatusCode)) {
+		message := fmt.Sprintf("Incorrect HTTP return code for url %s. Expected %s, got %d.", inst.URL, inst.HTTPResponseStatusCode, resp.StatusCode)
+		if inst.IncludeContent {
+			message += "\nContent: " + truncateContent(content)
+		}
+		return servicecheck.ServiceCheckCritical, message
+	}
+	if c.cfg.contentMatch != nil {
+		matched := c.cfg.contentMatch.Match(content)
+		switch {
+		case matched && inst.ReverseContentMatch:
+			return servicecheck.ServiceCheckCritical, fmt.Sprintf("Content %q found in the response", inst.ContentMatch)
+		case !matched && !inst.ReverseContentMatch:
+			message := fmt.Sprintf("Content %q not found in the response", inst.ContentMatch)
+			if inst.IncludeContent {
+				message += "\nContent: " + truncateContent(content)
+			}
+			return servicecheck.ServiceCheckCritical, message
+		}
+	}
+	return servicecheck.ServiceCheckOK, ""
+}
+
+func truncateContent(content []byte) string {
+	if len(content) > maxHTTPContentInMessage {
+		return string(content[:maxHTTPContentInMessage]) + "..."
+	}
+	return string(content)
+}
+
+func (c *HTTPCheck) reportCanConnect(sender sender.Sender, status servicecheck.ServiceCheckStatus, message string) {
+	canConnect := 0.0
+	if status == servicecheck.ServiceCheckOK {
+		canConnect = 1
+	}
+	sender.Gauge("network.http.can_connect", canConnect, "", c.cfg.tags)
+	sender.Gauge("network.http.cant_connect", 1-canConnect, "", c.cfg.tags)
+	sender.ServiceCheck(httpCanConnectServiceCheck, status, "", c.cfg.tags, message)
+}
+
+func (c *HTTPCheck) reportCertificateExpiration(sender sender.Sender, cert *x509.Certificate) {
+	left := cert.NotAfter.Sub(httpCheckNow())
+	daysLeft := left.Hours() / 24
+	sender.Gauge("http.ssl.days_left", daysLeft, "", c.cfg.tags)
+	sender.Gauge("http.ssl.seconds_left", left.Seconds(), "", c.cfg.tags)
+
+	status := servicecheck.ServiceCheckOK
+	message := fmt.Sprintf("Days left: %.0f", daysLeft)
+	switch {
+	case left <= 0:
+		status = servicecheck.ServiceCheckCritical
+		message = fmt.Sprintf("Certificate expired on %s", cert.NotAfter.UTC().Format(time.RFC3339))
+	case daysLeft < c.cfg.instance.DaysCritical:
+		status = servicecheck.ServiceCheckCritical
+	case daysLeft < c.cfg.instance.DaysWarning:
+		status = servicecheck.ServiceCheckWarning
+	}
+	sender.ServiceCheck(httpSSLCertServiceCheck, status, "", c.cfg.tags, message)
+}
+
+func httpCheckFactory() check.Check {
+	return &HTTPCheck{
+		CheckBase: core.NewCheckBase(httpCheckName),
+	}
+}
+
+func init() {
+	core.RegisterCheck(httpCheckName, httpCheckFactory)
+}
@@ -0,0 +1,184 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package net
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+)
+
+func httpTestHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/ok":
+		fmt.Fprint(w, `{"status": "healthy"}`)
+	case "/echo":
+		w.Header().Set("X-Method", r.Method)
+		fmt.Fprintf(w, "%s %s", r.Method, r.Header.Get("X-Test"))
+	case "/redirect":
+		http.Redirect(w, r, "/ok", http.StatusFound)
+	default:
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprint(w, "maintenance")
+	}
+}
+
+func runHTTPCheck(t *testing.T, instance string) *mocksender.MockSender {
+	httpCheck := httpCheckFactory().(*HTTPCheck)
+	require.NoError(t, httpCheck.Configure(integration.FakeConfigHash, []byte(instance), nil, "test"))
+	mockSender := mocksender.NewMockSender(httpCheck.ID())
+	mockSender.SetupAcceptAll()
+	require.NoError(t, httpCheck.Run())
+	mockSender.AssertNumberOfCalls(t, "Commit", 1)
+	return mockSender
+}
+
+func TestHTTPCheckConfig(t *testing.T) {
+	for name, tt := range map[string]struct {
+		instance string
+		err      string
+	}{
+		"no url":         {"name: test", "url is required"},
+		"bad scheme":     {"url: ftp://example.com", "should start with http:// or https://"},
+		"bad status":     {"url: http://example.com\nhttp_response_status_code: '('", "invalid http_response_status_code"},
+		"bad content":    {"url: http://example.com\ncontent_match: '('", "invalid content_match"},
+		"bad thresholds": {"url: http://example.com\ndays_warning: 5\ndays_critical: 10", "days_critical (10) should not be greater than days_warning (5)"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cfg := new(httpConfig)
+			err := cfg.parse([]byte(tt.instance))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+
+	cfg := new(httpConfig)
+	require.NoError(t, cfg.parse([]byte("url: http://example.com\nname: example\nmethod: post")))
+	assert.Equal(t, "POST", cfg.instance.Method)
+	assert.Equal(t, 10.0, cfg.instance.Timeout)
+	assert.Equal(t, []string{"url:http://example.com", "instance:example"}, cfg.tags)
+}
+
+func TestHTTPCheckOK(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(httpTestHandler))
+	defer srv.Close()
+
+	tags := []string{"url:" + srv.URL + "/ok", "instance:test"}
+	mockSender := runHTTPCheck(t, fmt.Sprintf("name: test\nurl: %s/ok\ncontent_match: healthy", srv.URL))
+	mockSender.AssertMetricInRange(t, "Gauge", "network.http.response_time", 0, 5, "", tags)
+	mockSender.AssertMetric(t, "Gauge", "network.http.can_connect", 1, "", tags)
+	mockSender.AssertMetric(t, "Gauge", "network.http.cant_connect", 0, "", tags)
+	mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckOK, "", tags, "")
+	mockSender.AssertNotCalled(t, "ServiceCheck", "http.ssl_cert", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPCheckRequest(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(httpTestHandler))
+	defer srv.Close()
+
+	mockSender := runHTTPCheck(t, fmt.Sprintf(`
+url: %s/echo
+method: put
+data: payload
+headers:
+  X-Test: value
+content_match: '^PUT value$'
+`, srv.URL))
+	mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckOK, "", nil, "")
+}
+
+func TestHTTPCheckFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(httpTestHandler))
+	defer srv.Close()
+
+	for name, tt := range map[string]struct {
+		instance string
+		message  string
+	}{
+		"status": {
+			fmt.Sprintf("url: %s/down\ninclude_content: true", srv.URL),
+			fmt.Sprintf("Incorrect HTTP return code for url %s/down. Expected (1|2|3)\\d\\d, got 503.\nContent: maintenance", srv.URL),
+		},
+		"content": {
+			fmt.Sprintf("url: %s/ok\ncontent_match: unhealthy", srv.URL),
+			`Content "unhealthy" not found in the response`,
+		},
+		"reverse content": {
+			fmt.Sprintf("url: %s/ok\ncontent_match: healthy\nreverse_content_match: true", srv.URL),
+			`Content "healthy" found in the response`,
+		},
+		"redirect": {
+			fmt.Sprintf("url: %s/redirect\nallow_redirects: false\nhttp_response_status_code: '2\\d\\d'", srv.URL),
+			fmt.Sprintf("Incorrect HTTP return code for url %s/redirect. Expected 2\\d\\d, got 302.", srv.URL),
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			mockSender := runHTTPCheck(t, tt.instance)
+			mockSender.AssertMetric(t, "Gauge", "network.http.can_connect", 0, "", nil)
+			mockSender.AssertMetric(t, "Gauge", "network.http.cant_connect", 1, "", nil)
+			mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckCritical, "", nil, tt.message)
+		})
+	}
+
+	srv.Close()
+	mockSender := runHTTPCheck(t, "url: "+srv.URL)
+	mockSender.AssertMetric(t, "Gauge", "network.http.can_connect", 0, "", nil)
+	mockSender.AssertNotCalled(t, "Gauge", "network.http.response_time", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHTTPCheckCertificate(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(httpTestHandler))
+	defer srv.Close()
+	notAfter := srv.Certificate().NotAfter
+	defer func() { httpCheckNow = time.Now }()
+
+	// the certificate of the test server is self-signed
+	mockSender := runHTTPCheck(t, "url: "+srv.URL+"/ok")
+	mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckCritical, "", nil, mock.Anything)
+	mockSender.AssertCalled(t, "ServiceCheck", "http.ssl_cert", servicecheck.ServiceCheckCritical, "", mock.Anything, mock.Anything)
+
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caCert, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: srv.Certificate().Raw}), 0600))
+
+	for name, tt := range map[string]struct {
+		instance string
+		daysLeft float64
+		status   servicecheck.ServiceCheckStatus
+	}{
+		"ok":       {"tls_ca_cert: " + caCert, 30, servicecheck.ServiceCheckOK},
+		"warning":  {"tls_ca_cert: " + caCert, 10, servicecheck.ServiceCheckWarning},
+		"critical": {"tls_ca_cert: " + caCert, 3, servicecheck.ServiceCheckCritical},
+		"custom":   {"tls_ca_cert: " + caCert + "\ndays_warning: 40\ndays_critical: 20", 30, servicecheck.ServiceCheckWarning},
+		"expired":  {"tls_verify: false", -1, servicecheck.ServiceCheckCritical},
+	} {
+		t.Run(name, func(t *testing.T) {
+			httpCheckNow = func() time.Time { return notAfter.Add(-time.Duration(tt.daysLeft*24) * time.Hour) }
+			mockSender := runHTTPCheck(t, fmt.Sprintf("url: %s/ok\n%s", srv.URL, tt.instance))
+			mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckOK, "", nil, "")
+			mockSender.AssertMetric(t, "Gauge", "http.ssl.days_left", tt.daysLeft, "", nil)
+			mockSender.AssertMetric(t, "Gauge", "http.ssl.seconds_left", tt.daysLeft*24*3600, "", nil)
+			mockSender.AssertServiceCheck(t, "http.ssl_cert", tt.status, "", nil, mock.Anything)
+		})
+	}
+
+	httpCheckNow = time.Now
+	mockSender = runHTTPCheck(t, fmt.Sprintf("url: %s/ok\ntls_ca_cert: %s\ncheck_certificate_expiration: false", srv.URL, caCert))
+	mockSender.AssertServiceCheck(t, "http.can_connect", servicecheck.ServiceCheckOK, "", nil, "")
+	mockSender.AssertNotCalled(t, "Gauge", "http.ssl.days_left", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -78,10 +78,17 @@ type NetworkCheck struct {
 }
 
 type networkInstanceConfig struct {
-	CollectConnectionState   bool     `yaml:"collect_connection_state"`
-	ExcludedInterfaces       []string `yaml:"excluded_interfaces"`
-	ExcludedInterfaceRe      string   `yaml:"excluded_interface_re"`
-	ExcludedInterfacePattern *regexp.Regexp
+	CollectConnectionState    bool     `yaml:"collect_connection_state"`
+	ExcludedInterfaces        []string `yaml:"excluded_interfaces"`
+	ExcludedInterfaceRe       string   `yaml:"excluded_interface_re"`
+	ExcludedInterfacePattern  *regexp.Regexp
+	CollectConntrackMetrics   bool     `yaml:"collect_conntrack_metrics"`
+	WhitelistConntrackMetrics []string `yaml:"whitelist_conntrack_metrics"`
+	BlacklistConntrackMetrics []string `yaml:"blacklist_conntrack_metrics"`
+	CollectEthtoolStats       bool     `yaml:"collect_ethtool_stats"`
+
+	conntrackWhitelistPattern *regexp.Regexp
+	conntrackBlacklistPattern *regexp.Regexp
 }
 
 type networkInitConfig struct{}
@@ -96,6 +103,9 @@ type networkStats interface {
 	ProtoCounters(protocols []string) ([]net.ProtoCountersStat, error)
 	Connections(kind string) ([]net.ConnectionStat, error)
 	NetstatTCPExtCounters() (map[string]int64, error)
+	ConntrackSysctls() (map[string]int64, error)
+	ConntrackCPUStats() ([]map[string]int64, error)
+	EthtoolStats(device string) (string, map[string]uint64, error)
 }
 
 type defaultNetworkStats struct{}
@@ -116,6 +126,18 @@ func (n defaultNetworkStats) NetstatTCPExtCounters() (map[string]int64, error) {
 	return netstatTCPExtCounters()
 }
 
+func (n defaultNetworkStats) ConntrackSysctls() (map[string]int64, error) {
+	return conntrackSysctls()
+}
+
+func (n defaultNetworkStats) ConntrackCPUStats() ([]map[string]int64, error) {
+	return conntrackCPUStats()
+}
+
+func (n defaultNetworkStats) EthtoolStats(device string) (string, map[string]uint64, error) {
+	return ethtoolStats(device)
+}
+
 // Run executes the check
 func (c *NetworkCheck) Run() error {
 	sender, err := c.GetSender()
@@ -128,8 +150,18 @@ func (c *NetworkCheck) Run() error {
 		return err
 	}
 	for _, interfaceIO := range ioByInterface {
-		if !c.isDeviceExcluded(interfaceIO.Name) {
-			submitInterfaceMetrics(sender, interfaceIO)
+		if c.isDeviceExcluded(interfaceIO.Name) {
+			continue
+		}
+		submitInterfaceMetrics(sender, interfaceIO)
+		if c.config.instance.CollectEthtoolStats {
+			driver, stats, err := c.net.EthtoolStats(interfaceIO.Name)
+			if err != nil {
+				// virtual interfaces such as lo do not support ethtool
+				log.Debugf("Unable to get the ethtool statistics of %s: %s", interfaceIO.Name, err)
+				continue
+			}
+			submitEthtoolMetrics(sender, interfaceIO.Name, driver, stats)
 		}
 	}
 
@@ -179,6 +211,12 @@ func (c *NetworkCheck) Run() error {
 		submitConnectionsMetrics(sender, "tcp6", tcpStateMetricsSuffixMapping, connectionsStats)
 	}
 
+	if c.config.instance.CollectConntrackMetrics {
+		if err := c.submitConntrackMetrics(sender); err != nil {
+			log.Warnf("Unable to collect the conntrack metrics: %s", err)
+		}
+	}
+
 	sender.Commit()
 	return nil
 }
@@ -301,6 +339,13 @@ func (c *NetworkCheck) Configure(integrationConfigDigest uint64, rawInstance int
 		}
 	}
 
+	if c.config.instance.conntrackWhitelistPattern, err = compileConntrackPatterns(c.config.instance.WhitelistConntrackMetrics); err != nil {
+		return fmt.Errorf("invalid whitelist_conntrack_metrics: %s", err)
+	}
+	if c.config.instance.conntrackBlacklistPattern, err = compileConntrackPatterns(c.config.instance.BlacklistConntrackMetrics); err != nil {
+		return fmt.Errorf("invalid blacklist_conntrack_metrics: %s", err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,162 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package net
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/config"
+)
+
+const conntrackSysctlPrefix = "nf_conntrack_"
+
+var (
+	// conntrackCPUStatsMapping maps the columns of /proc/net/stat/nf_conntrack to
+	// metric names, which are the ones reported by `conntrack -S`. The entries and
+	// chainlength columns are not counters and are left out: the former is already
+	// reported as system.net.conntrack.count.
+	conntrackCPUStatsMapping = map[string]string{
+		"found":          "found",
+		"invalid":        "invalid",
+		"ignore":         "ignore",
+		"insert":         "insert",
+		"insert_failed":  "insert_failed",
+		"drop":           "drop",
+		"early_drop":     "early_drop",
+		"icmp_error":     "error",
+		"search_restart": "search_restart",
+		"clashres":       "clash_resolve",
+	}
+)
+
+func procfsPath() string {
+	if config.Datadog.IsSet("procfs_path") {
+		return config.Datadog.GetString("procfs_path")
+	}
+	return "/proc"
+}
+
+// conntrackSysctls returns the integer values of the nf_conntrack_* files of
+// /proc/sys/net/netfilter, such as nf_conntrack_count and nf_conntrack_max, keyed
+// by their name without the nf_conntrack_ prefix.
+func conntrackSysctls() (map[string]int64, error) {
+	dir := filepath.Join(procfsPath(), "sys", "net", "netfilter")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]int64{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasPrefix(entry.Name(), conntrackSysctlPrefix) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			// some of the files are write-only or restricted to root
+			continue
+		}
+		value, err := strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+		if err != nil {
+			// not a single integer, such as nf_conntrack_helper in some kernels
+			continue
+		}
+		values[strings.TrimPrefix(entry.Name(), conntrackSysctlPrefix)] = value
+	}
+	return values, nil
+}
+
+// conntrackCPUStats parses /proc/net/stat/nf_conntrack, which has a header line
+// followed by one line of hexadecimal counters per CPU.
+func conntrackCPUStats() ([]map[string]int64, error) {
+	path := filepath.Join(procfsPath(), "net", "stat", "nf_conntrack")
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		return nil, fmt.Errorf("%s is empty", path)
+	}
+	columns := strings.Fields(scanner.Text())
+
+	var stats []map[string]int64
+	for scanner.Scan() {
+		values := strings.Fields(scanner.Text())
+		if len(values) != len(columns) {
+			return nil, fmt.Errorf("%s is not formatted correctly, expected %d columns, got %d", path, len(columns), len(values))
+		}
+		cpuStats := make(map[string]int64, len(values))
+		for i, v := range values {
+			value, err := strconv.ParseInt(v, 16, 64)
+			if err != nil {
+				return nil, err
+			}
+			cpuStats[columns[i]] = value
+		}
+		stats = append(stats, cpuStats)
+	}
+	return stats, scanner.Err()
+}
+
+// isConntrackMetricCollected applies the whitelist_conntrack_metrics and
+// blacklist_conntrack_metrics options to the name of a conntrack metric.
+func (c *NetworkCheck) isConntrackMetricCollected(name string) bool {
+	instance := c.config.instance
+	if instance.conntrackWhitelistPattern != nil && !instance.conntrackWhitelistPattern.MatchString(name) {
+		return false
+	}
+	return instance.conntrackBlacklistPattern == nil || !instance.conntrackBlacklistPattern.MatchString(name)
+}
+
+func (c *NetworkCheck) submitConntrackMetrics(sender sender.Sender) error {
+	values, err := c.net.ConntrackSysctls()
+	if err != nil {
+		return fmt.Errorf("unable to read the conntrack settings, is the nf_conntrack module loaded? %s", err)
+	}
+	for name, value := range values {
+		if c.isConntrackMetricCollected(name) {
+			sender.Gauge("system.net.conntrack."+name, float64(value), "", nil)
+		}
+	}
+
+	cpuStats, err := c.net.ConntrackCPUStats()
+	if err != nil {
+		return fmt.Errorf("unable to read the conntrack statistics: %s", err)
+	}
+	for cpu, stats := range cpuStats {
+		tags := []string{fmt.Sprintf("cpu:%d", cpu)}
+		for column, name := range conntrackCPUStatsMapping {
+			value, ok := stats[column]
+			if !ok || !c.isConntrackMetricCollected(name) {
+				continue
+			}
+			sender.MonotonicCount("system.net.conntrack."+name, float64(value), "", tags)
+		}
+	}
+	return nil
+}
+
+// compileConntrackPatterns returns a regexp matching the names of the conntrack
+// metrics, without the system.net.conntrack. prefix, fully matched by any of
+// patterns, or nil if there is none.
+func compileConntrackPatterns(patterns []string) (*regexp.Regexp, error) {
+	if len(patterns) == 0 {
+		return nil, nil
+	}
+	return regexp.Compile("^(?:" + strings.Join(patterns, "|") + ")$")
+}
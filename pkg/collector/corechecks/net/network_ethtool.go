@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package net
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+)
+
+const (
+	ethtoolStringLen = 32
+	ethSSStats       = 1
+)
+
+var (
+	// ethtoolQueuePatterns match the per-queue statistics, with the direction, the
+	// queue number and the name of the statistic as sub-matches, in the formats
+	// used by the common drivers: rx_queue_0_packets (virtio_net, ixgbe),
+	// rx0_packets (mlx5), rx-0.packets (i40e) and queue_0_tx_bytes (ena).
+	ethtoolQueuePatterns = []*regexp.Regexp{
+		regexp.MustCompile(`^(?P<dir>rx|tx)[_-]?(?:queue[_-])?(?P<queue>\d+)[_.](?P<name>.+)$`),
+		regexp.MustCompile(`^queue_(?P<queue>\d+)_(?P<dir>rx|tx)_(?P<name>.+)$`),
+	}
+	ethtoolInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+	// ethtoolGaugeWords are the words naming the ethtool statistics which are
+	// current levels, such as the state of the link or the occupancy of a
+	// queue, rather than counters. The other statistics are counters, the
+	// vast majority.
+	ethtoolGaugeWords = map[string]struct{}{
+		"active": {}, "avail": {}, "available": {}, "depth": {}, "inuse": {}, "level": {}, "link": {},
+		"mtu": {}, "occupancy": {}, "pending": {}, "speed": {}, "state": {}, "status": {}, "temp": {},
+		"temperature": {}, "util": {}, "utilization": {}, "watermark": {},
+	}
+	// ethtoolCounterWords are the words naming counters, taking precedence over
+	// ethtoolGaugeWords, as in link_down_events
+	ethtoolCounterWords = map[string]struct{}{
+		"bytes": {}, "changes": {}, "cnt": {}, "count": {}, "discards": {}, "drop": {}, "dropped": {},
+		"drops": {}, "err": {}, "errors": {}, "errs": {}, "events": {}, "exceeded": {}, "frames": {},
+		"packets": {}, "pkts": {}, "resets": {}, "timeout": {}, "timeouts": {},
+	}
+)
+
+// ethtoolIfreq is an ifreq carrying a pointer to an ethtool command, see
+// netdevice(7).
+type ethtoolIfreq struct {
+	name [unix.IFNAMSIZ]byte
+	data unsafe.Pointer
+	_    [24 - unsafe.Sizeof(uintptr(0))]byte
+}
+
+func ethtoolIoctl(fd int, device string, data unsafe.Pointer) error {
+	var ifr ethtoolIfreq
+	copy(ifr.name[:unix.IFNAMSIZ-1], device)
+	ifr.data = data
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, uintptr(fd), unix.SIOCETHTOOL, uintptr(unsafe.Pointer(&ifr))); errno != 0 {
+		return errno
+	}
+	return nil
+}
+
+// ethtoolStats returns the driver of a network interface and the statistics
+// reported by `ethtool -S`.
+func ethtoolStats(device string) (string, map[string]uint64, error) {
+	fd, err := unix.Socket(unix.AF_INET, unix.SOCK_DGRAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	defer unix.Close(fd)
+
+	drvinfo, err := unix.IoctlGetEthtoolDrvinfo(fd, device)
+	if err != nil {
+		return "", nil, err
+	}
+	driver := unix.ByteSliceToString(drvinfo.Driver[:])
+	n := int(drvinfo.N_stats)
+	if n == 0 {
+		return driver, nil, nil
+	}
+
+	// struct ethtool_gstrings { __u32 cmd; __u32 string_set; __u32 len; __u8 data[]; }
+	gstrings := make([]byte, 12+n*ethtoolStringLen)
+	header := (*[3]uint32)(unsafe.Pointer(&gstrings[0]))
+	header[0], header[1], header[2] = unix.ETHTOOL_GSTRINGS, ethSSStats, uint32(n)
+	if err := ethtoolIoctl(fd, device, unsafe.Pointer(&gstrings[0])); err != nil {
+		return "", nil, err
+	}
+
+	// struct ethtool_stats { __u32 cmd; __u32 n_stats; __u64 data[]; }
+	gstats := make([]uint64, 1+n)
+	statsHeader := (*[2]uint32)(unsafe.Pointer(&gstats[0]))
+	statsHeader[0], statsHeader[1] = unix.ETHTOOL_GSTATS, uint32(n)
+	if err := ethtoolIoctl(fd, device, unsafe.Pointer(&gstats[0])); err != nil {
+		return "", nil, err
+	}
+
+	stats := make(map[string]uint64, n)
+	for i := 0; i < n; i++ {
+		name := unix.ByteSliceToString(gstrings[12+i*ethtoolStringLen : 12+(i+1)*ethtoolStringLen])
+		stats[name] = gstats[1+i]
+	}
+	return driver, stats, nil
+}
+
+// ethtoolMetricName returns the name of the metric of an ethtool statistic and
+// its queue, if it is a per-queue statistic.
+func ethtoolMetricName(stat string) (string, string) {
+	stat = strings.ToLower(stat)
+	for _, pattern := range ethtoolQueuePatterns {
+		match := pattern.FindStringSubmatch(stat)
+		if match == nil {
+			continue
+		}
+		dir := match[pattern.SubexpIndex("dir")]
+		name := ethtoolInvalidChars.ReplaceAllString(match[pattern.SubexpIndex("name")], "_")
+		return "system.net.ethtool.queue." + dir + "_" + name, match[pattern.SubexpIndex("queue")]
+	}
+	return "system.net.ethtool." + ethtoolInvalidChars.ReplaceAllString(stat, "_"), ""
+}
+
+// ethtoolIsGauge returns true if the metric of an ethtool statistic is a gauge
+// rather than a monotonic counter, according to the words of its name.
+func ethtoolIsGauge(name string) bool {
+	isGauge := false
+	for _, word := range strings.Split(name[strings.LastIndex(name, ".")+1:], "_") {
+		if _, found := ethtoolCounterWords[word]; found {
+			return false
+		}
+		if _, found := ethtoolGaugeWords[word]; found {
+			isGauge = true
+		}
+	}
+	return isGauge
+}
+
+func submitEthtoolMetrics(sender sender.Sender, device, driver string, stats map[string]uint64) {
+	tags := []string{fmt.Sprintf("device:%s", device), fmt.Sprintf("device_name:%s", device), fmt.Sprintf("driver:%s", driver)}
+	for stat, value := range stats {
+		name, queue := ethtoolMetricName(stat)
+		metricTags := tags
+		if queue != "" {
+			metricTags = append(tags[:len(tags):len(tags)], "queue:"+queue)
+		}
+		if ethtoolIsGauge(name) {
+			sender.Gauge(name, float64(value), "", metricTags)
+		} else {
+			sender.MonotonicCount(name, float64(value), "", metricTags)
+		}
+	}
+}
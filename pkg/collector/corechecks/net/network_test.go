@@ -8,14 +8,19 @@
 package net
 
 import (
+	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/shirou/gopsutil/v3/net"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/config"
 )
 
 type fakeNetworkStats struct {
@@ -33,6 +38,11 @@ type fakeNetworkStats struct {
 	connectionStatsTCP6Error    error
 	netstatTCPExtCountersValues map[string]int64
 	netstatTCPExtCountersError  error
+	conntrackSysctls            map[string]int64
+	conntrackCPUStats           []map[string]int64
+	conntrackError              error
+	ethtoolDrivers              map[string]string
+	ethtoolStats                map[string]map[string]uint64
 }
 
 // IOCounters returns the inner values of counterStats and counterStatsError
@@ -64,6 +74,22 @@ func (n *fakeNetworkStats) NetstatTCPExtCounters() (map[string]int64, error) {
 	return n.netstatTCPExtCountersValues, n.netstatTCPExtCountersError
 }
 
+func (n *fakeNetworkStats) ConntrackSysctls() (map[string]int64, error) {
+	return n.conntrackSysctls, n.conntrackError
+}
+
+func (n *fakeNetworkStats) ConntrackCPUStats() ([]map[string]int64, error) {
+	return n.conntrackCPUStats, n.conntrackError
+}
+
+func (n *fakeNetworkStats) EthtoolStats(device string) (string, map[string]uint64, error) {
+	driver, ok := n.ethtoolDrivers[device]
+	if !ok {
+		return "", nil, errors.New("operation not supported")
+	}
+	return driver, n.ethtoolStats[device], nil
+}
+
 func TestDefaultConfiguration(t *testing.T) {
 	check := NetworkCheck{}
 	check.Configure(integration.FakeConfigHash, []byte(``), []byte(``), "test")
@@ -514,3 +540,167 @@ excluded_interface_re: "eth[0-9]"
 	mockSender.AssertCalled(t, "Rate", "system.net.packets_out.drop", float64(32), "", lo0Tags)
 	mockSender.AssertCalled(t, "Rate", "system.net.packets_out.error", float64(33), "", lo0Tags)
 }
+
+func TestConntrackMetrics(t *testing.T) {
+	net := &fakeNetworkStats{
+		conntrackSysctls: map[string]int64{
+			"count":           1500,
+			"max":             65536,
+			"tcp_timeout_syn": 120,
+		},
+		conntrackCPUStats: []map[string]int64{
+			{"entries": 1500, "found": 10, "insert_failed": 3, "drop": 2, "early_drop": 1},
+			{"entries": 1500, "found": 20, "insert_failed": 4, "drop": 0, "early_drop": 0},
+		},
+	}
+
+	networkCheck := NetworkCheck{
+		net: net,
+	}
+
+	rawInstanceConfig := []byte(`
+collect_conntrack_metrics: true
+blacklist_conntrack_metrics:
+  - .*_timeout_.*
+  - found
+`)
+
+	err := networkCheck.Configure(integration.FakeConfigHash, rawInstanceConfig, []byte(``), "test")
+	assert.Nil(t, err)
+
+	mockSender := mocksender.NewMockSender(networkCheck.ID())
+	mockSender.SetupAcceptAll()
+
+	err = networkCheck.Run()
+	assert.Nil(t, err)
+
+	mockSender.AssertMetric(t, "Gauge", "system.net.conntrack.count", 1500, "", nil)
+	mockSender.AssertMetric(t, "Gauge", "system.net.conntrack.max", 65536, "", nil)
+	mockSender.AssertNotCalled(t, "Gauge", "system.net.conntrack.tcp_timeout_syn", mock.Anything, mock.Anything, mock.Anything)
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.conntrack.insert_failed", 3, "", []string{"cpu:0"})
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.conntrack.insert_failed", 4, "", []string{"cpu:1"})
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.conntrack.drop", 2, "", []string{"cpu:0"})
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.conntrack.early_drop", 0, "", []string{"cpu:1"})
+	mockSender.AssertNotCalled(t, "MonotonicCount", "system.net.conntrack.found", mock.Anything, mock.Anything, mock.Anything)
+	mockSender.AssertNotCalled(t, "MonotonicCount", "system.net.conntrack.entries", mock.Anything, mock.Anything, mock.Anything)
+
+	// the check does not fail when conntrack is not available
+	net.conntrackError = errors.New("no such file or directory")
+	mockSender.ResetCalls()
+	err = networkCheck.Run()
+	assert.Nil(t, err)
+	mockSender.AssertNotCalled(t, "Gauge", "system.net.conntrack.count", mock.Anything, mock.Anything, mock.Anything)
+	mockSender.AssertCalled(t, "Commit")
+}
+
+func TestConntrackInvalidPattern(t *testing.T) {
+	networkCheck := NetworkCheck{}
+	err := networkCheck.Configure(integration.FakeConfigHash, []byte("whitelist_conntrack_metrics: ['(']"), []byte(``), "test")
+	assert.ErrorContains(t, err, "invalid whitelist_conntrack_metrics")
+}
+
+func TestReadConntrack(t *testing.T) {
+	procfs := t.TempDir()
+	config.Mock(t).Set("procfs_path", procfs)
+
+	netfilter := filepath.Join(procfs, "sys", "net", "netfilter")
+	require.NoError(t, os.MkdirAll(netfilter, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(netfilter, "nf_conntrack_count"), []byte("1500\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(netfilter, "nf_conntrack_max"), []byte("65536\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(netfilter, "nf_conntrack_helper"), []byte("not a number\n"), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(netfilter, "nf_log_all_netns"), []byte("0\n"), 0644))
+
+	values, err := conntrackSysctls()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int64{"count": 1500, "max": 65536}, values)
+
+	stat := filepath.Join(procfs, "net", "stat")
+	require.NoError(t, os.MkdirAll(stat, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(stat, "nf_conntrack"), []byte(`entries  clashres found new invalid ignore delete chainlength insert insert_failed drop early_drop icmp_error  expect_new expect_create expect_delete search_restart
+000005dc  00000000 0000000a 00000000 00000001 00000000 00000000 00000000 00000000 00000003 00000002 00000000 00000000  00000000 00000000 00000000 00000000
+000005dc  00000000 00000014 00000000 00000000 00000000 00000000 00000000 00000000 000000ff 00000000 00000000 00000000  00000000 00000000 00000000 00000000
+`), 0644))
+
+	cpuStats, err := conntrackCPUStats()
+	require.NoError(t, err)
+	require.Len(t, cpuStats, 2)
+	assert.Equal(t, int64(1500), cpuStats[0]["entries"])
+	assert.Equal(t, int64(10), cpuStats[0]["found"])
+	assert.Equal(t, int64(3), cpuStats[0]["insert_failed"])
+	assert.Equal(t, int64(255), cpuStats[1]["insert_failed"])
+}
+
+func TestEthtoolMetrics(t *testing.T) {
+	net := &fakeNetworkStats{
+		counterStats: []net.IOCountersStat{
+			{Name: "eth0"},
+			{Name: "lo"},
+		},
+		ethtoolDrivers: map[string]string{"eth0": "ena"},
+		ethtoolStats: map[string]map[string]uint64{
+			"eth0": {
+				"tx_timeout":                   1,
+				"queue_0_tx_cnt":               100,
+				"queue_1_rx_bytes":             2048,
+				"bw_in_allowance_exceeded":     5,
+				"conntrack_allowance_exceeded": 7,
+				"queue_0_rx_pending":           3,
+				"link_state":                   1,
+			},
+		},
+	}
+
+	networkCheck := NetworkCheck{
+		net: net,
+	}
+
+	err := networkCheck.Configure(integration.FakeConfigHash, []byte("collect_ethtool_stats: true"), []byte(``), "test")
+	assert.Nil(t, err)
+
+	mockSender := mocksender.NewMockSender(networkCheck.ID())
+	mockSender.SetupAcceptAll()
+
+	err = networkCheck.Run()
+	assert.Nil(t, err)
+
+	eth0Tags := []string{"device:eth0", "device_name:eth0", "driver:ena"}
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.ethtool.tx_timeout", 1, "", eth0Tags)
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.ethtool.bw_in_allowance_exceeded", 5, "", eth0Tags)
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.ethtool.conntrack_allowance_exceeded", 7, "", eth0Tags)
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.ethtool.queue.tx_cnt", 100, "", append(eth0Tags, "queue:0"))
+	mockSender.AssertMetric(t, "MonotonicCount", "system.net.ethtool.queue.rx_bytes", 2048, "", append(eth0Tags, "queue:1"))
+	mockSender.AssertNumberOfCalls(t, "MonotonicCount", 5)
+	mockSender.AssertMetric(t, "Gauge", "system.net.ethtool.queue.rx_pending", 3, "", append(eth0Tags, "queue:0"))
+	mockSender.AssertMetric(t, "Gauge", "system.net.ethtool.link_state", 1, "", eth0Tags)
+	mockSender.AssertCalled(t, "Commit")
+}
+
+func TestEthtoolIsGauge(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"system.net.ethtool.queue.rx_packets":    false,
+		"system.net.ethtool.tx_timeout":          false,
+		"system.net.ethtool.queue.rx_cache_full": false,
+		"system.net.ethtool.link_down_events":    false,
+		"system.net.ethtool.link_state":          true,
+		"system.net.ethtool.queue.tx_pending":    true,
+		"system.net.ethtool.temperature":         true,
+	} {
+		assert.Equal(t, expected, ethtoolIsGauge(name), name)
+	}
+}
+
+func TestEthtoolMetricName(t *testing.T) {
+	for stat, expected := range map[string][2]string{
+		"rx_queue_0_packets": {"system.net.ethtool.queue.rx_packets", "0"},
+		"tx_queue_12_bytes":  {"system.net.ethtool.queue.tx_bytes", "12"},
+		"rx3_cache_full":     {"system.net.ethtool.queue.rx_cache_full", "3"},
+		"tx-1.packets":       {"system.net.ethtool.queue.tx_packets", "1"},
+		"queue_2_rx_drops":   {"system.net.ethtool.queue.rx_drops", "2"},
+		"rx_missed_errors":   {"system.net.ethtool.rx_missed_errors", ""},
+		"RX-Flow Director":   {"system.net.ethtool.rx_flow_director", ""},
+	} {
+		name, queue := ethtoolMetricName(stat)
+		assert.Equal(t, expected[0], name, stat)
+		assert.Equal(t, expected[1], queue, stat)
+	}
+}
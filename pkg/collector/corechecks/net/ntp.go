@@ -16,6 +16,7 @@ import (
 	"github.com/beevik/ntp"
 	"gopkg.in/yaml.v2"
 
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
@@ -45,16 +46,22 @@ type NTPCheck struct {
 	cfg            *ntpConfig
 	lastCollection time.Time
 	errCount       int
+	// chronySynchronized is whether chronyd was synchronized on the last run
+	chronySynchronized bool
 }
 
 type ntpInstanceConfig struct {
-	OffsetThreshold        int      `yaml:"offset_threshold"`
+	OffsetThreshold        float64  `yaml:"offset_threshold"`
 	Host                   string   `yaml:"host"`
 	Hosts                  []string `yaml:"hosts"`
 	Port                   int      `yaml:"port"`
 	Timeout                int      `yaml:"timeout"`
 	Version                int      `yaml:"version"`
 	UseLocalDefinedServers bool     `yaml:"use_local_defined_servers"`
+	UseChrony              bool     `yaml:"use_chrony"`
+	ChronyAddress          string   `yaml:"chrony_address"`
+	CollectPTP             bool     `yaml:"collect_ptp"`
+	PTPUTCOffset           float64  `yaml:"ptp_utc_offset"`
 }
 
 type ntpInitConfig struct{}
@@ -77,7 +84,7 @@ func (c *ntpConfig) parse(data []byte, initData []byte, getLocalServers func() (
 	defaultVersion := 3
 	defaultTimeout := 5
 	defaultPort := 123
-	defaultOffsetThreshold := 60
+	defaultOffsetThreshold := 60.0
 
 	defaultHosts := getCloudProviderNTPHosts(context.TODO())
 
@@ -133,6 +140,9 @@ func (c *ntpConfig) parse(data []byte, initData []byte, getLocalServers func() (
 	if c.instance.OffsetThreshold == 0 {
 		c.instance.OffsetThreshold = defaultOffsetThreshold
 	}
+	if c.instance.ChronyAddress == "" {
+		c.instance.ChronyAddress = defaultChronyAddress
+	}
 	c.initConf = initConf
 
 	return nil
@@ -169,7 +179,18 @@ func (c *NTPCheck) Run() error {
 	serviceCheckMessage := ""
 	offsetThreshold := c.cfg.instance.OffsetThreshold
 
-	clockOffset, err := c.queryOffset()
+	if c.cfg.instance.CollectPTP {
+		if err := c.submitPTPMetrics(sender); err != nil {
+			log.Warnf("Unable to collect the PTP hardware clock offsets: %s", err)
+		}
+	}
+
+	var clockOffset float64
+	if c.cfg.instance.UseChrony {
+		clockOffset, err = c.queryChrony(sender)
+	} else {
+		clockOffset, err = c.queryOffset()
+	}
 	if err != nil {
 		log.Error(err)
 
@@ -179,9 +200,12 @@ func (c *NTPCheck) Run() error {
 
 		return err
 	}
-	if int(math.Abs(clockOffset)) > offsetThreshold {
+	if math.Abs(clockOffset) > offsetThreshold {
 		serviceCheckStatus = servicecheck.ServiceCheckCritical
 		serviceCheckMessage = fmt.Sprintf("Offset %v is higher than offset threshold (%v secs)", clockOffset, offsetThreshold)
+	} else if c.cfg.instance.UseChrony && !c.chronySynchronized {
+		serviceCheckStatus = servicecheck.ServiceCheckCritical
+		serviceCheckMessage = "chronyd is not synchronized to any source"
 	} else {
 		serviceCheckStatus = servicecheck.ServiceCheckOK
 	}
@@ -239,6 +263,58 @@ func (c *NTPCheck) queryOffset() (float64, error) {
 	return median, nil
 }
 
+// queryChrony submits the tracking information of the local chronyd and returns
+// the offset of the system clock.
+func (c *NTPCheck) queryChrony(sender sender.Sender) (float64, error) {
+	tracking, err := chronyQueryTracking(c.cfg.instance.ChronyAddress, time.Duration(c.cfg.instance.Timeout)*time.Second)
+	if err != nil {
+		return .0, err
+	}
+
+	tags := []string{"leap_status:" + tracking.leapStatus()}
+	sender.Gauge("ntp.chrony.stratum", float64(tracking.Stratum), "", tags)
+	sender.Gauge("ntp.chrony.last_offset", tracking.LastOffset, "", tags)
+	sender.Gauge("ntp.chrony.rms_offset", tracking.RMSOffset, "", tags)
+	sender.Gauge("ntp.chrony.frequency", tracking.FreqPPM, "", tags)
+	sender.Gauge("ntp.chrony.residual_frequency", tracking.ResidFreqPPM, "", tags)
+	sender.Gauge("ntp.chrony.skew", tracking.SkewPPM, "", tags)
+	sender.Gauge("ntp.chrony.root_delay", tracking.RootDelay, "", tags)
+	sender.Gauge("ntp.chrony.root_dispersion", tracking.RootDispersion, "", tags)
+	sender.Gauge("ntp.chrony.update_interval", tracking.LastUpdateInterval, "", tags)
+	sender.Gauge("ntp.chrony.leap_status", float64(tracking.LeapStatus), "", tags)
+	sender.Gauge("ntp.chrony.synchronized", boolToFloat64(tracking.synchronized()), "", tags)
+
+	c.chronySynchronized = tracking.synchronized()
+	// chronyd reports by how much the system clock is slow of NTP time, which is
+	// the offset reported by NTP servers
+	return tracking.CurrentCorrection, nil
+}
+
+// submitPTPMetrics submits the offset of the PTP hardware clocks from the system clock
+func (c *NTPCheck) submitPTPMetrics(sender sender.Sender) error {
+	clocks, err := getPTPClocks()
+	if err != nil {
+		return err
+	}
+	utcOffset := time.Duration(c.cfg.instance.PTPUTCOffset * float64(time.Second))
+	for _, clock := range clocks {
+		tags := []string{"ptp_device:" + clock.Device}
+		if clock.Name != "" {
+			tags = append(tags, "clock_name:"+clock.Name)
+		}
+		sender.Gauge("ntp.ptp.offset", (clock.Offset - utcOffset).Seconds(), "", tags)
+		sender.Gauge("ntp.ptp.read_delay", clock.Delay.Seconds(), "", tags)
+	}
+	return nil
+}
+
+func boolToFloat64(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func ntpFactory() check.Check {
 	return &NTPCheck{
 		CheckBase: core.NewCheckBaseWithInterval(ntpCheckName, time.Duration(defaultMinCollectionInterval)*time.Second),
@@ -0,0 +1,195 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package net
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Constants of the chrony command protocol, see candm.h in the chrony sources
+const (
+	chronyProtocolVersion = 6
+	chronyPktTypeRequest  = 1
+	chronyPktTypeReply    = 2
+	chronyReqTracking     = 33
+	chronyRpyTracking     = 5
+	chronyStatusSuccess   = 0
+
+	chronyRequestHeaderLen = 20
+	chronyReplyHeaderLen   = 28
+	// chronyTrackingReplyLen is the length of a tracking reply. Requests are padded
+	// to the length of their reply, chronyd drops them otherwise.
+	chronyTrackingReplyLen = chronyReplyHeaderLen + 76
+
+	defaultChronyAddress = "127.0.0.1:323"
+)
+
+// chronyLeapStatuses are the names of the leap statuses reported by chronyd
+var chronyLeapStatuses = []string{"normal", "insert_second", "delete_second", "unsynchronised"}
+
+// chronyTracking holds the tracking information reported by `chronyc tracking`
+type chronyTracking struct {
+	RefID              uint32
+	Stratum            uint16
+	LeapStatus         uint16
+	RefTime            time.Time
+	CurrentCorrection  float64
+	LastOffset         float64
+	RMSOffset          float64
+	FreqPPM            float64
+	ResidFreqPPM       float64
+	SkewPPM            float64
+	RootDelay          float64
+	RootDispersion     float64
+	LastUpdateInterval float64
+}
+
+// synchronized returns whether chronyd is synchronized to a source
+func (t *chronyTracking) synchronized() bool {
+	return t.LeapStatus != 3
+}
+
+// leapStatus returns the name of the leap status
+func (t *chronyTracking) leapStatus() string {
+	if int(t.LeapStatus) < len(chronyLeapStatuses) {
+		return chronyLeapStatuses[t.LeapStatus]
+	}
+	return "unknown"
+}
+
+// for testing purpose
+var chronyQueryTracking = queryChronyTracking
+
+// queryChronyTracking sends a tracking request to chronyd listening on address,
+// which is either host:port for its UDP command port or the path of its unix
+// socket.
+func queryChronyTracking(address string, timeout time.Duration) (*chronyTracking, error) {
+	conn, err := dialChrony(address)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return nil, err
+	}
+
+	sequence := rand.Uint32()
+	request := make([]byte, chronyTrackingReplyLen)
+	request[0] = chronyProtocolVersion
+	request[1] = chronyPktTypeRequest
+	binary.BigEndian.PutUint16(request[4:], chronyReqTracking)
+	binary.BigEndian.PutUint32(request[8:], sequence)
+	if _, err := conn.Write(request); err != nil {
+		return nil, fmt.Errorf("unable to send the tracking request to chronyd at %s: %s", address, err)
+	}
+
+	reply := make([]byte, 1024)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the tracking reply of chronyd at %s: %s", address, err)
+	}
+	return parseChronyTrackingReply(reply[:n], sequence)
+}
+
+// chronyConn is a connection to chronyd, which removes the local socket of unix
+// connections when closed
+type chronyConn struct {
+	net.Conn
+	localPath string
+}
+
+func (c *chronyConn) Close() error {
+	err := c.Conn.Close()
+	if c.localPath != "" {
+		os.Remove(c.localPath)
+	}
+	return err
+}
+
+func dialChrony(address string) (*chronyConn, error) {
+	if !strings.HasPrefix(address, "/") {
+		conn, err := net.Dial("udp", address)
+		if err != nil {
+			return nil, err
+		}
+		return &chronyConn{Conn: conn}, nil
+	}
+
+	// chronyd replies to the address of the client, which has to be bound
+	localPath := filepath.Join(os.TempDir(), fmt.Sprintf("datadog-agent-chrony-%d-%d.sock", os.Getpid(), rand.Uint32()))
+	conn, err := net.DialUnix("unixgram", &net.UnixAddr{Name: localPath, Net: "unixgram"}, &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		os.Remove(localPath)
+		return nil, err
+	}
+	return &chronyConn{Conn: conn, localPath: localPath}, nil
+}
+
+func parseChronyTrackingReply(reply []byte, sequence uint32) (*chronyTracking, error) {
+	if len(reply) < chronyTrackingReplyLen {
+		return nil, fmt.Errorf("invalid chrony reply: expected at least %d bytes, got %d", chronyTrackingReplyLen, len(reply))
+	}
+	if reply[0] != chronyProtocolVersion || reply[1] != chronyPktTypeReply {
+		return nil, fmt.Errorf("invalid chrony reply: unsupported version %d or packet type %d", reply[0], reply[1])
+	}
+	if status := binary.BigEndian.Uint16(reply[8:]); status != chronyStatusSuccess {
+		return nil, fmt.Errorf("chronyd replied with status %d, is the agent allowed by its cmdallow directives?", status)
+	}
+	if rpy := binary.BigEndian.Uint16(reply[6:]); rpy != chronyRpyTracking {
+		return nil, fmt.Errorf("invalid chrony reply: expected a tracking reply, got %d", rpy)
+	}
+	if seq := binary.BigEndian.Uint32(reply[16:]); seq != sequence {
+		return nil, fmt.Errorf("invalid chrony reply: expected sequence %d, got %d", sequence, seq)
+	}
+
+	data := reply[chronyReplyHeaderLen:]
+	floatAt := func(offset int) float64 {
+		return chronyFloat(binary.BigEndian.Uint32(data[offset:]))
+	}
+	// ref_id (4), ip_addr (20), stratum (2), leap_status (2), ref_time (12) then the floats
+	secs := uint64(binary.BigEndian.Uint32(data[28:]))<<32 | uint64(binary.BigEndian.Uint32(data[32:]))
+	return &chronyTracking{
+		RefID:              binary.BigEndian.Uint32(data[0:]),
+		Stratum:            binary.BigEndian.Uint16(data[24:]),
+		LeapStatus:         binary.BigEndian.Uint16(data[26:]),
+		RefTime:            time.Unix(int64(secs), int64(binary.BigEndian.Uint32(data[36:]))),
+		CurrentCorrection:  floatAt(40),
+		LastOffset:         floatAt(44),
+		RMSOffset:          floatAt(48),
+		FreqPPM:            floatAt(52),
+		ResidFreqPPM:       floatAt(56),
+		SkewPPM:            floatAt(60),
+		RootDelay:          floatAt(64),
+		RootDispersion:     floatAt(68),
+		LastUpdateInterval: floatAt(72),
+	}, nil
+}
+
+// chronyFloat decodes the 32-bit floating point format of the chrony protocol,
+// made of a 7-bit signed exponent followed by a 25-bit signed coefficient.
+func chronyFloat(x uint32) float64 {
+	const expBits, coefBits = 7, 25
+
+	exp := int32(x >> coefBits)
+	if exp >= 1<<(expBits-1) {
+		exp -= 1 << expBits
+	}
+	exp -= coefBits
+
+	coef := int32(x % (1 << coefBits))
+	if coef >= 1<<(coefBits-1) {
+		coef -= 1 << coefBits
+	}
+	return float64(coef) * math.Pow(2, float64(exp))
+}
@@ -0,0 +1,141 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package net
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+)
+
+// encodeChronyFloat is the reverse of chronyFloat, as done by chronyd
+func encodeChronyFloat(f float64) uint32 {
+	const expBits, coefBits = 7, 25
+	for exp := -(1 << (expBits - 1)); exp < 1<<(expBits-1); exp++ {
+		coef := math.Round(f / math.Pow(2, float64(exp-coefBits)))
+		if math.Abs(coef) < 1<<(coefBits-1) {
+			return uint32(exp)<<coefBits | uint32(int32(coef))&(1<<coefBits-1)
+		}
+	}
+	panic("value out of range")
+}
+
+func chronyTrackingReply(sequence uint32, status uint16, leapStatus uint16) []byte {
+	reply := make([]byte, chronyTrackingReplyLen)
+	reply[0] = chronyProtocolVersion
+	reply[1] = chronyPktTypeReply
+	binary.BigEndian.PutUint16(reply[4:], chronyReqTracking)
+	binary.BigEndian.PutUint16(reply[6:], chronyRpyTracking)
+	binary.BigEndian.PutUint16(reply[8:], status)
+	binary.BigEndian.PutUint32(reply[16:], sequence)
+
+	data := reply[chronyReplyHeaderLen:]
+	binary.BigEndian.PutUint32(data[0:], 0xC0A80001)
+	binary.BigEndian.PutUint16(data[24:], 2)
+	binary.BigEndian.PutUint16(data[26:], leapStatus)
+	binary.BigEndian.PutUint32(data[32:], 1700000000)
+	for i, f := range []float64{0.000012, -0.000003, 0.000021, -12.5, 0.001, 0.05, 0.0004, 0.0002, 64} {
+		binary.BigEndian.PutUint32(data[40+4*i:], encodeChronyFloat(f))
+	}
+	return reply
+}
+
+// fakeChronyd answers to one tracking request on a local UDP port
+func fakeChronyd(t *testing.T, status uint16) string {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	go func() {
+		request := make([]byte, 1024)
+		n, addr, err := conn.ReadFrom(request)
+		if err != nil {
+			return
+		}
+		if n != chronyTrackingReplyLen || request[0] != chronyProtocolVersion || binary.BigEndian.Uint16(request[4:]) != chronyReqTracking {
+			// chronyd drops invalid requests
+			return
+		}
+		conn.WriteTo(chronyTrackingReply(binary.BigEndian.Uint32(request[8:]), status, 0), addr)
+	}()
+	return conn.LocalAddr().String()
+}
+
+func TestChronyFloat(t *testing.T) {
+	for _, f := range []float64{0, 1, -1, 0.000012, -12.5, 1234567.25} {
+		assert.InDelta(t, f, chronyFloat(encodeChronyFloat(f)), math.Abs(f)*1e-7, f)
+	}
+	assert.Equal(t, 1.0, chronyFloat(0x04800000))
+	assert.Equal(t, -1.0, chronyFloat(0x05800000))
+}
+
+func TestQueryChronyTracking(t *testing.T) {
+	tracking, err := queryChronyTracking(fakeChronyd(t, chronyStatusSuccess), time.Second)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), tracking.Stratum)
+	assert.Equal(t, "normal", tracking.leapStatus())
+	assert.True(t, tracking.synchronized())
+	assert.Equal(t, int64(1700000000), tracking.RefTime.Unix())
+	assert.InDelta(t, 0.000012, tracking.CurrentCorrection, 1e-10)
+	assert.InDelta(t, -12.5, tracking.FreqPPM, 1e-9)
+	assert.InDelta(t, 0.0002, tracking.RootDispersion, 1e-10)
+	assert.Equal(t, 64.0, tracking.LastUpdateInterval)
+
+	_, err = queryChronyTracking(fakeChronyd(t, 2), time.Second)
+	assert.ErrorContains(t, err, "chronyd replied with status 2")
+
+	_, err = parseChronyTrackingReply(chronyTrackingReply(1, chronyStatusSuccess, 0), 2)
+	assert.ErrorContains(t, err, "expected sequence 2, got 1")
+	_, err = parseChronyTrackingReply(make([]byte, 10), 1)
+	assert.ErrorContains(t, err, "expected at least")
+}
+
+func TestNTPChrony(t *testing.T) {
+	var address string
+	chronyQueryTracking = func(addr string, timeout time.Duration) (*chronyTracking, error) {
+		address = addr
+		return parseChronyTrackingReply(chronyTrackingReply(1, chronyStatusSuccess, 0), 1)
+	}
+	defer func() { chronyQueryTracking = queryChronyTracking }()
+
+	ntpCheck := new(NTPCheck)
+	require.NoError(t, ntpCheck.Configure(integration.FakeConfigHash, []byte("use_chrony: true\noffset_threshold: 0.00001"), nil, "test"))
+
+	mockSender := mocksender.NewMockSender(ntpCheck.ID())
+	mockSender.SetupAcceptAll()
+	require.NoError(t, ntpCheck.Run())
+
+	tags := []string{"leap_status:normal"}
+	assert.Equal(t, defaultChronyAddress, address)
+	mockSender.AssertMetricInRange(t, "Gauge", "ntp.offset", 0.0000119, 0.0000121, "", nil)
+	mockSender.AssertMetric(t, "Gauge", "ntp.chrony.stratum", 2, "", tags)
+	mockSender.AssertMetric(t, "Gauge", "ntp.chrony.synchronized", 1, "", tags)
+	mockSender.AssertMetric(t, "Gauge", "ntp.chrony.update_interval", 64, "", tags)
+	mockSender.AssertMetricInRange(t, "Gauge", "ntp.chrony.frequency", -12.51, -12.49, "", tags)
+	// the offset of 12µs is above the threshold of 10µs
+	mockSender.AssertServiceCheck(t, "ntp.in_sync", servicecheck.ServiceCheckCritical, "", nil, "Offset 1.2000000424450263e-05 is higher than offset threshold (1e-05 secs)")
+
+	chronyQueryTracking = func(addr string, timeout time.Duration) (*chronyTracking, error) {
+		return parseChronyTrackingReply(chronyTrackingReply(1, chronyStatusSuccess, 3), 1)
+	}
+	require.NoError(t, ntpCheck.Configure(integration.FakeConfigHash, []byte("use_chrony: true\nchrony_address: /var/run/chrony/chronyd.sock"), nil, "test"))
+	mockSender = mocksender.NewMockSender(ntpCheck.ID())
+	mockSender.SetupAcceptAll()
+	require.NoError(t, ntpCheck.Run())
+
+	tags = []string{"leap_status:unsynchronised"}
+	mockSender.AssertMetric(t, "Gauge", "ntp.chrony.synchronized", 0, "", tags)
+	mockSender.AssertServiceCheck(t, "ntp.in_sync", servicecheck.ServiceCheckCritical, "", nil, "chronyd is not synchronized to any source")
+}
@@ -0,0 +1,18 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package net
+
+import "time"
+
+// ptpClock is the offset of a PTP hardware clock (PHC) from the system clock
+type ptpClock struct {
+	Device string
+	Name   string
+	// Offset is the time of the PHC minus the time of the system clock
+	Offset time.Duration
+	// Delay is the time it took to read the PHC, which bounds the error on Offset
+	Delay time.Duration
+}
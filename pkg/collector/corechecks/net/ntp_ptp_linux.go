@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package net
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+const (
+	ptpMaxSamples = 25
+	ptpSamples    = 5
+)
+
+// ptpClockTime is the ptp_clock_time structure of linux/ptp_clock.h
+type ptpClockTime struct {
+	Sec      int64
+	Nsec     uint32
+	Reserved uint32
+}
+
+func (t ptpClockTime) time() time.Time {
+	return time.Unix(t.Sec, int64(t.Nsec))
+}
+
+// ptpSysOffset is the ptp_sys_offset structure of linux/ptp_clock.h. Ts holds the
+// system time, followed by the PHC time, for each sample, and a final system time.
+type ptpSysOffset struct {
+	NSamples uint32
+	Rsv      [3]uint32
+	Ts       [2*ptpMaxSamples + 1]ptpClockTime
+}
+
+// ptpSysOffsetRequest is PTP_SYS_OFFSET, that is _IOW('=', 5, struct ptp_sys_offset)
+const ptpSysOffsetRequest = 1<<30 | unsafe.Sizeof(ptpSysOffset{})<<16 | '='<<8 | 5
+
+// for testing purpose
+var (
+	ptpSysfsPath     = "/sys/class/ptp"
+	ptpDevPath       = "/dev"
+	readPTPSysOffset = ioctlPTPSysOffset
+)
+
+func ioctlPTPSysOffset(device string) (*ptpSysOffset, error) {
+	f, err := os.Open(filepath.Join(ptpDevPath, device))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	offset := ptpSysOffset{NSamples: ptpSamples}
+	if _, _, errno := unix.Syscall(unix.SYS_IOCTL, f.Fd(), ptpSysOffsetRequest, uintptr(unsafe.Pointer(&offset))); errno != 0 {
+		return nil, errno
+	}
+	return &offset, nil
+}
+
+// getPTPClocks returns the offsets of the PTP hardware clocks listed in
+// /sys/class/ptp from the system clock.
+func getPTPClocks() ([]ptpClock, error) {
+	entries, err := os.ReadDir(ptpSysfsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var clocks []ptpClock
+	for _, entry := range entries {
+		device := entry.Name()
+		sysOffset, err := readPTPSysOffset(device)
+		if err != nil {
+			return nil, err
+		}
+		clock := phcOffset(sysOffset)
+		clock.Device = device
+		if name, err := os.ReadFile(filepath.Join(ptpSysfsPath, device, "clock_name")); err == nil {
+			clock.Name = strings.TrimSpace(string(name))
+		}
+		clocks = append(clocks, clock)
+	}
+	return clocks, nil
+}
+
+// phcOffset returns the offset of the PHC from the system clock measured by the
+// sample which took the least time to read, as done by phc_ctl and phc2sys.
+func phcOffset(sysOffset *ptpSysOffset) ptpClock {
+	var clock ptpClock
+	for i := 0; i < int(sysOffset.NSamples); i++ {
+		before := sysOffset.Ts[2*i].time()
+		phc := sysOffset.Ts[2*i+1].time()
+		after := sysOffset.Ts[2*i+2].time()
+
+		delay := after.Sub(before)
+		if i == 0 || delay < clock.Delay {
+			clock.Delay = delay
+			clock.Offset = phc.Sub(before.Add(delay / 2))
+		}
+	}
+	return clock
+}
@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package net
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+)
+
+func clockTime(t time.Time) ptpClockTime {
+	return ptpClockTime{Sec: t.Unix(), Nsec: uint32(t.Nanosecond())}
+}
+
+// testPTPSysOffset returns PTP_SYS_OFFSET samples of a PHC ahead of the system
+// clock by offset, the second sample being the fastest to read
+func testPTPSysOffset(offset time.Duration) *ptpSysOffset {
+	sys := time.Unix(1700000000, 0)
+	sysOffset := &ptpSysOffset{NSamples: 3}
+	for i, delay := range []time.Duration{4 * time.Microsecond, 2 * time.Microsecond, 6 * time.Microsecond} {
+		sysOffset.Ts[2*i] = clockTime(sys)
+		// the PHC is read at a third of the read delay, which is the error on the offset
+		sysOffset.Ts[2*i+1] = clockTime(sys.Add(delay / 3).Add(offset))
+		sys = sys.Add(delay)
+	}
+	sysOffset.Ts[6] = clockTime(sys)
+	return sysOffset
+}
+
+func TestPTPSysOffsetRequest(t *testing.T) {
+	assert.Equal(t, uintptr(832), unsafe.Sizeof(ptpSysOffset{}))
+	assert.Equal(t, uintptr(0x43403d05), uintptr(ptpSysOffsetRequest))
+}
+
+func TestPHCOffset(t *testing.T) {
+	clock := phcOffset(testPTPSysOffset(37*time.Second + 500*time.Nanosecond))
+	assert.Equal(t, 2*time.Microsecond, clock.Delay)
+	// the PHC was read 1/3 of the way, instead of half of it, during the 2µs read
+	assert.Equal(t, 37*time.Second+500*time.Nanosecond+2*time.Microsecond/3-time.Microsecond, clock.Offset)
+}
+
+func TestNTPPTP(t *testing.T) {
+	sysfs := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(sysfs, "ptp0"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(sysfs, "ptp0", "clock_name"), []byte("mlx5_ptp\n"), 0644))
+	require.NoError(t, os.MkdirAll(filepath.Join(sysfs, "ptp1"), 0755))
+	ptpSysfsPath = sysfs
+	readPTPSysOffset = func(device string) (*ptpSysOffset, error) {
+		if device == "ptp0" {
+			return testPTPSysOffset(37*time.Second + 10*time.Microsecond), nil
+		}
+		return testPTPSysOffset(-time.Microsecond), nil
+	}
+	chronyQueryTracking = func(string, time.Duration) (*chronyTracking, error) {
+		return nil, errors.New("chronyd is not running")
+	}
+	defer func() {
+		ptpSysfsPath = "/sys/class/ptp"
+		readPTPSysOffset = ioctlPTPSysOffset
+		chronyQueryTracking = queryChronyTracking
+	}()
+
+	ntpCheck := new(NTPCheck)
+	require.NoError(t, ntpCheck.Configure(integration.FakeConfigHash, []byte("use_chrony: true\ncollect_ptp: true\nptp_utc_offset: 37"), nil, "test"))
+	mockSender := mocksender.NewMockSender(ntpCheck.ID())
+	mockSender.SetupAcceptAll()
+	// the PTP metrics are submitted even though chronyd cannot be queried
+	assert.Error(t, ntpCheck.Run())
+
+	mockSender.AssertMetricInRange(t, "Gauge", "ntp.ptp.offset", 0.0000096, 0.0000097, "", []string{"ptp_device:ptp0", "clock_name:mlx5_ptp"})
+	mockSender.AssertMetricInRange(t, "Gauge", "ntp.ptp.offset", -37.000002, -37.000001, "", []string{"ptp_device:ptp1"})
+	mockSender.AssertMetric(t, "Gauge", "ntp.ptp.read_delay", 0.000002, "", []string{"ptp_device:ptp0"})
+	mockSender.AssertNumberOfCalls(t, "Commit", 1)
+}
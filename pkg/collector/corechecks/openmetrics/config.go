@@ -0,0 +1,204 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package openmetrics
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	defaultTimeout            = 10
+	defaultMaxReturnedMetrics = 2000
+	defaultBearerTokenPath    = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+)
+
+// instanceConfig holds the options of an instance of the check. It supports the
+// options generated by the prometheus autodiscovery providers, which follow the
+// Python openmetrics check, along with their V2 aliases where they exist.
+type instanceConfig struct {
+	OpenMetricsEndpoint string            `yaml:"openmetrics_endpoint"`
+	PrometheusURL       string            `yaml:"prometheus_url"`
+	Namespace           string            `yaml:"namespace"`
+	Metrics             []interface{}     `yaml:"metrics"`
+	ExcludeMetrics      []string          `yaml:"exclude_metrics"`
+	IgnoreMetrics       []string          `yaml:"ignore_metrics"`
+	TypeOverrides       map[string]string `yaml:"type_overrides"`
+
+	LabelsMapper  map[string]string `yaml:"labels_mapper"`
+	RenameLabels  map[string]string `yaml:"rename_labels"`
+	ExcludeLabels []string          `yaml:"exclude_labels"`
+
+	SendMonotonicCounter              *bool `yaml:"send_monotonic_counter"`
+	SendHistogramsBuckets             *bool `yaml:"send_histograms_buckets"`
+	SendDistributionBuckets           bool  `yaml:"send_distribution_buckets"`
+	HistogramBucketsAsDistributions   bool  `yaml:"histogram_buckets_as_distributions"`
+	SendDistributionCountsAsMonotonic bool  `yaml:"send_distribution_counts_as_monotonic"`
+	SendDistributionSumsAsMonotonic   bool  `yaml:"send_distribution_sums_as_monotonic"`
+	HealthServiceCheck                *bool `yaml:"health_service_check"`
+	MaxReturnedMetrics                int   `yaml:"max_returned_metrics"`
+
+	Timeout         int               `yaml:"timeout"`
+	Headers         map[string]string `yaml:"headers"`
+	ExtraHeaders    map[string]string `yaml:"extra_headers"`
+	Username        string            `yaml:"username"`
+	Password        string            `yaml:"password"`
+	BearerTokenAuth bool              `yaml:"bearer_token_auth"`
+	BearerTokenPath string            `yaml:"bearer_token_path"`
+	TLSVerify       *bool             `yaml:"tls_verify"`
+	TLSCACert       string            `yaml:"tls_ca_cert"`
+	TLSCert         string            `yaml:"tls_cert"`
+	TLSPrivateKey   string            `yaml:"tls_private_key"`
+	SkipProxy       bool              `yaml:"skip_proxy"`
+}
+
+// metricMatcher matches the names of the metrics to collect and renames them.
+type metricMatcher struct {
+	include []*regexp.Regexp
+	renames map[string]string
+	exclude []*regexp.Regexp
+}
+
+// config holds the parsed configuration of an instance of the check.
+type config struct {
+	instanceConfig
+
+	endpoint string
+	// v2 reports whether the instance uses openmetrics_endpoint, in which case
+	// V2 naming is used for the health service check.
+	v2      bool
+	metrics metricMatcher
+	labels  map[string]string
+	exclude map[string]struct{}
+}
+
+func boolValue(b *bool, def bool) bool {
+	if b == nil {
+		return def
+	}
+	return *b
+}
+
+// compileNamePattern compiles a metric name pattern, which must match the whole name.
+// Patterns are regular expressions for V2 instances, and wildcards where "*" matches
+// any sequence of characters and "?" any single character for V1 instances, like in
+// the Python openmetrics check.
+func compileNamePattern(p string, v2 bool) (*regexp.Regexp, error) {
+	if !v2 {
+		p = strings.NewReplacer(`\*`, ".*", `\?`, ".").Replace(regexp.QuoteMeta(p))
+	}
+	return regexp.Compile("^(?:" + p + ")$")
+}
+
+func (c *config) parse(data []byte) error {
+	if err := yaml.Unmarshal(data, &c.instanceConfig); err != nil {
+		return err
+	}
+	switch {
+	case c.OpenMetricsEndpoint != "":
+		c.endpoint = c.OpenMetricsEndpoint
+		c.v2 = true
+	case c.PrometheusURL != "":
+		c.endpoint = c.PrometheusURL
+	default:
+		return errors.New("one of openmetrics_endpoint or prometheus_url is required")
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = defaultTimeout
+	}
+	if c.MaxReturnedMetrics <= 0 {
+		c.MaxReturnedMetrics = defaultMaxReturnedMetrics
+	}
+	if c.BearerTokenPath == "" {
+		c.BearerTokenPath = defaultBearerTokenPath
+	}
+	c.HistogramBucketsAsDistributions = c.HistogramBucketsAsDistributions || c.SendDistributionBuckets
+
+	if len(c.Metrics) == 0 {
+		return errors.New("at least one entry is required in metrics")
+	}
+	c.metrics.renames = make(map[string]string)
+	for _, m := range c.Metrics {
+		switch v := m.(type) {
+		case string:
+			re, err := compileNamePattern(v, c.v2)
+			if err != nil {
+				return fmt.Errorf("invalid metric pattern %q: %s", v, err)
+			}
+			c.metrics.include = append(c.metrics.include, re)
+		case map[interface{}]interface{}:
+			for name, rename := range v {
+				n, ok1 := name.(string)
+				r, ok2 := rename.(string)
+				if !ok1 || !ok2 {
+					return fmt.Errorf("invalid metric rename %v: %v", name, rename)
+				}
+				c.metrics.renames[n] = r
+			}
+		default:
+			return fmt.Errorf("invalid metrics entry %v: it should be a pattern or a mapping", m)
+		}
+	}
+	// exclude_metrics is a V2 option taking regular expressions, while
+	// ignore_metrics is a V1 option taking wildcards
+	for _, p := range c.ExcludeMetrics {
+		re, err := compileNamePattern(p, true)
+		if err != nil {
+			return fmt.Errorf("invalid excluded metric pattern %q: %s", p, err)
+		}
+		c.metrics.exclude = append(c.metrics.exclude, re)
+	}
+	for _, p := range c.IgnoreMetrics {
+		re, err := compileNamePattern(p, false)
+		if err != nil {
+			return fmt.Errorf("invalid excluded metric pattern %q: %s", p, err)
+		}
+		c.metrics.exclude = append(c.metrics.exclude, re)
+	}
+	for name, typ := range c.TypeOverrides {
+		switch strings.ToLower(typ) {
+		case "gauge", "counter", "histogram", "summary", "untyped":
+		default:
+			return fmt.Errorf("invalid type override %q for metric %q", typ, name)
+		}
+	}
+
+	c.labels = make(map[string]string, len(c.LabelsMapper)+len(c.RenameLabels))
+	for k, v := range c.LabelsMapper {
+		c.labels[k] = v
+	}
+	for k, v := range c.RenameLabels {
+		c.labels[k] = v
+	}
+	c.exclude = make(map[string]struct{}, len(c.ExcludeLabels))
+	for _, l := range c.ExcludeLabels {
+		c.exclude[l] = struct{}{}
+	}
+	return nil
+}
+
+// match returns the name under which the metric with the given name must be
+// submitted, without namespace, and whether it must be submitted at all.
+func (m *metricMatcher) match(name string) (string, bool) {
+	for _, re := range m.exclude {
+		if re.MatchString(name) {
+			return "", false
+		}
+	}
+	if rename, ok := m.renames[name]; ok {
+		return rename, true
+	}
+	for _, re := range m.include {
+		if re.MatchString(name) {
+			return name, true
+		}
+	}
+	return "", false
+}
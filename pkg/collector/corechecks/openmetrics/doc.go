@@ -0,0 +1,11 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+/*
+Package openmetrics implements a core check scraping endpoints exposing metrics in
+the Prometheus or OpenMetrics format, for agents running without the Python
+openmetrics check or instances setting `loader: core`.
+*/
+package openmetrics
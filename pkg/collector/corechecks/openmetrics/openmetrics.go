@@ -0,0 +1,194 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package openmetrics
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	// CheckName is the name of the check
+	CheckName = "openmetrics"
+
+	// acceptHeader prefers the protobuf format, which is cheaper to parse, and
+	// falls back to the text format.
+	acceptHeader = `application/vnd.google.protobuf;proto=io.prometheus.client.MetricFamily;encoding=delimited;q=0.7,text/plain;version=0.0.4;q=0.3,*/*;q=0.1`
+)
+
+// OpenMetricsCheck collects metrics exposed by an endpoint in the Prometheus or
+// OpenMetrics format.
+type OpenMetricsCheck struct {
+	core.CheckBase
+	cfg    *config
+	client *http.Client
+}
+
+// Configure parses the check configuration and builds the HTTP client
+func (c *OpenMetricsCheck) Configure(integrationConfigDigest uint64, data integration.Data, initConfig integration.Data, source string) error {
+	cfg := new(config)
+	if err := cfg.parse(data); err != nil {
+		log.Errorf("Error parsing configuration file: %s", err)
+		return err
+	}
+
+	c.BuildID(integrationConfigDigest, data, initConfig)
+	c.cfg = cfg
+
+	client, err := newClient(cfg)
+	if err != nil {
+		return err
+	}
+	c.client = client
+
+	return c.CommonConfigure(integrationConfigDigest, initConfig, data, source)
+}
+
+// newClient returns the HTTP client used to scrape the endpoint of cfg.
+func newClient(cfg *config) (*http.Client, error) {
+	transport := httputils.CreateHTTPTransport()
+	if cfg.SkipProxy {
+		transport.Proxy = nil
+	}
+	tlsConfig := transport.TLSClientConfig.Clone()
+	tlsConfig.InsecureSkipVerify = !boolValue(cfg.TLSVerify, true)
+	if err := httputils.LoadClientTLSFiles(tlsConfig, cfg.TLSCACert, cfg.TLSCert, cfg.TLSPrivateKey); err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   time.Duration(cfg.Timeout) * time.Second,
+	}, nil
+}
+
+// Run scrapes the endpoint and submits the collected metrics
+func (c *OpenMetricsCheck) Run() error {
+	sender, err := c.GetSender()
+	if err != nil {
+		return err
+	}
+
+	families, err := c.scrape()
+	if boolValue(c.cfg.HealthServiceCheck, true) {
+		status, message := servicecheck.ServiceCheckOK, ""
+		if err != nil {
+			status, message = servicecheck.ServiceCheckCritical, err.Error()
+		}
+		sender.ServiceCheck(c.healthServiceCheckName(), status, "", []string{"endpoint:" + c.cfg.endpoint}, message)
+	}
+	if err != nil {
+		sender.Commit()
+		return err
+	}
+
+	sub := submitter{cfg: c.cfg, sender: sender, budget: c.cfg.MaxReturnedMetrics}
+	for _, mf := range families {
+		sub.submitFamily(mf)
+	}
+	if sub.budget < 0 {
+		log.Warnf("%s: the endpoint %s returned more than max_returned_metrics (%d) metrics, the extra ones were dropped", c.ID(), c.cfg.endpoint, c.cfg.MaxReturnedMetrics)
+	}
+
+	sender.Commit()
+	return nil
+}
+
+// healthServiceCheckName returns the name of the service check reporting
+// whether the endpoint could be scraped.
+func (c *OpenMetricsCheck) healthServiceCheckName() string {
+	name := "prometheus.health"
+	if c.cfg.v2 {
+		name = "openmetrics.health"
+	}
+	if c.cfg.Namespace != "" {
+		name = c.cfg.Namespace + "." + name
+	}
+	return name
+}
+
+// scrape fetches and decodes the metric families exposed by the endpoint.
+func (c *OpenMetricsCheck) scrape() ([]*dto.MetricFamily, error) {
+	req, err := http.NewRequest(http.MethodGet, c.cfg.endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	for k, v := range c.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	for k, v := range c.cfg.ExtraHeaders {
+		req.Header.Set(k, v)
+	}
+	if c.cfg.Username != "" {
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+	if c.cfg.BearerTokenAuth {
+		// the token is read on every run as it may be rotated
+		token, err := os.ReadFile(c.cfg.BearerTokenPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read bearer token: %s", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from %s", resp.StatusCode, c.cfg.endpoint)
+	}
+
+	var families []*dto.MetricFamily
+	dec := expfmt.NewDecoder(resp.Body, expfmt.ResponseFormat(resp.Header))
+	for {
+		mf := new(dto.MetricFamily)
+		if err := dec.Decode(mf); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("unable to parse the payload of %s: %s", c.cfg.endpoint, err)
+		}
+		families = append(families, mf)
+	}
+
+	// the text decoder returns the families in random order, they're sorted so
+	// that max_returned_metrics always keeps the same metrics
+	sort.Slice(families, func(i, j int) bool {
+		return families[i].GetName() < families[j].GetName()
+	})
+	return families, nil
+}
+
+func openMetricsFactory() check.Check {
+	return &OpenMetricsCheck{
+		CheckBase: core.NewCheckBase(CheckName),
+	}
+}
+
+func init() {
+	core.RegisterCheck(CheckName, openMetricsFactory)
+}
@@ -0,0 +1,263 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package openmetrics
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
+)
+
+const testPayload = `# HELP http_requests_total Total requests.
+# TYPE http_requests_total counter
+http_requests_total{code="200",method="get"} 1027
+http_requests_total{code="500",method="get"} 3
+# HELP temperature Current temperature.
+# TYPE temperature gauge
+temperature{room="kitchen"} 21.5
+# TYPE internal_only gauge
+internal_only 1
+# TYPE rpc_duration_seconds summary
+rpc_duration_seconds{quantile="0.5"} 0.05
+rpc_duration_seconds{quantile="0.99"} 0.2
+rpc_duration_seconds_sum 17.5
+rpc_duration_seconds_count 200
+# TYPE request_latency_seconds histogram
+request_latency_seconds_bucket{le="0.1"} 10
+request_latency_seconds_bucket{le="0.5"} 15
+request_latency_seconds_bucket{le="+Inf"} 16
+request_latency_seconds_sum 3.2
+request_latency_seconds_count 16
+`
+
+func testServer(t *testing.T, contentType, payload string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.Header.Get("Accept"), "text/plain")
+		assert.Equal(t, "value", r.Header.Get("X-Test"))
+		w.Header().Set("Content-Type", contentType)
+		fmt.Fprint(w, payload)
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func runCheck(t *testing.T, instance string) *mocksender.MockSender {
+	c := openMetricsFactory().(*OpenMetricsCheck)
+	require.NoError(t, c.Configure(integration.FakeConfigHash, []byte(instance), nil, "test"))
+	sender := mocksender.NewMockSender(c.ID())
+	sender.SetupAcceptAll()
+	c.Run()
+	return sender
+}
+
+func TestConfigParse(t *testing.T) {
+	for name, tt := range map[string]struct {
+		instance string
+		err      string
+	}{
+		"no endpoint":     {"metrics: [foo]", "one of openmetrics_endpoint or prometheus_url is required"},
+		"no metrics":      {"prometheus_url: http://localhost", "at least one entry is required in metrics"},
+		"bad pattern":     {"openmetrics_endpoint: http://localhost\nmetrics: ['(']", "invalid metric pattern"},
+		"bad exclude":     {"openmetrics_endpoint: http://localhost\nmetrics: [foo]\nexclude_metrics: ['(']", "invalid excluded metric pattern"},
+		"bad rename":      {"prometheus_url: http://localhost\nmetrics: [{foo: [bar]}]", "invalid metric rename"},
+		"bad override":    {"prometheus_url: http://localhost\nmetrics: [foo]\ntype_overrides: {foo: set}", "invalid type override"},
+		"valid":           {"openmetrics_endpoint: http://localhost\nmetrics: [foo, {bar: baz}]\nexclude_metrics: [foo_.*]", ""},
+		"valid ignore v1": {"prometheus_url: http://localhost\nmetrics: ['*']\nignore_metrics: [go_*]", ""},
+		"v1 parenthesis":  {"prometheus_url: http://localhost\nmetrics: ['(']", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var cfg config
+			err := cfg.parse([]byte(tt.instance))
+			if tt.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestMetricMatcher(t *testing.T) {
+	var cfg config
+	require.NoError(t, cfg.parse([]byte(`
+prometheus_url: http://localhost
+metrics:
+  - http_*
+  - go_?c
+  - process_cpu_seconds_total: cpu.seconds
+ignore_metrics:
+  - http_debug_*
+`)))
+	for name, want := range map[string]string{
+		"http_requests_total":       "http_requests_total",
+		"process_cpu_seconds_total": "cpu.seconds",
+		"go_gc":                     "go_gc",
+		"http_debug_requests":       "",
+		"my_http_requests":          "",
+		"go_goroutines":             "",
+		"go_ggc":                    "",
+	} {
+		got, ok := cfg.metrics.match(name)
+		assert.Equal(t, want != "", ok, name)
+		assert.Equal(t, want, got, name)
+	}
+}
+
+func TestMetricMatcherV2(t *testing.T) {
+	var cfg config
+	require.NoError(t, cfg.parse([]byte(`
+openmetrics_endpoint: http://localhost
+metrics:
+  - http_.*
+exclude_metrics:
+  - http_debug_.*
+`)))
+	for name, want := range map[string]bool{
+		"http_requests_total": true,
+		"http_debug_requests": false,
+		"http_":               true,
+		"my_http_requests":    false,
+	} {
+		_, ok := cfg.metrics.match(name)
+		assert.Equal(t, want, ok, name)
+	}
+}
+
+func TestConfigureDefaultMetricsV1(t *testing.T) {
+	srv := testServer(t, "text/plain; version=0.0.4", testPayload)
+	// the prometheus autodiscovery providers set metrics: ["*"] by default
+	sender := runCheck(t, fmt.Sprintf(`
+prometheus_url: %s
+namespace: app
+metrics: ["*"]
+headers:
+  X-Test: value
+`, srv.URL))
+
+	sender.AssertMetric(t, "Gauge", "app.temperature", 21.5, "", []string{"room:kitchen"})
+	sender.AssertMetric(t, "Gauge", "app.internal_only", 1, "", []string{})
+}
+
+func TestRunText(t *testing.T) {
+	srv := testServer(t, "text/plain; version=0.0.4", testPayload)
+	sender := runCheck(t, fmt.Sprintf(`
+prometheus_url: %s/metrics
+namespace: app
+metrics:
+  - http_requests_total: requests
+  - temperature
+  - rpc_*
+  - request_latency_seconds
+labels_mapper:
+  room: location
+exclude_labels:
+  - method
+headers:
+  X-Test: value
+`, srv.URL))
+
+	sender.AssertMetric(t, "MonotonicCount", "app.requests", 1027, "", []string{"code:200"})
+	sender.AssertMetricNotTaggedWith(t, "MonotonicCount", "app.requests", []string{"method:get"})
+	sender.AssertMetric(t, "Gauge", "app.temperature", 21.5, "", []string{"location:kitchen"})
+	sender.AssertNotCalled(t, "Gauge", "app.internal_only", 1.0, "", []string{})
+
+	sender.AssertMetric(t, "Gauge", "app.rpc_duration_seconds.count", 200, "", []string{})
+	sender.AssertMetric(t, "Gauge", "app.rpc_duration_seconds.sum", 17.5, "", []string{})
+	sender.AssertMetric(t, "Gauge", "app.rpc_duration_seconds.quantile", 0.2, "", []string{"quantile:0.99"})
+
+	sender.AssertMetric(t, "Gauge", "app.request_latency_seconds.count", 16, "", []string{})
+	sender.AssertMetric(t, "Gauge", "app.request_latency_seconds.bucket", 10, "", []string{"upper_bound:0.1"})
+	sender.AssertMetric(t, "Gauge", "app.request_latency_seconds.bucket", 16, "", []string{"upper_bound:+Inf"})
+	sender.AssertNotCalled(t, "HistogramBucket")
+
+	sender.AssertServiceCheck(t, "app.prometheus.health", servicecheck.ServiceCheckOK, "", []string{"endpoint:" + srv.URL + "/metrics"}, "")
+	sender.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestRunProtobuf(t *testing.T) {
+	families, err := (&expfmt.TextParser{}).TextToMetricFamilies(strings.NewReader(testPayload))
+	require.NoError(t, err)
+	var payload strings.Builder
+	enc := expfmt.NewEncoder(&payload, expfmt.FmtProtoDelim)
+	for _, mf := range families {
+		require.NoError(t, enc.Encode(mf))
+	}
+
+	srv := testServer(t, string(expfmt.FmtProtoDelim), payload.String())
+	sender := runCheck(t, fmt.Sprintf(`
+openmetrics_endpoint: %s
+metrics:
+  - request_latency_seconds
+  - http_requests_total
+histogram_buckets_as_distributions: true
+send_monotonic_counter: false
+send_distribution_sums_as_monotonic: true
+extra_headers:
+  X-Test: value
+`, srv.URL))
+
+	sender.AssertMetric(t, "Gauge", "http_requests_total", 3, "", []string{"code:500", "method:get"})
+	sender.AssertMetric(t, "Gauge", "request_latency_seconds.count", 16, "", []string{})
+	sender.AssertMetric(t, "MonotonicCount", "request_latency_seconds.sum", 3.2, "", []string{})
+	sender.AssertHistogramBucket(t, "HistogramBucket", "request_latency_seconds", 10, 0, 0.1, true, "", []string{}, false)
+	sender.AssertHistogramBucket(t, "HistogramBucket", "request_latency_seconds", 5, 0.1, 0.5, true, "", []string{}, false)
+	sender.AssertHistogramBucket(t, "HistogramBucket", "request_latency_seconds", 1, 0.5, math.Inf(1), true, "", []string{}, false)
+	sender.AssertNotCalled(t, "Gauge", "request_latency_seconds.bucket", 10.0, "", []string{"upper_bound:0.1"})
+	sender.AssertServiceCheck(t, "openmetrics.health", servicecheck.ServiceCheckOK, "", []string{}, "")
+}
+
+func TestRunTypeOverridesAndLimit(t *testing.T) {
+	srv := testServer(t, "text/plain; version=0.0.4", testPayload)
+	instance := fmt.Sprintf(`
+prometheus_url: %s
+metrics:
+  - temperature
+  - http_requests_total
+type_overrides:
+  temperature: counter
+headers:
+  X-Test: value
+`, srv.URL)
+
+	sender := runCheck(t, instance)
+	sender.AssertMetric(t, "MonotonicCount", "temperature", 21.5, "", []string{"room:kitchen"})
+	sender.AssertNumberOfCalls(t, "MonotonicCount", 3)
+
+	// the metrics are kept in the order of their names
+	sender = runCheck(t, instance+"max_returned_metrics: 2\n")
+	sender.AssertNumberOfCalls(t, "MonotonicCount", 2)
+	sender.AssertMetric(t, "MonotonicCount", "http_requests_total", 1027, "", []string{"code:200", "method:get"})
+	sender.AssertMetric(t, "MonotonicCount", "http_requests_total", 3, "", []string{"code:500", "method:get"})
+	sender.AssertNotCalled(t, "MonotonicCount", "temperature", 21.5, "", []string{"room:kitchen"})
+}
+
+func TestRunError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	c := openMetricsFactory().(*OpenMetricsCheck)
+	require.NoError(t, c.Configure(integration.FakeConfigHash, []byte("prometheus_url: "+srv.URL+"\nnamespace: app\nmetrics: ['*']"), nil, "test"))
+	sender := mocksender.NewMockSender(c.ID())
+	sender.SetupAcceptAll()
+	assert.Error(t, c.Run())
+	sender.AssertServiceCheck(t, "app.prometheus.health", servicecheck.ServiceCheckCritical, "", []string{"endpoint:" + srv.URL}, "unexpected status code 500 from "+srv.URL)
+	sender.AssertNumberOfCalls(t, "Gauge", 0)
+	sender.AssertNumberOfCalls(t, "Commit", 1)
+}
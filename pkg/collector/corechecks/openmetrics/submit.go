@@ -0,0 +1,172 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package openmetrics
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+)
+
+// submitter converts metric families to Datadog metrics and submits them.
+type submitter struct {
+	cfg    *config
+	sender sender.Sender
+	// budget is the number of metrics which can still be submitted in this run.
+	// It goes negative once metrics start being dropped.
+	budget int
+}
+
+// take reserves room for one metric and reports whether it can be submitted.
+func (s *submitter) take() bool {
+	s.budget--
+	return s.budget >= 0
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// tags converts the labels of a sample to tags, applying the label renames and exclusions.
+func (s *submitter) tags(labels []*dto.LabelPair) []string {
+	tags := make([]string, 0, len(labels)+1)
+	for _, l := range labels {
+		name := l.GetName()
+		if _, ok := s.cfg.exclude[name]; ok {
+			continue
+		}
+		if rename, ok := s.cfg.labels[name]; ok {
+			name = rename
+		}
+		tags = append(tags, name+":"+l.GetValue())
+	}
+	return tags
+}
+
+// value returns the value of a gauge, counter or untyped sample, whatever its actual type.
+func value(m *dto.Metric) float64 {
+	switch {
+	case m.Gauge != nil:
+		return m.Gauge.GetValue()
+	case m.Counter != nil:
+		return m.Counter.GetValue()
+	default:
+		return m.Untyped.GetValue()
+	}
+}
+
+// count submits the count or sum of a summary or histogram.
+func (s *submitter) count(name string, v float64, tags []string, monotonic bool) {
+	if !s.take() {
+		return
+	}
+	if monotonic {
+		s.sender.MonotonicCount(name, v, "", tags)
+	} else {
+		s.sender.Gauge(name, v, "", tags)
+	}
+}
+
+func (s *submitter) submitFamily(mf *dto.MetricFamily) {
+	name, ok := s.cfg.metrics.match(mf.GetName())
+	if !ok {
+		return
+	}
+	if s.cfg.Namespace != "" {
+		name = s.cfg.Namespace + "." + name
+	}
+	typ := strings.ToLower(mf.GetType().String())
+	if override, ok := s.cfg.TypeOverrides[mf.GetName()]; ok {
+		typ = strings.ToLower(override)
+	}
+
+	for _, m := range mf.GetMetric() {
+		tags := s.tags(m.GetLabel())
+		switch {
+		case typ == "summary" && m.Summary != nil:
+			s.submitSummary(name, m.Summary, tags)
+		case typ == "histogram" && m.Histogram != nil:
+			s.submitHistogram(name, m.Histogram, tags)
+		case typ == "counter":
+			if !s.take() {
+				return
+			}
+			if boolValue(s.cfg.SendMonotonicCounter, true) {
+				s.sender.MonotonicCount(name, value(m), "", tags)
+			} else {
+				s.sender.Gauge(name, value(m), "", tags)
+			}
+		case m.Gauge != nil || m.Counter != nil || m.Untyped != nil:
+			if !s.take() {
+				return
+			}
+			s.sender.Gauge(name, value(m), "", tags)
+		}
+	}
+}
+
+func (s *submitter) submitSummary(name string, m *dto.Summary, tags []string) {
+	s.count(name+".count", float64(m.GetSampleCount()), tags, s.cfg.SendDistributionCountsAsMonotonic)
+	s.count(name+".sum", m.GetSampleSum(), tags, s.cfg.SendDistributionSumsAsMonotonic)
+	for _, q := range m.GetQuantile() {
+		if !s.take() {
+			return
+		}
+		s.sender.Gauge(name+".quantile", q.GetValue(), "", append(tags[:len(tags):len(tags)], "quantile:"+formatFloat(q.GetQuantile())))
+	}
+}
+
+func (s *submitter) submitHistogram(name string, m *dto.Histogram, tags []string) {
+	s.count(name+".count", float64(m.GetSampleCount()), tags, s.cfg.SendDistributionCountsAsMonotonic)
+	s.count(name+".sum", m.GetSampleSum(), tags, s.cfg.SendDistributionSumsAsMonotonic)
+
+	buckets := m.GetBucket()
+	if len(buckets) == 0 || !math.IsInf(buckets[len(buckets)-1].GetUpperBound(), 1) {
+		// the +Inf bucket is implicit in the protobuf format
+		inf := math.Inf(1)
+		buckets = append(buckets[:len(buckets):len(buckets)], &dto.Bucket{
+			CumulativeCount: m.SampleCount,
+			UpperBound:      &inf,
+		})
+	}
+
+	if s.cfg.HistogramBucketsAsDistributions {
+		// buckets are cumulative, distributions need the count of each bucket
+		var lower float64
+		var prev uint64
+		for i, b := range buckets {
+			upper := b.GetUpperBound()
+			if i == 0 && upper <= 0 {
+				lower = math.Inf(-1)
+			}
+			if !s.take() {
+				return
+			}
+			s.sender.HistogramBucket(name, int64(b.GetCumulativeCount()-prev), lower, upper, true, "", tags, false)
+			lower, prev = upper, b.GetCumulativeCount()
+		}
+		return
+	}
+
+	if !boolValue(s.cfg.SendHistogramsBuckets, true) {
+		return
+	}
+	for _, b := range buckets {
+		if !s.take() {
+			return
+		}
+		bucketTags := append(tags[:len(tags):len(tags)], "upper_bound:"+formatFloat(b.GetUpperBound()))
+		if s.cfg.SendDistributionCountsAsMonotonic {
+			s.sender.MonotonicCount(name+".bucket", float64(b.GetCumulativeCount()), "", bucketTags)
+		} else {
+			s.sender.Gauge(name+".bucket", float64(b.GetCumulativeCount()), "", bucketTags)
+		}
+	}
+}
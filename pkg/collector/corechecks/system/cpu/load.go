@@ -14,6 +14,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/system/psi"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
@@ -21,6 +22,7 @@ const loadCheckName = "load"
 
 // For testing purpose
 var loadAvg = load.Avg
+var submitPSI = psi.Submit
 
 // LoadCheck doesn't need additional fields
 type LoadCheck struct {
@@ -48,6 +50,9 @@ func (c *LoadCheck) Run() error {
 	sender.Gauge("system.load.norm.1", avg.Load1/cpus, "", nil)
 	sender.Gauge("system.load.norm.5", avg.Load5/cpus, "", nil)
 	sender.Gauge("system.load.norm.15", avg.Load15/cpus, "", nil)
+	if err := submitPSI(sender, psi.CPU, "system.cpu.pressure"); err != nil {
+		log.Debugf("system.LoadCheck: could not retrieve cpu pressure stats: %s", err)
+	}
 	sender.Commit()
 
 	return nil
@@ -12,6 +12,7 @@ import (
 	"github.com/shirou/gopsutil/v3/load"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
 )
 
@@ -28,6 +29,12 @@ func Avg() (*load.AvgStat, error) {
 func TestLoadCheckLinux(t *testing.T) {
 	loadAvg = Avg
 	cpuInfo = CPUInfo
+	submitPSI = func(s sender.Sender, resource, prefix string) error {
+		if resource == "cpu" {
+			s.Gauge(prefix+".some.avg60", 12.5, "", nil)
+		}
+		return nil
+	}
 	loadCheck := new(LoadCheck)
 	loadCheck.Configure(integration.FakeConfigHash, nil, nil, "test")
 
@@ -45,10 +52,11 @@ func TestLoadCheckLinux(t *testing.T) {
 	mock.On("Gauge", "system.load.norm.1", 0.83/nbCPU, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.load.norm.5", 0.96/nbCPU, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.load.norm.15", 1.15/nbCPU, "", []string(nil)).Return().Times(1)
+	mock.On("Gauge", "system.cpu.pressure.some.avg60", 12.5, "", []string(nil)).Return().Times(1)
 	mock.On("Commit").Return().Times(1)
 	loadCheck.Run()
 
 	mock.AssertExpectations(t)
-	mock.AssertNumberOfCalls(t, "Gauge", 6)
+	mock.AssertNumberOfCalls(t, "Gauge", 7)
 	mock.AssertNumberOfCalls(t, "Commit", 1)
 }
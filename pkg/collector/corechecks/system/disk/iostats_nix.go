@@ -18,6 +18,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
 	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/system/psi"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
@@ -25,6 +26,7 @@ import (
 var (
 	ioCounters = disk.IOCounters
 	swapMemory = mem.SwapMemory
+	submitPSI  = psi.Submit
 
 	// for test purpose
 	nowNano = func() int64 { return time.Now().UnixNano() }
@@ -198,6 +200,9 @@ func (c *IOCheck) Run() error {
 	err = c.nixIO()
 
 	if err == nil {
+		if err := submitPSI(sender, psi.IO, "system.io.pressure"); err != nil {
+			log.Debugf("system.IOCheck: could not retrieve io pressure stats: %s", err)
+		}
 		sender.Commit()
 	}
 	return err
@@ -16,7 +16,9 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/system/psi"
 )
 
 var currentStats = map[string]disk.IOCountersStat{
@@ -91,6 +93,13 @@ func TestIoStatsOverflow(t *testing.T) {
 		return currentStats, nil
 	}
 	swapMemory = SwapMemory
+	submitPSI = func(s sender.Sender, resource, prefix string) error {
+		if resource == "io" {
+			s.Rate(prefix+".full.total", 42.0, "", nil)
+		}
+		return nil
+	}
+	defer func() { submitPSI = psi.Submit }()
 
 	mock := mocksender.NewMockSender(ioCheck.ID())
 
@@ -109,6 +118,7 @@ func TestIoStatsOverflow(t *testing.T) {
 	mock.On("Gauge", "system.io.svctm", 0.5, "", []string{"device:sda", "device_name:sda"}).Return().Times(1)
 	mock.On("Rate", "system.io.block_in", 23.0, "", []string(nil)).Return().Times(1)
 	mock.On("Rate", "system.io.block_out", 24.0, "", []string(nil)).Return().Times(1)
+	mock.On("Rate", "system.io.pressure.full.total", 42.0, "", []string(nil)).Return().Times(1)
 	mock.On("Commit").Return().Times(1)
 
 	// simulate a 1s interval
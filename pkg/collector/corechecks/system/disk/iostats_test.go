@@ -16,6 +16,7 @@ import (
 	"github.com/shirou/gopsutil/v3/mem"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
 )
 
@@ -107,6 +108,7 @@ func sampler(samples []map[string]disk.IOCountersStat, names ...string) (map[str
 func TestIOCheckDM(t *testing.T) {
 	ioCounters = ioSamplerDM
 	swapMemory = SwapMemory
+	submitPSI = func(sender.Sender, string, string) error { return nil }
 	ioCheck := new(IOCheck)
 	ioCheck.Configure(integration.FakeConfigHash, nil, nil, "test")
 
@@ -133,6 +135,7 @@ func TestIOCheck(t *testing.T) {
 
 	ioCounters = ioSampler
 	swapMemory = SwapMemory
+	submitPSI = func(sender.Sender, string, string) error { return nil }
 	ioCheck := new(IOCheck)
 	ioCheck.Configure(integration.FakeConfigHash, nil, nil, "test")
 
@@ -201,6 +204,7 @@ func TestIOCheck(t *testing.T) {
 func TestIOCheckBlacklist(t *testing.T) {
 	ioCounters = ioSampler
 	swapMemory = SwapMemory
+	submitPSI = func(sender.Sender, string, string) error { return nil }
 	ioCheck := new(IOCheck)
 	ioCheck.Configure(integration.FakeConfigHash, nil, nil, "test")
 
@@ -16,12 +16,14 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 
 	core "github.com/DataDog/datadog-agent/pkg/collector/corechecks"
+	"github.com/DataDog/datadog-agent/pkg/collector/corechecks/system/psi"
 )
 
 // For testing purpose
 var virtualMemory = mem.VirtualMemory
 var swapMemory = mem.SwapMemory
 var runtimeOS = runtime.GOOS
+var submitPSI = psi.Submit
 
 // Check doesn't need additional fields
 type Check struct {
@@ -96,6 +98,10 @@ func (c *Check) linuxSpecificVirtualMemoryCheck(v *mem.VirtualMemoryStat) error
 	sender.Gauge("system.mem.commit_limit", float64(v.CommitLimit)/mbSize, "", nil)
 	sender.Gauge("system.mem.committed_as", float64(v.CommittedAS)/mbSize, "", nil)
 	sender.Gauge("system.swap.cached", float64(v.SwapCached)/mbSize, "", nil)
+
+	if err := submitPSI(sender, psi.Memory, "system.mem.pressure"); err != nil {
+		log.Debugf("memory.Check: could not retrieve memory pressure stats: %s", err)
+	}
 	return nil
 }
 
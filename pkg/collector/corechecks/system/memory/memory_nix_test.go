@@ -16,6 +16,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 )
 
 func VirtualMemory() (*mem.VirtualMemoryStat, error) {
@@ -56,9 +57,19 @@ func SwapMemory() (*mem.SwapMemoryStat, error) {
 	}, nil
 }
 
+func fakeSubmitPSI(s sender.Sender, resource, prefix string) error {
+	if resource == "memory" {
+		s.Gauge(prefix+".some.avg10", 1.5, "", nil)
+	}
+	return nil
+}
+
 func TestMemoryCheckLinux(t *testing.T) {
 	virtualMemory = VirtualMemory
 	swapMemory = SwapMemory
+	origSubmitPSI := submitPSI
+	t.Cleanup(func() { submitPSI = origSubmitPSI })
+	submitPSI = fakeSubmitPSI
 	memCheck := new(Check)
 
 	mock := mocksender.NewMockSender(memCheck.ID())
@@ -83,6 +94,7 @@ func TestMemoryCheckLinux(t *testing.T) {
 	mock.On("Gauge", "system.swap.used", 40000.0/mbSize, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.swap.pct_free", 0.6, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.swap.cached", 25000000000.0/mbSize, "", []string(nil)).Return().Times(1)
+	mock.On("Gauge", "system.mem.pressure.some.avg10", 1.5, "", []string(nil)).Return().Times(1)
 	mock.On("Rate", "system.swap.swap_in", 21.0/mbSize, "", []string(nil)).Return().Times(1)
 	mock.On("Rate", "system.swap.swap_out", 22.0/mbSize, "", []string(nil)).Return().Times(1)
 	mock.On("Commit").Return().Times(1)
@@ -91,7 +103,7 @@ func TestMemoryCheckLinux(t *testing.T) {
 	require.Nil(t, err)
 
 	mock.AssertExpectations(t)
-	mock.AssertNumberOfCalls(t, "Gauge", 18)
+	mock.AssertNumberOfCalls(t, "Gauge", 19)
 	mock.AssertNumberOfCalls(t, "Rate", 2)
 	mock.AssertNumberOfCalls(t, "Commit", 1)
 }
@@ -177,6 +189,9 @@ func TestMemoryError(t *testing.T) {
 func TestSwapMemoryError(t *testing.T) {
 	virtualMemory = VirtualMemory
 	swapMemory = func() (*mem.SwapMemoryStat, error) { return nil, fmt.Errorf("some error") }
+	origSubmitPSI := submitPSI
+	t.Cleanup(func() { submitPSI = origSubmitPSI })
+	submitPSI = fakeSubmitPSI
 	memCheck := new(Check)
 
 	mock := mocksender.NewMockSender(memCheck.ID())
@@ -197,12 +212,13 @@ func TestSwapMemoryError(t *testing.T) {
 	mock.On("Gauge", "system.mem.commit_limit", 785338368.0/mbSize, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.mem.committed_as", 433750016.0/mbSize, "", []string(nil)).Return().Times(1)
 	mock.On("Gauge", "system.swap.cached", 25000000000.0/mbSize, "", []string(nil)).Return().Times(1)
+	mock.On("Gauge", "system.mem.pressure.some.avg10", 1.5, "", []string(nil)).Return().Times(1)
 	mock.On("Commit").Return().Times(1)
 	err := memCheck.Run()
 	require.Nil(t, err)
 
 	mock.AssertExpectations(t)
-	mock.AssertNumberOfCalls(t, "Gauge", 14)
+	mock.AssertNumberOfCalls(t, "Gauge", 15)
 	mock.AssertNumberOfCalls(t, "Commit", 1)
 }
 
@@ -0,0 +1,15 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package psi submits the host-wide Linux Pressure Stall Information (PSI) metrics
+// found in /proc/pressure for the system checks.
+package psi
+
+// Resources exposing Pressure Stall Information.
+const (
+	CPU    = "cpu"
+	Memory = "memory"
+	IO     = "io"
+)
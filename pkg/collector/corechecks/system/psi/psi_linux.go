@@ -0,0 +1,51 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package psi
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/cgroups"
+)
+
+// Submit reads /proc/pressure/<resource> and submits its metrics under prefix:
+// the some and full avg10, avg60 and avg300 gauges, in percent, and the total
+// stall time as a rate, in nanoseconds. An error is returned when PSI is not
+// available, which is the case on kernels older than 4.20 or booted with psi=0.
+func Submit(s sender.Sender, resource, prefix string) error {
+	procfsPath := "/proc"
+	if config.Datadog.IsSet("procfs_path") {
+		procfsPath = config.Datadog.GetString("procfs_path")
+	}
+
+	var some, full cgroups.PSIStats
+	if err := cgroups.ParsePSIFile(filepath.Join(procfsPath, "pressure", resource), &some, &full); err != nil {
+		return err
+	}
+	submitStats(s, prefix+".some", &some)
+	submitStats(s, prefix+".full", &full)
+	return nil
+}
+
+func submitStats(s sender.Sender, prefix string, stats *cgroups.PSIStats) {
+	if stats.Avg10 != nil {
+		s.Gauge(prefix+".avg10", *stats.Avg10, "", nil)
+	}
+	if stats.Avg60 != nil {
+		s.Gauge(prefix+".avg60", *stats.Avg60, "", nil)
+	}
+	if stats.Avg300 != nil {
+		s.Gauge(prefix+".avg300", *stats.Avg300, "", nil)
+	}
+	if stats.Total != nil {
+		s.Rate(prefix+".total", float64(*stats.Total)*float64(time.Microsecond), "", nil)
+	}
+}
@@ -0,0 +1,44 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package psi
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/config"
+)
+
+func TestSubmit(t *testing.T) {
+	procfsPath := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(procfsPath, "pressure"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(procfsPath, "pressure", Memory), []byte(
+		"some avg10=1.50 avg60=0.75 avg300=0.25 total=123456\n"+
+			"full avg10=0.50 avg60=0.10 avg300=0.00 total=2000\n"), 0644))
+	config.Mock(t).Set("procfs_path", procfsPath)
+
+	mockSender := mocksender.NewMockSender("psi")
+	mockSender.SetupAcceptAll()
+
+	require.NoError(t, Submit(mockSender, Memory, "system.mem.pressure"))
+	mockSender.AssertMetric(t, "Gauge", "system.mem.pressure.some.avg10", 1.5, "", nil)
+	mockSender.AssertMetric(t, "Gauge", "system.mem.pressure.some.avg60", 0.75, "", nil)
+	mockSender.AssertMetric(t, "Gauge", "system.mem.pressure.some.avg300", 0.25, "", nil)
+	mockSender.AssertMetric(t, "Rate", "system.mem.pressure.some.total", 123456000, "", nil)
+	mockSender.AssertMetric(t, "Gauge", "system.mem.pressure.full.avg10", 0.5, "", nil)
+	mockSender.AssertMetric(t, "Rate", "system.mem.pressure.full.total", 2000000, "", nil)
+	mockSender.AssertNumberOfCalls(t, "Gauge", 6)
+	mockSender.AssertNumberOfCalls(t, "Rate", 2)
+
+	assert.Error(t, Submit(mockSender, IO, "system.io.pressure"))
+}
@@ -0,0 +1,15 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build !linux
+
+package psi
+
+import "github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+
+// Submit does nothing as Pressure Stall Information is only available on Linux.
+func Submit(s sender.Sender, resource, prefix string) error {
+	return nil
+}
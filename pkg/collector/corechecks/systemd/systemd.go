@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/coreos/go-systemd/dbus"
+	"golang.org/x/sys/unix"
 	"gopkg.in/yaml.v2"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
@@ -35,17 +36,36 @@ const (
 	typeUnit    = "unit"
 	typeService = "service"
 	typeSocket  = "socket"
+	typeTimer   = "timer"
 
 	canConnectServiceCheck   = "systemd.can_connect"
 	systemStateServiceCheck  = "systemd.system.state"
 	unitStateServiceCheck    = "systemd.unit.state"
 	unitSubStateServiceCheck = "systemd.unit.substate"
+
+	// defaultTimerGracePeriod is the default delay, in seconds, after which a timer which
+	// did not trigger at its scheduled time is reported as having missed a run. It leaves
+	// room for the default AccuracySec of timers, which is one minute.
+	defaultTimerGracePeriod = 300
+
+	// unsetProperty is the value of the accounting properties which are not available,
+	// for instance MemoryCurrent when the unit is not running.
+	unsetProperty = ^uint64(0)
 )
 
 var dbusTypeMap = map[string]string{
 	typeUnit:    "Unit",
 	typeService: "Service",
 	typeSocket:  "Socket",
+	typeTimer:   "Timer",
+}
+
+// execMainCodes maps the ExecMainCode property of services, which is a CLD_* code, to
+// how their main process ended.
+var execMainCodes = map[int32]string{
+	1: "exited",
+	2: "killed",
+	3: "dumped",
 }
 
 // metricConfigItem map a metric to a systemd unit property.
@@ -89,6 +109,32 @@ var metricConfigs = map[string][]metricConfigItem{
 			propertyName: "NRestarts",
 			optional:     true,
 		},
+		{
+			// only present from systemd v238
+			// https://github.com/systemd/systemd/blob/dd0395b5654c52e982adf6d354db9c7fdcf4b6c7/NEWS#L2752-L2755
+			metricName:         "systemd.service.io_read_bytes",
+			propertyName:       "IOReadBytes",
+			accountingProperty: "IOAccounting",
+			optional:           true,
+		},
+		{
+			metricName:         "systemd.service.io_write_bytes",
+			propertyName:       "IOWriteBytes",
+			accountingProperty: "IOAccounting",
+			optional:           true,
+		},
+		{
+			metricName:         "systemd.service.io_read_operations",
+			propertyName:       "IOReadOperations",
+			accountingProperty: "IOAccounting",
+			optional:           true,
+		},
+		{
+			metricName:         "systemd.service.io_write_operations",
+			propertyName:       "IOWriteOperations",
+			accountingProperty: "IOAccounting",
+			optional:           true,
+		},
 	},
 	typeSocket: {
 		{
@@ -147,6 +193,7 @@ type systemdInstanceConfig struct {
 	PrivateSocket         string                         `yaml:"private_socket"`
 	UnitNames             []string                       `yaml:"unit_names"`
 	SubstateStatusMapping map[string]unitSubstateMapping `yaml:"substate_status_mapping"`
+	TimerGracePeriod      int64                          `yaml:"timer_grace_period"`
 }
 
 type systemdInitConfig struct{}
@@ -170,6 +217,9 @@ type systemdStats interface {
 
 	// Misc
 	UnixNow() int64
+	// MonotonicNow returns the time of CLOCK_MONOTONIC in microseconds, which is the clock
+	// of the monotonic timestamps of systemd
+	MonotonicNow() uint64
 }
 
 type defaultSystemdStats struct{}
@@ -206,6 +256,14 @@ func (s *defaultSystemdStats) UnixNow() int64 {
 	return time.Now().Unix()
 }
 
+func (s *defaultSystemdStats) MonotonicNow() uint64 {
+	var ts unix.Timespec
+	if err := unix.ClockGettime(unix.CLOCK_MONOTONIC, &ts); err != nil {
+		return 0
+	}
+	return uint64(ts.Nano() / 1000)
+}
+
 // Run executes the check
 func (c *SystemdCheck) Run() error {
 	sender, err := c.GetSender()
@@ -340,6 +398,9 @@ func (c *SystemdCheck) submitMetrics(sender sender.Sender, conn *dbus.Conn) erro
 
 		c.submitBasicUnitMetrics(sender, conn, unit, tags)
 		c.submitPropertyMetricsAsGauge(sender, conn, unit, tags)
+		if strings.HasSuffix(unit.Name, "."+typeTimer) {
+			c.submitTimerMetrics(sender, conn, unit, tags)
+		}
 	}
 
 	sender.Gauge("systemd.units_total", float64(len(units)), "", nil)
@@ -412,9 +473,85 @@ func (c *SystemdCheck) submitPropertyMetricsAsGauge(sender sender.Sender, conn *
 				}
 			}
 		}
+		if unitType == typeService {
+			submitServiceExitMetrics(sender, serviceProperties, tags)
+		}
 	}
 }
 
+// submitServiceExitMetrics submits the exit status of the main process of a service,
+// once it exited.
+func submitServiceExitMetrics(sender sender.Sender, properties map[string]interface{}, tags []string) {
+	exitTimestamp, err := getPropertyUint64(properties, "ExecMainExitTimestamp")
+	if err != nil || exitTimestamp == 0 {
+		// the main process is still running or never ran
+		return
+	}
+	code, err := getPropertyInt32(properties, "ExecMainCode")
+	if err != nil {
+		log.Debugf("Cannot send the exit status of the service: %v", err)
+		return
+	}
+	status, err := getPropertyInt32(properties, "ExecMainStatus")
+	if err != nil {
+		log.Debugf("Cannot send the exit status of the service: %v", err)
+		return
+	}
+
+	exitReason, found := execMainCodes[code]
+	if !found {
+		exitReason = "unknown"
+	}
+	exitTags := append(tags[:len(tags):len(tags)], "exit_reason:"+exitReason)
+	if result, err := getPropertyString(properties, "Result"); err == nil {
+		exitTags = append(exitTags, "result:"+result)
+	}
+	// the exit status for processes which exited, the signal number otherwise
+	sender.Gauge("systemd.service.exit_status", float64(status), "", exitTags)
+}
+
+// submitTimerMetrics submits the times of the last and next triggers of a timer, and
+// whether it missed its last scheduled run.
+func (c *SystemdCheck) submitTimerMetrics(sender sender.Sender, conn *dbus.Conn, unit dbus.UnitStatus, tags []string) {
+	properties, err := c.stats.GetUnitTypeProperties(conn, unit.Name, dbusTypeMap[typeTimer])
+	if err != nil {
+		log.Warnf("Error getting detailed properties for unit %s", unit.Name)
+		return
+	}
+	if triggered, err := getPropertyString(properties, "Unit"); err == nil {
+		tags = append(tags[:len(tags):len(tags)], "trigger_unit:"+triggered)
+	}
+
+	nowSec := c.stats.UnixNow()
+	now := nowSec * 1000000
+	if lastTrigger, err := getPropertyUint64(properties, "LastTriggerUSec"); err == nil && lastTrigger != 0 {
+		sender.Gauge("systemd.timer.seconds_since_last_trigger", float64(now-int64(lastTrigger))/1000000, "", tags)
+	}
+
+	// timers have calendar (realtime) and monotonic triggers, the next one is the earliest
+	next := int64(0)
+	if realtime, err := getPropertyUint64(properties, "NextElapseUSecRealtime"); err == nil && realtime != 0 && realtime != unsetProperty {
+		next = int64(realtime)
+	}
+	if monotonic, err := getPropertyUint64(properties, "NextElapseUSecMonotonic"); err == nil && monotonic != 0 && monotonic != unsetProperty {
+		if realtime := now + int64(monotonic) - int64(c.stats.MonotonicNow()); next == 0 || realtime < next {
+			next = realtime
+		}
+	}
+	if next == 0 || unit.ActiveState != unitActiveState {
+		// the timer is stopped or elapsed for good, it has no next run
+		return
+	}
+
+	untilNext := float64(next-now) / 1000000
+	sender.Gauge("systemd.timer.next_trigger", untilNext, "", tags)
+	missed := 0
+	if -untilNext > float64(c.config.instance.TimerGracePeriod) {
+		missed = 1
+	}
+	sender.Gauge("systemd.timer.missed_run", float64(missed), "", tags)
+}
+
 func sendServicePropertyAsGauge(sender sender.Sender, properties map[string]interface{}, service metricConfigItem, tags []string) error {
 	if service.accountingProperty != "" {
 		accounting, err := getPropertyBool(properties, service.accountingProperty)
@@ -430,6 +567,10 @@ func sendServicePropertyAsGauge(sender sender.Sender, properties map[string]inte
 	if err != nil {
 		return fmt.Errorf("error getting property %s: %v", service.propertyName, err)
 	}
+	if value == unsetProperty {
+		log.Debugf("Skip sending metric due to unavailable value. PropertyName=%s, tags: %v", service.propertyName, tags)
+		return nil
+	}
 	sender.Gauge(service.metricName, float64(value), "", tags)
 	return nil
 }
@@ -462,6 +603,18 @@ func getPropertyUint64(properties map[string]interface{}, propertyName string) (
 	return 0, fmt.Errorf("property %s (%T) cannot be converted to uint64", propertyName, prop)
 }
 
+func getPropertyInt32(properties map[string]interface{}, propertyName string) (int32, error) {
+	prop, ok := properties[propertyName]
+	if !ok {
+		return 0, fmt.Errorf("property %s not found", propertyName)
+	}
+	propValue, ok := prop.(int32)
+	if !ok {
+		return 0, fmt.Errorf("property %s (%T) cannot be converted to int32", propertyName, prop)
+	}
+	return propValue, nil
+}
+
 func getPropertyString(properties map[string]interface{}, propertyName string) (string, error) {
 	prop, ok := properties[propertyName]
 	if !ok {
@@ -537,6 +690,10 @@ func (c *SystemdCheck) Configure(integrationConfigDigest uint64, rawInstance int
 		return err
 	}
 
+	if c.config.instance.TimerGracePeriod == 0 {
+		c.config.instance.TimerGracePeriod = defaultTimerGracePeriod
+	}
+
 	if len(c.config.instance.UnitNames) == 0 {
 		return fmt.Errorf("instance config `unit_names` must not be empty")
 	}
@@ -75,6 +75,11 @@ func (s *mockSystemdStats) UnixNow() int64 {
 	return args.Get(0).(int64)
 }
 
+func (s *mockSystemdStats) MonotonicNow() uint64 {
+	args := s.Mock.Called()
+	return args.Get(0).(uint64)
+}
+
 func (s *mockSystemdStats) GetUnitTypeProperties(conn *dbus.Conn, unitName string, unitType string) (map[string]interface{}, error) {
 	args := s.Mock.Called(conn, unitName, unitType)
 	return args.Get(0).(map[string]interface{}), args.Error(1)
@@ -529,6 +534,155 @@ unit_names:
 	mockSender.AssertCalled(t, "Gauge", "systemd.socket.connection_refused_count", mock.Anything, "", tags)
 }
 
+func TestServiceIOAndExitMetrics(t *testing.T) {
+	rawInstanceConfig := []byte(`
+unit_names:
+ - unit1.service
+ - unit2.service
+`)
+
+	stats := createDefaultMockSystemdStats()
+	stats.On("ListUnits", mock.Anything).Return([]dbus.UnitStatus{
+		{Name: "unit1.service", ActiveState: "active", LoadState: "loaded"},
+		{Name: "unit2.service", ActiveState: "failed", LoadState: "loaded"},
+	}, nil)
+	stats.On("UnixNow").Return(int64(1000))
+	stats.On("GetUnitTypeProperties", mock.Anything, mock.Anything, dbusTypeMap[typeUnit]).Return(map[string]interface{}{}, nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "unit1.service", dbusTypeMap[typeService]).Return(getCreatePropertieWithDefaults(map[string]interface{}{
+		"MemoryCurrent":         unsetProperty,
+		"IOAccounting":          true,
+		"IOReadBytes":           uint64(4096),
+		"IOWriteBytes":          uint64(8192),
+		"IOReadOperations":      uint64(1),
+		"IOWriteOperations":     unsetProperty,
+		"ExecMainExitTimestamp": uint64(0),
+		"ExecMainCode":          int32(0),
+		"ExecMainStatus":        int32(0),
+	}), nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "unit2.service", dbusTypeMap[typeService]).Return(getCreatePropertieWithDefaults(map[string]interface{}{
+		"IOAccounting":          false,
+		"IOReadBytes":           uint64(4096),
+		"NRestarts":             uint32(12),
+		"ExecMainExitTimestamp": uint64(900 * 1000 * 1000),
+		"ExecMainCode":          int32(2),
+		"ExecMainStatus":        int32(9),
+		"Result":                "signal",
+	}), nil)
+	stats.On("GetVersion", mock.Anything).Return(systemdVersion)
+
+	check := SystemdCheck{stats: stats}
+	check.Configure(integration.FakeConfigHash, rawInstanceConfig, nil, "test")
+
+	mockSender := mocksender.NewMockSender(check.ID())
+	mockSender.SetupAcceptAll()
+
+	check.Run()
+
+	tags := []string{"unit:unit1.service"}
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.io_read_bytes", float64(4096), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.io_write_bytes", float64(8192), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.io_read_operations", float64(1), "", tags)
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.service.io_write_operations", mock.Anything, "", tags)
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.service.memory_usage", mock.Anything, "", tags)
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.service.exit_status", mock.Anything, "", mock.MatchedBy(func(tags []string) bool {
+		return tags[0] == "unit:unit1.service"
+	}))
+
+	tags = []string{"unit:unit2.service"}
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.service.io_read_bytes", mock.Anything, "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.restart_count", float64(12), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.exit_status", float64(9), "", []string{"unit:unit2.service", "exit_reason:killed", "result:signal"})
+}
+
+func TestServiceExitMetricsUnknownReason(t *testing.T) {
+	mockSender := mocksender.NewMockSender("systemd")
+	mockSender.SetupAcceptAll()
+
+	submitServiceExitMetrics(mockSender, map[string]interface{}{
+		"ExecMainExitTimestamp": uint64(900 * 1000 * 1000),
+		"ExecMainCode":          int32(7),
+		"ExecMainStatus":        int32(1),
+	}, []string{"unit:unit1.service"})
+
+	mockSender.AssertCalled(t, "Gauge", "systemd.service.exit_status", float64(1), "", []string{"unit:unit1.service", "exit_reason:unknown"})
+}
+
+func TestTimerMetrics(t *testing.T) {
+	rawInstanceConfig := []byte(`
+unit_names:
+ - backup.timer
+ - logrotate.timer
+ - boot.timer
+ - stopped.timer
+timer_grace_period: 60
+`)
+
+	const now = 1700000000
+	const sec = 1000 * 1000
+	stats := createDefaultMockSystemdStats()
+	stats.On("ListUnits", mock.Anything).Return([]dbus.UnitStatus{
+		{Name: "backup.timer", ActiveState: "active", LoadState: "loaded"},
+		{Name: "logrotate.timer", ActiveState: "active", LoadState: "loaded"},
+		{Name: "boot.timer", ActiveState: "active", LoadState: "loaded"},
+		{Name: "stopped.timer", ActiveState: "inactive", LoadState: "loaded"},
+	}, nil)
+	stats.On("UnixNow").Return(int64(now))
+	stats.On("MonotonicNow").Return(uint64(500 * sec))
+	stats.On("GetUnitTypeProperties", mock.Anything, mock.Anything, dbusTypeMap[typeUnit]).Return(map[string]interface{}{}, nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "backup.timer", dbusTypeMap[typeTimer]).Return(map[string]interface{}{
+		"Unit":                    "backup.service",
+		"LastTriggerUSec":         uint64((now - 3600) * sec),
+		"NextElapseUSecRealtime":  uint64((now + 120) * sec),
+		"NextElapseUSecMonotonic": uint64(0),
+	}, nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "logrotate.timer", dbusTypeMap[typeTimer]).Return(map[string]interface{}{
+		"Unit":                    "logrotate.service",
+		"LastTriggerUSec":         uint64((now - 90000) * sec),
+		"NextElapseUSecRealtime":  uint64((now - 3600) * sec),
+		"NextElapseUSecMonotonic": uint64(0),
+	}, nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "boot.timer", dbusTypeMap[typeTimer]).Return(map[string]interface{}{
+		"Unit":                    "boot.service",
+		"LastTriggerUSec":         uint64(0),
+		"NextElapseUSecRealtime":  uint64(0),
+		"NextElapseUSecMonotonic": uint64(530 * sec),
+	}, nil)
+	stats.On("GetUnitTypeProperties", mock.Anything, "stopped.timer", dbusTypeMap[typeTimer]).Return(map[string]interface{}{
+		"Unit":                    "stopped.service",
+		"LastTriggerUSec":         uint64((now - 60) * sec),
+		"NextElapseUSecRealtime":  uint64((now - 3600) * sec),
+		"NextElapseUSecMonotonic": uint64(0),
+	}, nil)
+	stats.On("GetVersion", mock.Anything).Return(systemdVersion)
+
+	check := SystemdCheck{stats: stats}
+	assert.NoError(t, check.Configure(integration.FakeConfigHash, rawInstanceConfig, nil, "test"))
+
+	mockSender := mocksender.NewMockSender(check.ID())
+	mockSender.SetupAcceptAll()
+
+	check.Run()
+
+	tags := []string{"unit:backup.timer", "trigger_unit:backup.service"}
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.seconds_since_last_trigger", float64(3600), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.next_trigger", float64(120), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.missed_run", float64(0), "", tags)
+
+	tags = []string{"unit:logrotate.timer", "trigger_unit:logrotate.service"}
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.next_trigger", float64(-3600), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.missed_run", float64(1), "", tags)
+
+	tags = []string{"unit:boot.timer", "trigger_unit:boot.service"}
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.timer.seconds_since_last_trigger", mock.Anything, "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.next_trigger", float64(30), "", tags)
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.missed_run", float64(0), "", tags)
+
+	tags = []string{"unit:stopped.timer", "trigger_unit:stopped.service"}
+	mockSender.AssertCalled(t, "Gauge", "systemd.timer.seconds_since_last_trigger", float64(60), "", tags)
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.timer.next_trigger", mock.Anything, "", tags)
+	mockSender.AssertNotCalled(t, "Gauge", "systemd.timer.missed_run", mock.Anything, "", tags)
+}
+
 func TestSubmitMonitoredServiceMetrics(t *testing.T) {
 	rawInstanceConfig := []byte(`
 unit_names:
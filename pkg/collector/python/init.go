@@ -19,6 +19,7 @@ import (
 	"unsafe"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator"
+	"github.com/DataDog/datadog-agent/pkg/collector/check/stats"
 	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/metrics"
 	"github.com/DataDog/datadog-agent/pkg/tagset"
@@ -494,4 +495,17 @@ func initPymemTelemetry() {
 			prevAlloc = s.alloc
 		}
 	}()
+
+	// Account the allocations of the python interpreter in the check stats
+	stats.RegisterAllocationCounter(func() uint64 {
+		pyDestroyLock.RLock()
+		defer pyDestroyLock.RUnlock()
+
+		if rtloader == nil {
+			return 0
+		}
+		var s C.pymem_stats_t
+		C.get_pymem_stats(rtloader, &s)
+		return uint64(s.alloc)
+	})
 }
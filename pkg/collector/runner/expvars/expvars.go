@@ -121,13 +121,61 @@ func AddCheckStats(
 	mStats checkstats.SenderStats,
 ) {
 
-	var s *checkstats.Stats
-
 	checkStats.statsLock.Lock()
 	defer checkStats.statsLock.Unlock()
 
 	log.Tracef("Adding stats for %s", string(c.ID()))
 
+	getOrCreateCheckStats(c).Add(execTime, err, warnings, mStats)
+}
+
+// AddResourceUsageStats tracks the resources used by a run of a check
+func AddResourceUsageStats(c check.Check, usage checkstats.ResourceUsage) {
+	checkStats.statsLock.Lock()
+	defer checkStats.statsLock.Unlock()
+
+	getOrCreateCheckStats(c).AddResourceUsage(usage)
+}
+
+// AddRunFailuresStats tracks the consecutive failed runs of a check and the
+// number of its scheduled runs to skip as it's backing off
+func AddRunFailuresStats(c check.Check, failures, backoffRuns int, timedOut bool) {
+	checkStats.statsLock.Lock()
+	defer checkStats.statsLock.Unlock()
+
+	getOrCreateCheckStats(c).AddRunFailures(failures, backoffRuns, timedOut)
+}
+
+// AddStuckRunStats adds delta to the number of timed out runs of a check still
+// in progress
+func AddStuckRunStats(c check.Check, delta int) {
+	checkStats.statsLock.Lock()
+	defer checkStats.statsLock.Unlock()
+
+	getOrCreateCheckStats(c).AddStuckRun(delta)
+}
+
+// AddBackoffRunStats tracks a scheduled run of a check skipped as the check is
+// backing off
+func AddBackoffRunStats(c check.Check, remainingRuns int) {
+	checkStats.statsLock.Lock()
+	defer checkStats.statsLock.Unlock()
+
+	getOrCreateCheckStats(c).AddBackoffRun(remainingRuns)
+}
+
+// AddOverlappingRunStats tracks a run of a check which was due while its
+// previous run was still in progress
+func AddOverlappingRunStats(c check.Check, queued bool) {
+	checkStats.statsLock.Lock()
+	defer checkStats.statsLock.Unlock()
+
+	getOrCreateCheckStats(c).AddOverlappingRun(queued)
+}
+
+// getOrCreateCheckStats returns the stats of a check, creating them if needed.
+// checkStats.statsLock must be held.
+func getOrCreateCheckStats(c check.Check) *checkstats.Stats {
 	checkName := checkid.IDToCheckName(c.ID())
 	stats, found := checkStats.stats[checkName]
 	if !found {
@@ -135,13 +183,15 @@ func AddCheckStats(
 		checkStats.stats[checkName] = stats
 	}
 
-	s, found = stats[c.ID()]
+	s, found := stats[c.ID()]
 	if !found {
 		s = checkstats.NewStats(c)
+		if schedule, err := check.GetSchedule(c); err == nil && !schedule.IsDefault() {
+			s.Schedule = schedule.String()
+		}
 		stats[c.ID()] = s
 	}
-
-	s.Add(execTime, err, warnings, mStats)
+	return s
 }
 
 // RemoveCheckStats removes a check from the check stats map
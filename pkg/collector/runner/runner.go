@@ -251,8 +251,13 @@ func (r *Runner) ShouldAddCheckStats(id checkid.ID) bool {
 }
 
 // StopCheck invokes the `Stop` method on a check if it's running. If the check
-// is not running, this is a noop
+// is not running, this is a noop. In both cases, the failed runs and the failure
+// policy of the check are forgotten, as its ID may never be scheduled again.
 func (r *Runner) StopCheck(id checkid.ID) error {
+	// a run in progress records its result once the check is unscheduled, but
+	// the workers forget it then
+	r.checksTracker.DeleteBackoff(id)
+
 	done := make(chan bool)
 
 	stopFunc := func(c check.Check) {
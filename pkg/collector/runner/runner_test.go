@@ -405,6 +405,24 @@ func TestRunnerStopCheck(t *testing.T) {
 	require.Nil(t, err)
 }
 
+func TestRunnerStopCheckDeletesBackoff(t *testing.T) {
+	testSetUp(t)
+
+	r := NewRunner()
+	require.NotNil(t, r)
+	defer r.Stop()
+
+	id := checkid.ID("mycheck:123")
+	r.checksTracker.AddRunResult(id, true, func(int) int { return 2 })
+	skip, _ := r.checksTracker.SkipBackoffRun(id)
+	require.True(t, skip)
+
+	// the backoff of a check isn't kept once it's unscheduled
+	require.Nil(t, r.StopCheck(id))
+	skip, _ = r.checksTracker.SkipBackoffRun(id)
+	assert.False(t, skip)
+}
+
 func TestRunnerScheduler(t *testing.T) {
 	testSetUp(t)
 	config.Datadog.Set("check_runners", "3")
@@ -0,0 +1,93 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tracker
+
+import (
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
+)
+
+// backoff holds the consecutive failed runs of a check and the number of its
+// scheduled runs left to skip
+type backoff struct {
+	failures    int
+	skippedRuns int
+}
+
+// failurePolicyOptions holds the failure policy options of a check instance,
+// parsed once
+type failurePolicyOptions struct {
+	options check.FailurePolicyOptions
+	err     error
+}
+
+// FailurePolicy returns the failure policy of a check. The failure policy
+// options of its instance are parsed on the first call only, while the agent
+// options they override are read on every call, as they can be reloaded.
+func (t *RunningChecksTracker) FailurePolicy(c check.Check) (check.FailurePolicy, error) {
+	t.accessLock.Lock()
+	parsed, found := t.failurePolicies[c.ID()]
+	if !found {
+		parsed = &failurePolicyOptions{}
+		parsed.options, parsed.err = check.GetFailurePolicyOptions(c)
+		t.failurePolicies[c.ID()] = parsed
+	}
+	t.accessLock.Unlock()
+
+	policy, err := parsed.options.FailurePolicy()
+	if parsed.err != nil {
+		return policy, parsed.err
+	}
+	return policy, err
+}
+
+// AddRunResult records the result of a run of a check. backoffRuns returns the
+// number of scheduled runs to skip after the given number of consecutive failed
+// runs. The method returns the number of consecutive failed runs of the check and
+// the number of its scheduled runs to skip.
+func (t *RunningChecksTracker) AddRunResult(id checkid.ID, failed bool, backoffRuns func(failures int) int) (int, int) {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	if !failed {
+		delete(t.backoffs, id)
+		return 0, 0
+	}
+
+	b, found := t.backoffs[id]
+	if !found {
+		b = &backoff{}
+		t.backoffs[id] = b
+	}
+	b.failures++
+	b.skippedRuns = backoffRuns(b.failures)
+	return b.failures, b.skippedRuns
+}
+
+// SkipBackoffRun returns true if a scheduled run of a check must be skipped
+// because the check is backing off, along with the number of its scheduled runs
+// left to skip after this one.
+func (t *RunningChecksTracker) SkipBackoffRun(id checkid.ID) (bool, int) {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	b, found := t.backoffs[id]
+	if !found || b.skippedRuns == 0 {
+		return false, 0
+	}
+	b.skippedRuns--
+	return true, b.skippedRuns
+}
+
+// DeleteBackoff forgets the failed runs and the failure policy of a check, when
+// it's unscheduled
+func (t *RunningChecksTracker) DeleteBackoff(id checkid.ID) {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	delete(t.backoffs, id)
+	delete(t.failurePolicies, id)
+}
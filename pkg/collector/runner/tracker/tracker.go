@@ -22,14 +22,22 @@ type withRunningChecksFunc func(map[checkid.ID]check.Check)
 // RunningChecksTracker is an object that keeps a thread-safe track of
 // all the running checks
 type RunningChecksTracker struct {
-	runningChecks map[checkid.ID]check.Check // The list of checks running
-	accessLock    sync.RWMutex               // To control races on runningChecks
+	runningChecks   map[checkid.ID]check.Check           // The list of checks running
+	queuedRuns      map[checkid.ID]struct{}              // The running checks to run again once they complete
+	backoffs        map[checkid.ID]*backoff              // The checks whose last runs failed
+	failurePolicies map[checkid.ID]*failurePolicyOptions // The parsed failure policy options of the checks
+	stuckRuns       map[checkid.ID]int                   // The timed out runs of the checks still in progress
+	accessLock      sync.RWMutex                         // To control races on the maps above
 }
 
 // NewRunningChecksTracker is a contructor for a RunningChecksTracker
 func NewRunningChecksTracker() *RunningChecksTracker {
 	return &RunningChecksTracker{
-		runningChecks: make(map[checkid.ID]check.Check),
+		runningChecks:   make(map[checkid.ID]check.Check),
+		queuedRuns:      make(map[checkid.ID]struct{}),
+		backoffs:        make(map[checkid.ID]*backoff),
+		failurePolicies: make(map[checkid.ID]*failurePolicyOptions),
+		stuckRuns:       make(map[checkid.ID]int),
 	}
 }
 
@@ -57,12 +65,47 @@ func (t *RunningChecksTracker) AddCheck(check check.Check) bool {
 	return true
 }
 
+// AddOrQueueCheck adds a check to the list of running checks if the check
+// isn't already added, like AddCheck. Otherwise, it queues a run of the check,
+// to be returned by DeleteOrDequeueCheck once the current run completes. At
+// most one run is queued per check. Method returns a boolean if the addition
+// was successful.
+func (t *RunningChecksTracker) AddOrQueueCheck(check check.Check) bool {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	if _, found := t.runningChecks[check.ID()]; found {
+		t.queuedRuns[check.ID()] = struct{}{}
+		return false
+	}
+
+	t.runningChecks[check.ID()] = check
+	return true
+}
+
 // DeleteCheck removes a check from the list of running checks
 func (t *RunningChecksTracker) DeleteCheck(id checkid.ID) {
 	t.accessLock.Lock()
 	defer t.accessLock.Unlock()
 
 	delete(t.runningChecks, id)
+	delete(t.queuedRuns, id)
+}
+
+// DeleteOrDequeueCheck removes a check from the list of running checks, unless
+// a run of the check was queued by AddOrQueueCheck. In that case, the check is
+// kept in the list, the queued run is removed and the method returns true.
+func (t *RunningChecksTracker) DeleteOrDequeueCheck(id checkid.ID) bool {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	if _, found := t.queuedRuns[id]; found {
+		delete(t.queuedRuns, id)
+		return true
+	}
+
+	delete(t.runningChecks, id)
+	return false
 }
 
 // WithRunningChecks takes in a function to execute in the context of a locked
@@ -100,3 +143,23 @@ func (t *RunningChecksTracker) WithCheck(id checkid.ID, closureFunc withCheckFun
 
 	return true
 }
+
+// AddStuckRun adds delta to the number of timed out runs of a check still in
+// progress
+func (t *RunningChecksTracker) AddStuckRun(id checkid.ID, delta int) {
+	t.accessLock.Lock()
+	defer t.accessLock.Unlock()
+
+	t.stuckRuns[id] += delta
+	if t.stuckRuns[id] <= 0 {
+		delete(t.stuckRuns, id)
+	}
+}
+
+// IsStuck returns true if a timed out run of a check is still in progress
+func (t *RunningChecksTracker) IsStuck(id checkid.ID) bool {
+	t.accessLock.RLock()
+	defer t.accessLock.RUnlock()
+
+	return t.stuckRuns[id] > 0
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -16,15 +17,18 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
 	"github.com/DataDog/datadog-agent/pkg/collector/check/stats"
+	"github.com/DataDog/datadog-agent/pkg/config"
 )
 
 type testCheck struct {
 	stats.StubCheck
-	id string
+	id       string
+	instance string
 }
 
-func (c *testCheck) ID() checkid.ID { return checkid.ID(c.id) }
-func (c *testCheck) String() string { return checkid.IDToCheckName(c.ID()) }
+func (c *testCheck) ID() checkid.ID         { return checkid.ID(c.id) }
+func (c *testCheck) String() string         { return checkid.IDToCheckName(c.ID()) }
+func (c *testCheck) InstanceConfig() string { return c.instance }
 
 func newTestCheck(id string) *testCheck {
 	return &testCheck{id: id}
@@ -213,3 +217,120 @@ func TestRunningChecksTrackerWithCheck(t *testing.T) {
 
 	wg.Wait()
 }
+
+func TestRunningChecksTrackerQueuedRuns(t *testing.T) {
+	tracker := NewRunningChecksTracker()
+	testCheck := newTestCheck("mycheck")
+
+	require.True(t, tracker.AddOrQueueCheck(testCheck))
+	// the check is running, the runs are queued and merged
+	require.False(t, tracker.AddOrQueueCheck(testCheck))
+	require.False(t, tracker.AddOrQueueCheck(testCheck))
+
+	// the queued run keeps the check running
+	assert.True(t, tracker.DeleteOrDequeueCheck(testCheck.ID()))
+	_, found := tracker.Check(testCheck.ID())
+	assert.True(t, found)
+
+	assert.False(t, tracker.DeleteOrDequeueCheck(testCheck.ID()))
+	_, found = tracker.Check(testCheck.ID())
+	assert.False(t, found)
+
+	// DeleteCheck drops the queued runs
+	require.True(t, tracker.AddOrQueueCheck(testCheck))
+	require.False(t, tracker.AddOrQueueCheck(testCheck))
+	tracker.DeleteCheck(testCheck.ID())
+	require.True(t, tracker.AddOrQueueCheck(testCheck))
+	assert.False(t, tracker.DeleteOrDequeueCheck(testCheck.ID()))
+}
+
+func TestRunningChecksTrackerBackoff(t *testing.T) {
+	tracker := NewRunningChecksTracker()
+	testCheck := newTestCheck("mycheck")
+	backoffRuns := func(failures int) int {
+		if failures < 2 {
+			return 0
+		}
+		return failures
+	}
+
+	skip, _ := tracker.SkipBackoffRun(testCheck.ID())
+	assert.False(t, skip)
+
+	failures, runs := tracker.AddRunResult(testCheck.ID(), true, backoffRuns)
+	assert.Equal(t, 1, failures)
+	assert.Equal(t, 0, runs)
+	skip, _ = tracker.SkipBackoffRun(testCheck.ID())
+	assert.False(t, skip)
+
+	failures, runs = tracker.AddRunResult(testCheck.ID(), true, backoffRuns)
+	assert.Equal(t, 2, failures)
+	assert.Equal(t, 2, runs)
+	skip, remaining := tracker.SkipBackoffRun(testCheck.ID())
+	assert.True(t, skip)
+	assert.Equal(t, 1, remaining)
+	skip, remaining = tracker.SkipBackoffRun(testCheck.ID())
+	assert.True(t, skip)
+	assert.Equal(t, 0, remaining)
+	skip, _ = tracker.SkipBackoffRun(testCheck.ID())
+	assert.False(t, skip)
+
+	// a successful run resets the failures
+	failures, runs = tracker.AddRunResult(testCheck.ID(), false, backoffRuns)
+	assert.Equal(t, 0, failures)
+	assert.Equal(t, 0, runs)
+	failures, _ = tracker.AddRunResult(testCheck.ID(), true, backoffRuns)
+	assert.Equal(t, 1, failures)
+
+	tracker.DeleteBackoff(testCheck.ID())
+	failures, _ = tracker.AddRunResult(testCheck.ID(), true, backoffRuns)
+	assert.Equal(t, 1, failures)
+}
+
+func TestRunningChecksTrackerFailurePolicy(t *testing.T) {
+	tracker := NewRunningChecksTracker()
+	testCheck := newTestCheck("mycheck")
+	testCheck.instance = "run_timeout: 10"
+
+	policy, err := tracker.FailurePolicy(testCheck)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, policy.RunTimeout)
+	assert.Equal(t, 0, policy.BackoffFailures)
+
+	// the instance options are parsed once, the agent options on every call
+	testCheck.instance = "run_timeout: 20"
+	config.Datadog.Set("check_backoff_failures", 3)
+	defer config.Datadog.Set("check_backoff_failures", 0)
+	policy, err = tracker.FailurePolicy(testCheck)
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, policy.RunTimeout)
+	assert.Equal(t, 3, policy.BackoffFailures)
+
+	tracker.DeleteBackoff(testCheck.ID())
+	policy, err = tracker.FailurePolicy(testCheck)
+	require.NoError(t, err)
+	assert.Equal(t, 20*time.Second, policy.RunTimeout)
+
+	invalidCheck := newTestCheck("invalid")
+	invalidCheck.instance = "run_timeout: [1"
+	policy, err = tracker.FailurePolicy(invalidCheck)
+	assert.Error(t, err)
+	assert.Equal(t, time.Duration(0), policy.RunTimeout)
+	assert.Equal(t, 3, policy.BackoffFailures)
+}
+
+func TestRunningChecksTrackerStuckRuns(t *testing.T) {
+	tracker := NewRunningChecksTracker()
+	id := newTestCheck("mycheck").ID()
+
+	assert.False(t, tracker.IsStuck(id))
+	tracker.AddStuckRun(id, 1)
+	tracker.AddStuckRun(id, 1)
+	assert.True(t, tracker.IsStuck(id))
+
+	// the check is stuck as long as one of its timed out runs is in progress
+	tracker.AddStuckRun(id, -1)
+	assert.True(t, tracker.IsStuck(id))
+	tracker.AddStuckRun(id, -1)
+	assert.False(t, tracker.IsStuck(id))
+}
@@ -0,0 +1,72 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package scheduler
+
+import (
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/DataDog/datadog-agent/pkg/collector/check"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// cronJob schedules a check following a cron expression, instead of its
+// collection interval
+type cronJob struct {
+	check    check.Check
+	schedule cron.Schedule
+	delay    time.Duration // offset and jitter added to the times of the schedule
+	stop     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+func newCronJob(c check.Check, schedule cron.Schedule, delay time.Duration) *cronJob {
+	return &cronJob{
+		check:    c,
+		schedule: schedule,
+		delay:    delay,
+		stop:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+}
+
+// run posts the check to the execution pipeline at the times of the schedule.
+// Not blocking, runs in a new goroutine.
+func (j *cronJob) run(s *Scheduler) {
+	go func() {
+		defer close(j.stopped)
+		for {
+			next := j.schedule.Next(time.Now()).Add(j.delay)
+			log.Tracef("Next run of check %s at %v", j.check.ID(), next)
+
+			timer := time.NewTimer(time.Until(next))
+			select {
+			case <-timer.C:
+			case <-j.stop:
+				timer.Stop()
+				return
+			}
+
+			select {
+			// blocking, we'll be here as long as it takes
+			case s.checksPipe <- j.check:
+			case <-j.stop:
+				return
+			}
+		}
+	}()
+}
+
+// cancel stops the job and blocks until it's stopped
+func (j *cronJob) cancel() {
+	j.stopOnce.Do(func() {
+		close(j.stop)
+	})
+	<-j.stopped
+}
@@ -114,11 +114,19 @@ func newJobQueue(interval time.Duration) *jobQueue {
 	return jq
 }
 
-// addJob is a convenience method to add a check to a queue
-func (jq *jobQueue) addJob(c check.Check) {
+// addJob is a convenience method to add a check to a queue. A non-zero delay
+// puts the check in the bucket processed after that delay, modulo the interval
+// of the queue.
+func (jq *jobQueue) addJob(c check.Check, delay time.Duration) {
 	jq.mu.Lock()
 	defer jq.mu.Unlock()
 
+	if delay > 0 {
+		idx := (jq.currentBucketIdx + uint(delay/time.Second)) % uint(len(jq.buckets))
+		jq.buckets[idx].addJob(c)
+		return
+	}
+
 	// Checks scheduled to buckets scheduled with sparse round-robin
 	jq.buckets[jq.schedulingBucketIdx].addJob(c)
 	jq.schedulingBucketIdx = (jq.schedulingBucketIdx + jq.sparseStep) % uint(len(jq.buckets))
@@ -8,9 +8,12 @@ package scheduler
 import (
 	"expvar"
 	"fmt"
+	"math/rand"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/robfig/cron/v3"
 	"go.uber.org/atomic"
 
 	"github.com/DataDog/datadog-agent/pkg/telemetry"
@@ -30,6 +33,9 @@ var (
 		[]string{"check_name"}, "How many checks are currently tracked by the scheduler")
 	tlmQueuesCount = telemetry.NewCounter("scheduler", "queues_count",
 		nil, "How many queues were opened")
+
+	// checkSchedule is check.GetSchedule, as Enter's argument shadows the check package
+	checkSchedule = check.GetSchedule
 )
 
 func init() {
@@ -51,7 +57,8 @@ type Scheduler struct {
 	mu               sync.Mutex                  // To protect critical sections in struct's fields
 
 	checkToQueue map[checkid.ID]*jobQueue // Keep track of what is the queue for any Check
-	// To protect checkToQueue. Using mu would create a deadlock when stopping the Scheduler. 'jobQueue' is calling
+	cronJobs     map[checkid.ID]*cronJob  // Checks scheduled with a cron expression instead of a queue
+	// To protect checkToQueue and cronJobs. Using mu would create a deadlock when stopping the Scheduler. 'jobQueue' is calling
 	// 'IsCheckScheduled' right when then 'Stop' function is called and mu is already lock. for this reason we have
 	// to lock: one for the Scheduler and a dedicated one for the 'IsCheckScheduled' method. This way 'jobQueue' and
 	// metadata provider can call 'IsCheckScheduled' without creating a deadlock.
@@ -70,6 +77,7 @@ func NewScheduler(checksPipe chan<- check.Check) *Scheduler {
 		started:          make(chan bool),
 		jobQueues:        make(map[time.Duration]*jobQueue),
 		checkToQueue:     make(map[checkid.ID]*jobQueue),
+		cronJobs:         make(map[checkid.ID]*cronJob),
 		tlmTrackedChecks: make(map[checkid.ID]string),
 		running:          atomic.NewBool(false),
 		cancelOneTime:    make(chan bool),
@@ -77,7 +85,8 @@ func NewScheduler(checksPipe chan<- check.Check) *Scheduler {
 	}
 }
 
-// Enter schedules a `Check`s for execution accordingly to the `Check.Interval()` value.
+// Enter schedules a `Check`s for execution accordingly to the `Check.Interval()` value,
+// or to the cron expression of its `schedule` option.
 // If the interval is 0, the check is supposed to run only once.
 func (s *Scheduler) Enter(check check.Check) error {
 	// enqueue immediately if this is a one-time schedule
@@ -90,6 +99,19 @@ func (s *Scheduler) Enter(check check.Check) error {
 		return fmt.Errorf("schedule interval must be greater than %v or 0", minAllowedInterval)
 	}
 
+	schedule, err := checkSchedule(check)
+	if err != nil {
+		return fmt.Errorf("invalid schedule for check %s: %s", check.ID(), err)
+	}
+	delay := schedule.Offset
+	if schedule.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(schedule.Jitter/time.Second)+1)) * time.Second
+	}
+
+	if schedule.Cron != "" {
+		return s.enterCron(check, schedule.Cron, delay)
+	}
+
 	log.Infof("Scheduling check %s with an interval of %v", check.ID(), check.Interval())
 
 	// sync when accessing `jobQueues` and `check2queue`
@@ -104,13 +126,54 @@ func (s *Scheduler) Enter(check check.Check) error {
 		}
 		schedulerQueuesCount.Add(1)
 	}
-	s.jobQueues[check.Interval()].addJob(check)
+	s.jobQueues[check.Interval()].addJob(check, delay)
 
 	// map each check to the Job Queue it was assigned to
 	s.checkToQueueMutex.Lock()
 	s.checkToQueue[check.ID()] = s.jobQueues[check.Interval()]
 	s.checkToQueueMutex.Unlock()
 
+	s.trackCheck(check)
+	return nil
+}
+
+// enterCron schedules a check following a cron expression
+func (s *Scheduler) enterCron(c check.Check, expression string, delay time.Duration) error {
+	schedule, err := cron.ParseStandard(expression)
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q for check %s: %s", expression, c.ID(), err)
+	}
+
+	// standard expressions run every minute at most, but @every takes any
+	// duration, rounded up to a second by the parser
+	if every := strings.TrimPrefix(expression, "@every "); every != expression {
+		if period, err := time.ParseDuration(every); err == nil && period < minAllowedInterval {
+			return fmt.Errorf("invalid schedule %q for check %s: the period must be at least %v", expression, c.ID(), minAllowedInterval)
+		}
+	}
+
+	log.Infof("Scheduling check %s with the schedule %q", c.ID(), expression)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job := newCronJob(c, schedule, delay)
+	s.checkToQueueMutex.Lock()
+	previous, found := s.cronJobs[c.ID()]
+	s.cronJobs[c.ID()] = job
+	s.checkToQueueMutex.Unlock()
+
+	if found {
+		previous.cancel()
+	} else {
+		s.trackCheck(c)
+	}
+	job.run(s)
+	return nil
+}
+
+// trackCheck updates the telemetry and expvars after a check is entered
+func (s *Scheduler) trackCheck(check check.Check) {
 	schedulerChecksEntered.Add(1)
 	if check.IsTelemetryEnabled() {
 		checkName := check.String()
@@ -118,7 +181,6 @@ func (s *Scheduler) Enter(check check.Check) error {
 		tlmChecksEntered.Inc(checkName)
 	}
 	schedulerExpvars.Set("Queues", expvar.Func(expQueues(s)))
-	return nil
 }
 
 // Cancel remove a Check from the scheduled queue. If the check is not
@@ -131,16 +193,21 @@ func (s *Scheduler) Cancel(id checkid.ID) error {
 
 	log.Infof("Unscheduling check %s", string(id))
 
-	if _, ok := s.checkToQueue[id]; !ok {
-		return nil
-	}
+	if job, ok := s.cronJobs[id]; ok {
+		job.cancel()
+		delete(s.cronJobs, id)
+	} else {
+		if _, ok := s.checkToQueue[id]; !ok {
+			return nil
+		}
 
-	// remove it from the queue
-	err := s.checkToQueue[id].removeJob(id)
-	if err != nil {
-		return fmt.Errorf("unable to remove the Job from the queue: %s", err)
+		// remove it from the queue
+		err := s.checkToQueue[id].removeJob(id)
+		if err != nil {
+			return fmt.Errorf("unable to remove the Job from the queue: %s", err)
+		}
+		delete(s.checkToQueue, id)
 	}
-	delete(s.checkToQueue, id)
 
 	schedulerChecksEntered.Add(-1)
 	if checkName, ok := s.tlmTrackedChecks[id]; ok {
@@ -216,6 +283,9 @@ func (s *Scheduler) IsCheckScheduled(id checkid.ID) bool {
 	s.checkToQueueMutex.RLock()
 	defer s.checkToQueueMutex.RUnlock()
 
+	if _, found := s.cronJobs[id]; found {
+		return true
+	}
 	_, found := s.checkToQueue[id]
 	return found
 }
@@ -237,6 +307,13 @@ func (s *Scheduler) stopQueues() {
 			q.running = false
 		}
 	}
+
+	s.checkToQueueMutex.RLock()
+	defer s.checkToQueueMutex.RUnlock()
+	for id, job := range s.cronJobs {
+		job.cancel()
+		log.Debugf("Stopped the schedule of check %s", id)
+	}
 }
 
 // startQueues loads the timer for each queue
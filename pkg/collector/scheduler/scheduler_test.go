@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	"github.com/DataDog/datadog-agent/pkg/collector/check/stats"
@@ -18,10 +19,12 @@ import (
 // FIXTURE
 type TestCheck struct {
 	stats.StubCheck
-	intl time.Duration
+	intl     time.Duration
+	instance string
 }
 
 func (c *TestCheck) Interval() time.Duration { return c.intl }
+func (c *TestCheck) InstanceConfig() string  { return c.instance }
 
 var initialMinAllowedInterval = minAllowedInterval
 
@@ -198,3 +201,60 @@ func TestStopOneTimeSchedule(t *testing.T) {
 	// sleep to make the runtime schedule the hanging goroutines, if there are any
 	time.Sleep(time.Millisecond)
 }
+
+func TestEnterWithOffset(t *testing.T) {
+	s := getScheduler()
+	defer s.Stop()
+
+	// the check runs 5 seconds after entering the queue
+	err := s.Enter(&TestCheck{intl: 20 * time.Second, instance: "schedule_offset: 5"})
+	require.NoError(t, err)
+	assert.Len(t, s.jobQueues[20*time.Second].buckets[5].jobs, 1)
+
+	// the jitter delays the check by up to 3 seconds more
+	err = s.Enter(&TestCheck{intl: 20 * time.Second, instance: "schedule_offset: 5\nschedule_jitter: 3"})
+	require.NoError(t, err)
+	jobs := 0
+	for idx := 5; idx <= 8; idx++ {
+		jobs += len(s.jobQueues[20*time.Second].buckets[idx].jobs)
+	}
+	assert.Equal(t, 2, jobs)
+
+	// the offset wraps around the interval
+	err = s.Enter(&TestCheck{intl: 10 * time.Second, instance: "schedule_offset: 25"})
+	require.NoError(t, err)
+	assert.Len(t, s.jobQueues[10*time.Second].buckets[5].jobs, 1)
+
+	err = s.Enter(&TestCheck{intl: 20 * time.Second, instance: "overlap_policy: wait"})
+	assert.Error(t, err)
+}
+
+func TestEnterCron(t *testing.T) {
+	ch := make(chan check.Check)
+	s := NewScheduler(ch)
+	defer s.Stop()
+
+	err := s.Enter(&TestCheck{intl: 15 * time.Second, instance: "schedule: 61 * * * *"})
+	assert.Error(t, err)
+
+	// the period can't be shorter than the minimum interval, one second
+	err = s.Enter(&TestCheck{intl: 15 * time.Second, instance: "schedule: '@every 500ms'"})
+	assert.EqualError(t, err, `invalid schedule "@every 500ms" for check StubCheck: the period must be at least 1s`)
+
+	// a period of one second is allowed, like an interval of one second
+	c := &TestCheck{intl: 15 * time.Second, instance: "schedule: '@every 1s'"}
+	require.NoError(t, s.Enter(c))
+	assert.Len(t, s.jobQueues, 0)
+	assert.True(t, s.IsCheckScheduled(c.ID()))
+
+	select {
+	case scheduled := <-ch:
+		assert.Equal(t, c, scheduled)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "the check wasn't scheduled")
+	}
+
+	require.NoError(t, s.Cancel(c.ID()))
+	assert.False(t, s.IsCheckScheduled(c.ID()))
+	assert.Len(t, s.cronJobs, 0)
+}
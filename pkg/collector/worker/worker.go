@@ -14,6 +14,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/collector/check"
 	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
+	checkstats "github.com/DataDog/datadog-agent/pkg/collector/check/stats"
 	"github.com/DataDog/datadog-agent/pkg/collector/runner/expvars"
 	"github.com/DataDog/datadog-agent/pkg/collector/runner/tracker"
 	"github.com/DataDog/datadog-agent/pkg/config"
@@ -119,84 +120,244 @@ func (w *Worker) Run() {
 
 	for check := range w.pendingChecksChan {
 		checkLogger := CheckLogger{Check: check}
-		longRunning := check.Interval() == 0
 
 		// Add check to tracker if it's not already running
-		if !w.checksTracker.AddCheck(check) {
-			checkLogger.Debug("Check is already running, skipping execution...")
+		if !w.addCheck(check, checkLogger) {
 			continue
 		}
 
-		checkStartTime := time.Now()
+		for {
+			if !w.skipBackoffRun(check, checkLogger) {
+				if timedOut := w.runCheck(check, checkLogger, utilizationTracker); timedOut {
+					// The check is removed from the running list once its run
+					// completes
+					break
+				}
+			}
+
+			// Remove the check from the running list, unless it was queued
+			// again while it was running
+			if !w.checksTracker.DeleteOrDequeueCheck(check.ID()) {
+				break
+			}
+			checkLogger.Debug("Running the check again, as it was scheduled while it was running...")
+		}
+	}
+
+	log.Debugf("Runner %d, worker %d: Finished processing checks.", w.runnerID, w.ID)
+}
+
+// addCheck adds a check to the tracker, unless it's already running. In that
+// case, the run is either queued or skipped, according to the overlap policy of
+// the check, and the method returns false.
+func (w *Worker) addCheck(c check.Check, checkLogger CheckLogger) bool {
+	if w.checksTracker.AddCheck(c) {
+		return true
+	}
+
+	queued := false
+	if w.checksTracker.IsStuck(c.ID()) {
+		// don't queue runs behind a run which may never complete
+		checkLogger.Debug("Check run is still in progress past its run timeout, skipping execution...")
+	} else if schedule, err := check.GetSchedule(c); err == nil && schedule.OverlapPolicy == check.OverlapPolicyQueue {
+		if w.checksTracker.AddOrQueueCheck(c) {
+			// the previous run completed in the meantime
+			return true
+		}
+		queued = true
+		checkLogger.Debug("Check is already running, queueing execution...")
+	} else {
+		checkLogger.Debug("Check is already running, skipping execution...")
+	}
+
+	if w.shouldAddCheckStatsFunc(c.ID()) {
+		expvars.AddOverlappingRunStats(c, queued)
+	}
+	return false
+}
+
+// skipBackoffRun returns true if the run of a check must be skipped because the
+// check is backing off after repeated failures
+func (w *Worker) skipBackoffRun(c check.Check, checkLogger CheckLogger) bool {
+	skip, remainingRuns := w.checksTracker.SkipBackoffRun(c.ID())
+	if !skip {
+		return false
+	}
+
+	checkLogger.Debug(fmt.Sprintf("Check is backing off after repeated failures, skipping execution (%d more runs to skip)...", remainingRuns))
+	if w.shouldAddCheckStatsFunc(c.ID()) {
+		expvars.AddBackoffRunStats(c, remainingRuns)
+	}
+	return true
+}
+
+// failurePolicy returns the failure policy of a check, which doesn't apply to
+// long running checks
+func (w *Worker) failurePolicy(c check.Check, checkLogger CheckLogger, longRunning bool) check.FailurePolicy {
+	if longRunning {
+		return check.FailurePolicy{}
+	}
+
+	failurePolicy, err := w.checksTracker.FailurePolicy(c)
+	if err != nil {
+		checkLogger.Debug(fmt.Sprintf("Ignoring the failure policy of the check: %v", err))
+	}
+	return failurePolicy
+}
 
-		checkLogger.CheckStarted()
+// runCheck runs a check and publishes the statistics of the run. It returns true
+// if the run timed out, in which case the check is removed from the running list
+// once the run completes, in the background.
+func (w *Worker) runCheck(check check.Check, checkLogger CheckLogger, utilizationTracker *UtilizationTracker) bool {
+	longRunning := check.Interval() == 0
 
-		expvars.AddRunningCheckCount(1)
-		expvars.SetRunningStats(check.ID(), checkStartTime)
+	failurePolicy := w.failurePolicy(check, checkLogger, longRunning)
 
-		utilizationTracker.CheckStarted()
+	checkStartTime := time.Now()
 
-		// Run the check
-		var checkErr error
+	checkLogger.CheckStarted()
+
+	expvars.AddRunningCheckCount(1)
+	expvars.SetRunningStats(check.ID(), checkStartTime)
+
+	utilizationTracker.CheckStarted()
+
+	// Run the check, measuring the resources it uses unless it's long running,
+	// as it would keep its thread locked
+	var checkErr error
+	var resourceUsage checkstats.ResourceUsage
+	timedOut := false
+	if longRunning {
+		checkErr = check.Run()
+	} else if failurePolicy.RunTimeout == 0 {
+		meter := checkstats.StartResourceUsageMeter()
 		checkErr = check.Run()
+		resourceUsage = meter.Stop()
+	} else {
+		resourceUsage, timedOut, checkErr = w.runWithTimeout(check, checkLogger, failurePolicy.RunTimeout)
+	}
 
-		utilizationTracker.CheckFinished()
+	utilizationTracker.CheckFinished()
 
-		expvars.DeleteRunningStats(check.ID())
+	expvars.DeleteRunningStats(check.ID())
 
-		checkWarnings := check.GetWarnings()
+	checkWarnings := check.GetWarnings()
 
-		// Use the default sender for the service checks
-		sender, err := w.getDefaultSenderFunc()
-		if err != nil {
-			log.Errorf("Error getting default sender: %v. Not sending status check for %s", err, check)
-		}
-		serviceCheckTags := []string{fmt.Sprintf("check:%s", check.String()), "dd_enable_check_intake:true"}
-		serviceCheckStatus := servicecheck.ServiceCheckOK
+	// Use the default sender for the service checks
+	sender, err := w.getDefaultSenderFunc()
+	if err != nil {
+		log.Errorf("Error getting default sender: %v. Not sending status check for %s", err, check)
+	}
+	serviceCheckTags := []string{fmt.Sprintf("check:%s", check.String()), "dd_enable_check_intake:true"}
+	serviceCheckStatus := servicecheck.ServiceCheckOK
 
-		hname, _ := hostname.Get(context.TODO())
+	hname, _ := hostname.Get(context.TODO())
 
-		if len(checkWarnings) != 0 {
-			expvars.AddWarningsCount(len(checkWarnings))
-			serviceCheckStatus = servicecheck.ServiceCheckWarning
+	if len(checkWarnings) != 0 {
+		expvars.AddWarningsCount(len(checkWarnings))
+		serviceCheckStatus = servicecheck.ServiceCheckWarning
+	}
+
+	if checkErr != nil {
+		checkLogger.Error(checkErr)
+		expvars.AddErrorsCount(1)
+		serviceCheckStatus = servicecheck.ServiceCheckCritical
+	}
+
+	if sender != nil && !longRunning {
+		if config.Datadog.GetBool("integration_check_status_enabled") {
+			sender.ServiceCheck(serviceCheckStatusKey, serviceCheckStatus, hname, serviceCheckTags, "")
 		}
+		// FIXME(remy): this `Commit()` should be part of the `if` above, we keep
+		// it here for now to make sure it's not breaking any historical behavior
+		// with the shared default sender.
+		sender.Commit()
+	}
 
-		if checkErr != nil {
-			checkLogger.Error(checkErr)
-			expvars.AddErrorsCount(1)
-			serviceCheckStatus = servicecheck.ServiceCheckCritical
+	// Back off after repeated failures
+	var failures, backoffRuns int
+	if !longRunning {
+		failures, backoffRuns = w.checksTracker.AddRunResult(check.ID(), checkErr != nil, func(failures int) int {
+			return failurePolicy.BackoffRuns(check.Interval(), failures)
+		})
+		if failures == failurePolicy.BackoffFailures && backoffRuns > 0 {
+			checkLogger.Warn(fmt.Sprintf("Check failed %d consecutive times, backing off until it succeeds", failures))
 		}
+	}
 
-		if sender != nil && !longRunning {
-			if config.Datadog.GetBool("integration_check_status_enabled") {
-				sender.ServiceCheck(serviceCheckStatusKey, serviceCheckStatus, hname, serviceCheckTags, "")
+	// Publish statistics about this run
+	expvars.AddRunningCheckCount(-1)
+	expvars.AddRunsCount(1)
+
+	if !longRunning || len(checkWarnings) != 0 || checkErr != nil {
+		// If the scheduler isn't assigned (it should), just add stats
+		// otherwise only do so if the check is in the scheduler
+		if w.shouldAddCheckStatsFunc(check.ID()) {
+			sStats, _ := check.GetSenderStats()
+			expvars.AddCheckStats(check, time.Since(checkStartTime), checkErr, checkWarnings, sStats)
+			if !longRunning {
+				expvars.AddResourceUsageStats(check, resourceUsage)
+				expvars.AddRunFailuresStats(check, failures, backoffRuns, timedOut)
 			}
-			// FIXME(remy): this `Commit()` should be part of the `if` above, we keep
-			// it here for now to make sure it's not breaking any historical behavior
-			// with the shared default sender.
-			sender.Commit()
+		} else {
+			w.checksTracker.DeleteBackoff(check.ID())
 		}
+	}
 
-		// Remove the check from the running list
-		w.checksTracker.DeleteCheck(check.ID())
+	checkLogger.CheckFinished()
 
-		// Publish statistics about this run
-		expvars.AddRunningCheckCount(-1)
-		expvars.AddRunsCount(1)
+	return timedOut
+}
 
-		if !longRunning || len(checkWarnings) != 0 || checkErr != nil {
-			// If the scheduler isn't assigned (it should), just add stats
-			// otherwise only do so if the check is in the scheduler
-			if w.shouldAddCheckStatsFunc(check.ID()) {
-				sStats, _ := check.GetSenderStats()
-				expvars.AddCheckStats(check, time.Since(checkStartTime), checkErr, checkWarnings, sStats)
-			}
-		}
+// runResult is the result of a check run
+type runResult struct {
+	resourceUsage checkstats.ResourceUsage
+	err           error
+}
+
+// runWithTimeout runs a check, measuring the resources it uses, and returns when
+// the run completes or when the timeout elapses. In the latter case, the run
+// keeps going in the background, as neither Go nor Python checks can be
+// cancelled, and the check is reported as stuck until it completes. The check
+// stays in the running list until then, so that it never runs twice at the same
+// time and its scheduled runs are skipped. A run which never completes holds its
+// goroutine, and the thread it's locked to, for the lifetime of the agent.
+func (w *Worker) runWithTimeout(c check.Check, checkLogger CheckLogger, timeout time.Duration) (checkstats.ResourceUsage, bool, error) {
+	done := make(chan runResult, 1)
+	go func() {
+		meter := checkstats.StartResourceUsageMeter()
+		err := c.Run()
+		done <- runResult{resourceUsage: meter.Stop(), err: err}
+	}()
 
-		checkLogger.CheckFinished()
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result.resourceUsage, false, result.err
+	case <-timer.C:
+		w.addStuckRun(c, 1)
+		go w.waitTimedOutRun(c, checkLogger, done)
+		return checkstats.ResourceUsage{}, true, check.RunTimeoutError{Timeout: timeout}
 	}
+}
 
-	log.Debugf("Runner %d, worker %d: Finished processing checks.", w.runnerID, w.ID)
+// waitTimedOutRun waits for a timed out run of a check to complete, and removes
+// the check from the running list once it does
+func (w *Worker) waitTimedOutRun(c check.Check, checkLogger CheckLogger, done chan runResult) {
+	<-done
+	checkLogger.Debug("Timed out check run completed")
+	w.addStuckRun(c, -1)
+	w.checksTracker.DeleteCheck(c.ID())
+}
+
+// addStuckRun tracks the timed out runs of a check still in progress
+func (w *Worker) addStuckRun(c check.Check, delta int) {
+	w.checksTracker.AddStuckRun(c.ID(), delta)
+	if w.shouldAddCheckStatsFunc(c.ID()) {
+		expvars.AddStuckRunStats(c, delta)
+	}
 }
 
 func startExpvarUpdater(name string, ut *UtilizationTracker) {
@@ -37,12 +37,15 @@ type testCheck struct {
 	t           *testing.T
 	runFunc     func(id checkid.ID)
 	runCount    *atomic.Uint64
+	instance    string
 }
 
 func (c *testCheck) ID() checkid.ID { return checkid.ID(c.id) }
 func (c *testCheck) String() string { return checkid.IDToCheckName(c.ID()) }
 func (c *testCheck) RunCount() int  { return int(c.runCount.Load()) }
 
+func (c *testCheck) InstanceConfig() string { return c.instance }
+
 func (c *testCheck) Interval() time.Duration {
 	if c.longRunning {
 		return 0
@@ -408,6 +411,193 @@ func TestWorkerConcurrentCheckScheduling(t *testing.T) {
 	assert.Equal(t, 0, int(expvars.GetWarningsCount()))
 }
 
+func TestWorkerOverlappingRuns(t *testing.T) {
+	for _, tc := range []struct {
+		policy       string
+		expectedRuns int
+		skippedRuns  uint64
+		queuedRuns   uint64
+		schedule     string
+	}{
+		{policy: "skip", expectedRuns: 1, skippedRuns: 1},
+		{policy: "queue", expectedRuns: 2, queuedRuns: 1, schedule: "every 123ns, queue overlapping runs"},
+	} {
+		t.Run(tc.policy, func(t *testing.T) {
+			expvars.Reset()
+			config.Datadog.Set("hostname", "myhost")
+
+			checksTracker := tracker.NewRunningChecksTracker()
+			pendingChecksChan := make(chan check.Check, 10)
+			mockShouldAddStatsFunc := func(id checkid.ID) bool { return true }
+
+			started := make(chan struct{}, 2)
+			release := make(chan struct{})
+			testCheck := newCheck(t, "overlapping:123", false, func(checkid.ID) {
+				started <- struct{}{}
+				<-release
+			})
+			testCheck.instance = "overlap_policy: " + tc.policy
+
+			var wg sync.WaitGroup
+			for idx := 0; idx < 2; idx++ {
+				worker, err := NewWorker(100, 200+idx, pendingChecksChan, checksTracker, mockShouldAddStatsFunc)
+				require.Nil(t, err)
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					worker.Run()
+				}()
+			}
+
+			pendingChecksChan <- testCheck
+			<-started
+			// the check is due again while it's still running
+			pendingChecksChan <- testCheck
+			require.Eventually(t, func() bool {
+				stats, found := expvars.CheckStats(testCheck.ID())
+				return found && stats.SkippedRuns+stats.QueuedRuns == 1
+			}, 5*time.Second, 10*time.Millisecond)
+
+			close(release)
+			close(pendingChecksChan)
+			wg.Wait()
+
+			assert.Equal(t, tc.expectedRuns, testCheck.RunCount())
+			assert.Equal(t, tc.expectedRuns, int(expvars.GetRunsCount()))
+			stats, found := expvars.CheckStats(testCheck.ID())
+			require.True(t, found)
+			assert.Equal(t, tc.skippedRuns, stats.SkippedRuns)
+			assert.Equal(t, tc.queuedRuns, stats.QueuedRuns)
+			assert.Equal(t, tc.schedule, stats.Schedule)
+		})
+	}
+}
+
+func TestWorkerBackoff(t *testing.T) {
+	expvars.Reset()
+	config.Datadog.Set("hostname", "myhost")
+
+	checksTracker := tracker.NewRunningChecksTracker()
+	pendingChecksChan := make(chan check.Check, 10)
+	mockShouldAddStatsFunc := func(id checkid.ID) bool { return true }
+
+	testCheck := newCheck(t, "failing:123", true, nil)
+	testCheck.instance = "backoff_failures: 2"
+
+	worker, err := NewWorker(100, 200, pendingChecksChan, checksTracker, mockShouldAddStatsFunc)
+	require.Nil(t, err)
+
+	// the 2nd failed run skips the next run, the 3rd one the next 3 runs
+	for idx := 0; idx < 7; idx++ {
+		pendingChecksChan <- testCheck
+	}
+	close(pendingChecksChan)
+	worker.Run()
+
+	assert.Equal(t, 3, testCheck.RunCount())
+	stats, found := expvars.CheckStats(testCheck.ID())
+	require.True(t, found)
+	assert.Equal(t, int64(3), stats.ConsecutiveFailures)
+	assert.Equal(t, int64(0), stats.BackoffRemainingRuns)
+	assert.Equal(t, uint64(4), stats.BackoffSkippedRuns)
+
+	// a successful run stops the backoff
+	testCheck.doErr = false
+	pendingChecksChan = make(chan check.Check, 10)
+	worker, err = NewWorker(100, 200, pendingChecksChan, checksTracker, mockShouldAddStatsFunc)
+	require.Nil(t, err)
+	pendingChecksChan <- testCheck
+	pendingChecksChan <- testCheck
+	close(pendingChecksChan)
+	worker.Run()
+
+	assert.Equal(t, 5, testCheck.RunCount())
+	stats, found = expvars.CheckStats(testCheck.ID())
+	require.True(t, found)
+	assert.Equal(t, int64(0), stats.ConsecutiveFailures)
+}
+
+func TestWorkerRunTimeout(t *testing.T) {
+	expvars.Reset()
+	config.Datadog.Set("hostname", "myhost")
+
+	checksTracker := tracker.NewRunningChecksTracker()
+	pendingChecksChan := make(chan check.Check, 10)
+	mockShouldAddStatsFunc := func(id checkid.ID) bool { return true }
+
+	release := make(chan struct{})
+	testCheck := newCheck(t, "slow:123", false, func(checkid.ID) { <-release })
+	testCheck.instance = "run_timeout: 1"
+
+	worker, err := NewWorker(100, 200, pendingChecksChan, checksTracker, mockShouldAddStatsFunc)
+	require.Nil(t, err)
+
+	// the run times out and the check stays running, so the next run is skipped
+	pendingChecksChan <- testCheck
+	pendingChecksChan <- testCheck
+	close(pendingChecksChan)
+	worker.Run()
+
+	stats, found := expvars.CheckStats(testCheck.ID())
+	require.True(t, found)
+	assert.Equal(t, uint64(1), stats.TimedOutRuns)
+	assert.Equal(t, uint64(1), stats.SkippedRuns)
+	assert.Equal(t, 1, int(stats.TotalErrors))
+	assert.Contains(t, stats.LastError, "timeout")
+	assert.Equal(t, int64(1), stats.StuckRuns)
+	_, found = checksTracker.Check(testCheck.ID())
+	assert.True(t, found)
+
+	// the check is removed from the running list once the run completes
+	close(release)
+	require.Eventually(t, func() bool {
+		_, found := checksTracker.Check(testCheck.ID())
+		return !found
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, testCheck.RunCount())
+	require.Eventually(t, func() bool {
+		stats, _ := expvars.CheckStats(testCheck.ID())
+		return stats.StuckRuns == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestWorkerRunTimeoutQueuePolicy(t *testing.T) {
+	expvars.Reset()
+	config.Datadog.Set("hostname", "myhost")
+
+	checksTracker := tracker.NewRunningChecksTracker()
+	pendingChecksChan := make(chan check.Check, 10)
+	mockShouldAddStatsFunc := func(id checkid.ID) bool { return true }
+
+	release := make(chan struct{})
+	testCheck := newCheck(t, "stuck:123", false, func(checkid.ID) { <-release })
+	testCheck.instance = "run_timeout: 1\noverlap_policy: queue"
+
+	worker, err := NewWorker(100, 200, pendingChecksChan, checksTracker, mockShouldAddStatsFunc)
+	require.Nil(t, err)
+
+	// the runs aren't queued behind a timed out run, which may never complete
+	pendingChecksChan <- testCheck
+	pendingChecksChan <- testCheck
+	close(pendingChecksChan)
+	worker.Run()
+
+	stats, found := expvars.CheckStats(testCheck.ID())
+	require.True(t, found)
+	assert.Equal(t, uint64(1), stats.SkippedRuns)
+	assert.Equal(t, uint64(0), stats.QueuedRuns)
+	assert.Equal(t, int64(1), stats.StuckRuns)
+	assert.True(t, checksTracker.IsStuck(testCheck.ID()))
+
+	close(release)
+	require.Eventually(t, func() bool {
+		_, found := checksTracker.Check(testCheck.ID())
+		return !found
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, testCheck.RunCount())
+	assert.False(t, checksTracker.IsStuck(testCheck.ID()))
+}
+
 func TestWorkerStatsAddition(t *testing.T) {
 	expvars.Reset()
 	config.Datadog.Set("hostname", "myhost")
@@ -147,6 +147,13 @@ type ConfigurationProviders struct {
 	Token                   string `mapstructure:"token"`
 	GraceTimeSeconds        int    `mapstructure:"grace_time_seconds"`
 	DegradedDeadlineMinutes int    `mapstructure:"degraded_deadline_minutes"`
+	// Headers are added to the requests of the HTTP config provider
+	Headers map[string]string `mapstructure:"headers"`
+	// Branch, VerifySignatures and AllowedSignersFile configure the git config
+	// provider
+	Branch             string `mapstructure:"branch"`
+	VerifySignatures   bool   `mapstructure:"verify_signatures"`
+	AllowedSignersFile string `mapstructure:"allowed_signers_file"`
 }
 
 // Listeners helps unmarshalling `listeners` config param
@@ -194,6 +201,9 @@ type Endpoint struct {
 type Warnings struct {
 	TraceMallocEnabledWithPy2 bool
 	Err                       error
+	// ValidationErrors are the errors found by validating the configuration
+	// file against the schema of the configuration
+	ValidationErrors []error
 }
 
 // DataType represent the generic data type (e.g. metrics, logs) that can be sent by the Agent
@@ -234,6 +244,9 @@ func InitConfig(config Config) {
 	config.BindEnv("env")
 	config.BindEnvAndSetDefault("tag_value_split_separator", map[string]string{})
 	config.BindEnvAndSetDefault("conf_path", ".")
+	// Reload the reloadable settings of the configuration file when it changes
+	config.BindEnvAndSetDefault("config_reload.watch_file", false)
+	config.BindEnvAndSetDefault("config_reload.watch_interval", 30) // in seconds
 	config.BindEnvAndSetDefault("confd_path", defaultConfdPath)
 	config.BindEnvAndSetDefault("additional_checksd", defaultAdditionalChecksPath)
 	config.BindEnvAndSetDefault("jmx_log_file", "")
@@ -263,6 +276,9 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("enable_metadata_collection", true)
 	config.BindEnvAndSetDefault("enable_gohai", true)
 	config.BindEnvAndSetDefault("check_runners", int64(4))
+	config.BindEnvAndSetDefault("check_run_timeout", 0)
+	config.BindEnvAndSetDefault("check_backoff_failures", 0)
+	config.BindEnvAndSetDefault("check_max_backoff", 600)
 	config.BindEnvAndSetDefault("auth_token_file_path", "")
 	config.BindEnv("bind_host")
 	config.BindEnvAndSetDefault("ipc_address", "localhost")
@@ -370,6 +386,8 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("secret_backend_command_allow_group_exec_perm", false)
 	config.BindEnvAndSetDefault("secret_backend_skip_checks", false)
 	config.BindEnvAndSetDefault("secret_backend_remove_trailing_line_break", false)
+	config.BindEnvAndSetDefault("secret_refresh_interval", 0)
+	config.BindEnvAndSetDefault("secret_refresh_on_api_key_failure", false)
 
 	// Use to output logs in JSON format
 	config.BindEnvAndSetDefault("log_format_json", false)
@@ -607,6 +625,8 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("autoconfig_from_environment", true)
 	config.BindEnvAndSetDefault("autoconfig_exclude_features", []string{})
 	config.BindEnvAndSetDefault("autoconfig_include_features", []string{})
+	// named instance defaults of the checks, indexed by check name
+	config.SetKnown("autoconfig_instance_defaults")
 
 	// Docker
 	config.BindEnvAndSetDefault("docker_query_timeout", int64(5))
@@ -1290,6 +1310,9 @@ func LoadProxyFromEnv(config Config) {
 	}
 
 	var isSet bool
+	// the sources of the settings found in the env vars, the other ones keep
+	// their source
+	sources := map[string]Source{}
 	p := &Proxy{}
 	if isSet = config.IsSet("proxy"); isSet {
 		if err := config.UnmarshalKey("proxy", p); err != nil {
@@ -1301,30 +1324,39 @@ func LoadProxyFromEnv(config Config) {
 	if HTTP, found := lookupEnv("DD_PROXY_HTTP"); found {
 		isSet = true
 		p.HTTP = HTTP
+		sources["proxy.http"] = SourceEnvVar
 	} else if HTTP, found := lookupEnvCaseInsensitive("HTTP_PROXY"); found {
 		isSet = true
 		p.HTTP = HTTP
+		sources["proxy.http"] = SourceEnvVar
 	}
 
 	if HTTPS, found := lookupEnv("DD_PROXY_HTTPS"); found {
 		isSet = true
 		p.HTTPS = HTTPS
+		sources["proxy.https"] = SourceEnvVar
 	} else if HTTPS, found := lookupEnvCaseInsensitive("HTTPS_PROXY"); found {
 		isSet = true
 		p.HTTPS = HTTPS
+		sources["proxy.https"] = SourceEnvVar
 	}
 
 	if noProxy, found := lookupEnv("DD_PROXY_NO_PROXY"); found {
 		isSet = true
 		p.NoProxy = strings.Split(noProxy, " ") // space-separated list, consistent with viper
+		sources["proxy.no_proxy"] = SourceEnvVar
 	} else if noProxy, found := lookupEnvCaseInsensitive("NO_PROXY"); found {
 		isSet = true
 		p.NoProxy = strings.Split(noProxy, ",") // comma-separated list, consistent with other tools that use the NO_PROXY env var
+		sources["proxy.no_proxy"] = SourceEnvVar
 	}
 
 	if !config.GetBool("use_proxy_for_cloud_metadata") {
 		log.Debugf("'use_proxy_for_cloud_metadata' is enabled: adding cloud provider URL to the no_proxy list")
 		isSet = true
+		if _, found := sources["proxy.no_proxy"]; !found && config.GetSource("proxy.no_proxy") == SourceDefault {
+			sources["proxy.no_proxy"] = SourceAgentRuntime
+		}
 		p.NoProxy = append(p.NoProxy,
 			"169.254.169.254", // Azure, EC2, GCE
 			"100.100.100.200", // Alibaba
@@ -1334,8 +1366,15 @@ func LoadProxyFromEnv(config Config) {
 	// We have to set each value individually so both config.Get("proxy")
 	// and config.Get("proxy.http") work
 	if isSet {
-		config.Set("proxy.http", p.HTTP)
-		config.Set("proxy.https", p.HTTPS)
+		setProxy := func(key string, value interface{}) {
+			if source, found := sources[key]; found {
+				config.SetWithSource(key, value, source)
+			} else {
+				setWithSourceOf(config, key, value, key)
+			}
+		}
+		setProxy("proxy.http", p.HTTP)
+		setProxy("proxy.https", p.HTTPS)
 
 		// If this is set to an empty []string, viper will have a type conflict when merging
 		// this config during secrets resolution. It unmarshals empty yaml lists to type
@@ -1344,7 +1383,7 @@ func LoadProxyFromEnv(config Config) {
 		for idx := range p.NoProxy {
 			noProxy[idx] = p.NoProxy[idx]
 		}
-		config.Set("proxy.no_proxy", noProxy)
+		setProxy("proxy.no_proxy", noProxy)
 	}
 }
 
@@ -1559,8 +1598,19 @@ func LoadCustom(config Config, origin string, loadSecret bool, additionalKnownEn
 		return &warnings, err
 	}
 
-	for _, key := range findUnknownKeys(config) {
-		log.Warnf("Unknown key in config file: %v", key)
+	if validationErrors, err := ValidateConfigFile(config, config.ConfigFileUsed()); err == nil {
+		for _, validationErr := range validationErrors {
+			log.Warnf("Invalid configuration in %s: %s", config.ConfigFileUsed(), validationErr)
+		}
+		warnings.ValidationErrors = validationErrors
+	} else {
+		for _, key := range findUnknownKeys(config) {
+			log.Warnf("Unknown key in config file: %v", key)
+		}
+	}
+
+	if err := recordLoadedFile(config, origin); err != nil {
+		log.Debugf("Unable to record the settings of %s, it can't be reloaded: %v", config.ConfigFileUsed(), err)
 	}
 
 	for _, v := range findUnknownEnvVars(config, os.Environ(), additionalKnownEnvVars) {
@@ -1646,52 +1696,55 @@ func setupFipsEndpoints(config Config) error {
 	os.Unsetenv("HTTP_PROXY")
 	os.Unsetenv("HTTPS_PROXY")
 
+	// The endpoints set below come from the FIPS settings
+	set := func(key string, value interface{}) { setWithSourceOf(config, key, value, "fips.enabled") }
+
 	// HTTP for now, will soon be updated to HTTPS
 	protocol := "http://"
 	if config.GetBool("fips.https") {
 		protocol = "https://"
-		config.Set("skip_ssl_validation", !config.GetBool("fips.tls_verify"))
+		setWithSourceOf(config, "skip_ssl_validation", !config.GetBool("fips.tls_verify"), "fips.tls_verify")
 	}
 
 	// The following overwrites should be sync with the documentation for the fips.enabled config setting in the
 	// config_template.yaml
 
 	// Metrics
-	config.Set("dd_url", protocol+urlFor(metrics))
+	set("dd_url", protocol+urlFor(metrics))
 
 	// Logs
-	setupFipsLogsConfig(config, "logs_config.", urlFor(logs))
+	setupFipsLogsConfig(config, set, "logs_config.", urlFor(logs))
 
 	// APM
-	config.Set("apm_config.apm_dd_url", protocol+urlFor(traces))
+	set("apm_config.apm_dd_url", protocol+urlFor(traces))
 	// Adding "/api/v2/profile" because it's not added to the 'apm_config.profiling_dd_url' value by the Agent
-	config.Set("apm_config.profiling_dd_url", protocol+urlFor(profiles)+"/api/v2/profile")
-	config.Set("apm_config.telemetry.dd_url", protocol+urlFor(instrumentationTelemetry))
+	set("apm_config.profiling_dd_url", protocol+urlFor(profiles)+"/api/v2/profile")
+	set("apm_config.telemetry.dd_url", protocol+urlFor(instrumentationTelemetry))
 
 	// Processes
-	config.Set("process_config.process_dd_url", protocol+urlFor(processes))
+	set("process_config.process_dd_url", protocol+urlFor(processes))
 
 	// Database monitoring
-	config.Set("database_monitoring.metrics.dd_url", urlFor(databasesMonitoringMetrics))
-	config.Set("database_monitoring.activity.dd_url", urlFor(databasesMonitoringMetrics))
-	config.Set("database_monitoring.samples.dd_url", urlFor(databasesMonitoringSamples))
+	set("database_monitoring.metrics.dd_url", urlFor(databasesMonitoringMetrics))
+	set("database_monitoring.activity.dd_url", urlFor(databasesMonitoringMetrics))
+	set("database_monitoring.samples.dd_url", urlFor(databasesMonitoringSamples))
 
 	// Network devices
-	config.Set("network_devices.metadata.dd_url", urlFor(networkDevicesMetadata))
+	set("network_devices.metadata.dd_url", urlFor(networkDevicesMetadata))
 
 	// Orchestrator Explorer
-	config.Set("orchestrator_explorer.orchestrator_dd_url", protocol+urlFor(orchestratorExplorer))
+	set("orchestrator_explorer.orchestrator_dd_url", protocol+urlFor(orchestratorExplorer))
 
 	// CWS
-	setupFipsLogsConfig(config, "runtime_security_config.endpoints.", urlFor(runtimeSecurity))
+	setupFipsLogsConfig(config, set, "runtime_security_config.endpoints.", urlFor(runtimeSecurity))
 
 	return nil
 }
 
-func setupFipsLogsConfig(config Config, configPrefix string, url string) {
-	config.Set(configPrefix+"use_http", true)
-	config.Set(configPrefix+"logs_no_ssl", !config.GetBool("fips.https"))
-	config.Set(configPrefix+"logs_dd_url", url)
+func setupFipsLogsConfig(config Config, set func(key string, value interface{}), configPrefix string, url string) {
+	set(configPrefix+"use_http", true)
+	set(configPrefix+"logs_no_ssl", !config.GetBool("fips.https"))
+	set(configPrefix+"logs_dd_url", url)
 }
 
 // ResolveSecrets merges all the secret values from origin into config. Secret values
@@ -1727,10 +1780,35 @@ func ResolveSecrets(config Config, origin string) error {
 		if err = config.MergeConfigOverride(r); err != nil {
 			return fmt.Errorf("could not update main configuration after decrypting secrets: %v", err)
 		}
+
+		secrets.RegisterRefreshCallback(func(changes []secrets.SecretChange) {
+			updateRefreshedSecrets(config, origin, changes)
+		})
 	}
 	return nil
 }
 
+// updateRefreshedSecrets updates the settings of the configuration from origin
+// whose secret changed when the secrets were refreshed. The settings set in lists
+// can't be updated, as their exact location isn't known.
+func updateRefreshedSecrets(config Config, origin string, changes []secrets.SecretChange) {
+	for _, change := range changes {
+		if change.Origin != origin {
+			continue
+		}
+
+		key := strings.ReplaceAll(change.Path, "/", ".")
+		if value, ok := config.Get(key).(string); !ok || value != change.OldValue {
+			log.Warnf("Secret '%s' changed but setting '%s' can't be updated, the agent must be restarted to use its new value", change.Handle, key)
+			continue
+		}
+
+		// the setting keeps the source of the secret
+		config.SetWithSource(key, change.NewValue, config.GetSource(key))
+		log.Infof("Setting '%s' updated with the new value of secret '%s'", key, change.Handle)
+	}
+}
+
 // EnvVarAreSetAndNotEqual returns true if two given variables are set in environment and are not equal.
 func EnvVarAreSetAndNotEqual(lhsName string, rhsName string) bool {
 	lhsValue, lhsIsSet := os.LookupEnv(lhsName)
@@ -1744,7 +1822,8 @@ func SanitizeAPIKeyConfig(config Config, key string) {
 	if !config.IsKnown(key) {
 		return
 	}
-	config.Set(key, SanitizeAPIKey(config.GetString(key)))
+	// the sanitized key keeps its source
+	config.SetWithSource(key, SanitizeAPIKey(config.GetString(key)), config.GetSource(key))
 }
 
 // sanitizeExternalMetricsProviderChunkSize ensures the value of `external_metrics_provider.chunk_size` is within an acceptable range
@@ -1756,11 +1835,11 @@ func sanitizeExternalMetricsProviderChunkSize(config Config) {
 	chunkSize := config.GetInt("external_metrics_provider.chunk_size")
 	if chunkSize <= 0 {
 		log.Warnf("external_metrics_provider.chunk_size cannot be negative: %d", chunkSize)
-		config.Set("external_metrics_provider.chunk_size", 1)
+		setWithSourceOf(config, "external_metrics_provider.chunk_size", 1, "external_metrics_provider.chunk_size")
 	}
 	if chunkSize > maxExternalMetricsProviderChunkSize {
 		log.Warnf("external_metrics_provider.chunk_size has been set to %d, which is higher than the maximum allowed value %d. Using %d.", chunkSize, maxExternalMetricsProviderChunkSize, maxExternalMetricsProviderChunkSize)
-		config.Set("external_metrics_provider.chunk_size", maxExternalMetricsProviderChunkSize)
+		setWithSourceOf(config, "external_metrics_provider.chunk_size", maxExternalMetricsProviderChunkSize, "external_metrics_provider.chunk_size")
 	}
 }
 
@@ -1891,7 +1970,7 @@ func setTracemallocEnabled(config Config) bool {
 	}
 
 	// update config with the actual effective tracemalloc
-	config.Set("tracemalloc_debug", wTracemalloc)
+	setWithSourceOf(config, "tracemalloc_debug", wTracemalloc, "tracemalloc_debug")
 	return traceMallocEnabledWithPy2
 }
 
@@ -1910,7 +1989,7 @@ func setNumWorkers(config Config) {
 	}
 
 	// update config with the actual effective number of workers
-	config.Set("check_runners", numWorkers)
+	setWithSourceOf(config, "check_runners", numWorkers, "check_runners")
 }
 
 // GetDogstatsdMappingProfiles returns mapping profiles used in DogStatsD mapper
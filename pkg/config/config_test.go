@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/common/types"
+	"github.com/DataDog/datadog-agent/pkg/secrets"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -512,6 +513,26 @@ func TestProxy(t *testing.T) {
 	}
 }
 
+func TestUpdateRefreshedSecrets(t *testing.T) {
+	config := SetupConf()
+	config.Set("api_key", "old_key")
+	config.Set("logs_config.api_key", "old_logs_key")
+	config.Set("proxy.no_proxy", []string{"old_host"})
+
+	updateRefreshedSecrets(config, "datadog.yaml", []secrets.SecretChange{
+		{Handle: "key", Origin: "datadog.yaml", Path: "api_key", OldValue: "old_key", NewValue: "new_key"},
+		{Handle: "logs_key", Origin: "datadog.yaml", Path: "logs_config/api_key", OldValue: "old_logs_key", NewValue: "new_logs_key"},
+		// settings in lists aren't updated
+		{Handle: "host", Origin: "datadog.yaml", Path: "proxy/no_proxy", OldValue: "old_host", NewValue: "new_host"},
+		// secrets from other configurations are ignored
+		{Handle: "key", Origin: "cpu", Path: "api_key", OldValue: "new_key", NewValue: "other_key"},
+	})
+
+	assert.Equal(t, "new_key", config.GetString("api_key"))
+	assert.Equal(t, "new_logs_key", config.GetString("logs_config.api_key"))
+	assert.Equal(t, []string{"old_host"}, config.GetStringSlice("proxy.no_proxy"))
+}
+
 func TestSanitizeAPIKeyConfig(t *testing.T) {
 	config := SetupConf()
 
@@ -216,16 +216,18 @@ func loadProcessTransforms(config Config) {
 		log.Info("process_config.enabled is deprecated, use process_config.container_collection.enabled " +
 			"and process_config.process_collection.enabled instead, " +
 			"see https://docs.datadoghq.com/infrastructure/process#installation for more information")
+		// the settings set below come from process_config.enabled
+		set := func(key string, value interface{}) { setWithSourceOf(config, key, value, "process_config.enabled") }
 		procConfigEnabled := strings.ToLower(config.GetString("process_config.enabled"))
 		if procConfigEnabled == "disabled" {
-			config.Set("process_config.process_collection.enabled", false)
-			config.Set("process_config.container_collection.enabled", false)
+			set("process_config.process_collection.enabled", false)
+			set("process_config.container_collection.enabled", false)
 		} else if enabled, _ := strconv.ParseBool(procConfigEnabled); enabled { // "true"
-			config.Set("process_config.process_collection.enabled", true)
-			config.Set("process_config.container_collection.enabled", false)
+			set("process_config.process_collection.enabled", true)
+			set("process_config.container_collection.enabled", false)
 		} else { // "false"
-			config.Set("process_config.process_collection.enabled", false)
-			config.Set("process_config.container_collection.enabled", true)
+			set("process_config.process_collection.enabled", false)
+			set("process_config.container_collection.enabled", true)
 		}
 	}
 }
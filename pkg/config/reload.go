@@ -0,0 +1,319 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/secrets"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// reloadable is a set of keys whose new values are applied to the running
+// components by a handler
+type reloadable struct {
+	name    string
+	keys    []string
+	handler func() error
+}
+
+// loadedFile holds the settings of a configuration file, as they were loaded
+// or last reloaded
+type loadedFile struct {
+	origin   string
+	settings map[string]interface{}
+}
+
+var (
+	reloadablesMu sync.Mutex
+	reloadables   []reloadable
+
+	// reloadMu serializes the reloads and protects loadedFiles
+	reloadMu    sync.Mutex
+	loadedFiles = map[Config]loadedFile{}
+)
+
+// ReloadResult is the result of a reload of a configuration file
+type ReloadResult struct {
+	// Applied are the changed keys whose new value was applied
+	Applied []string
+	// RequiresRestart are the changed keys that can't be reloaded
+	RequiresRestart []string
+	// OverriddenByEnv are the changed reloadable keys that were left
+	// untouched because an env var sets their value
+	OverriddenByEnv []string
+	// Errors are the errors that happened while applying the changed keys
+	Errors []string
+}
+
+// HasChanges returns whether the reloaded file had any changed key
+func (r *ReloadResult) HasChanges() bool {
+	return len(r.Applied)+len(r.RequiresRestart)+len(r.OverriddenByEnv) > 0
+}
+
+// RegisterReloadable declares keys that can be changed without restarting the
+// agent. When the configuration file is reloaded and one of the keys changed,
+// its new value is set in the configuration and handler is called to apply it
+// to the running components. Registering a name twice replaces its keys and
+// handler.
+func RegisterReloadable(name string, handler func() error, keys ...string) {
+	reloadablesMu.Lock()
+	defer reloadablesMu.Unlock()
+
+	r := reloadable{name: name, handler: handler}
+	for _, key := range keys {
+		r.keys = append(r.keys, strings.ToLower(key))
+	}
+
+	for i := range reloadables {
+		if reloadables[i].name == name {
+			reloadables[i] = r
+			return
+		}
+	}
+	reloadables = append(reloadables, r)
+}
+
+// reloadableFor returns the index of the reloadable declaring a key, or -1
+func reloadableFor(key string) int {
+	for i, r := range reloadables {
+		for _, k := range r.keys {
+			if k == key {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// recordLoadedFile records the settings of the configuration file of a
+// configuration, so that they can be compared to the file when reloading it
+func recordLoadedFile(config Config, origin string) error {
+	content, err := os.ReadFile(config.ConfigFileUsed())
+	if err != nil {
+		return err
+	}
+	settings, err := flattenConfig(GenerateSchema(config), content)
+	if err != nil {
+		return err
+	}
+
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+	loadedFiles[config] = loadedFile{origin: origin, settings: settings}
+	return nil
+}
+
+// Reload re-reads the configuration file of a configuration and applies the
+// changed keys that are reloadable. The other changed keys are only reported,
+// and keep their current value until the agent is restarted.
+func Reload(config Config) (*ReloadResult, error) {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	loaded, found := loadedFiles[config]
+	if !found {
+		return nil, errors.New("the configuration wasn't loaded from a file")
+	}
+
+	content, err := os.ReadFile(config.ConfigFileUsed())
+	if err != nil {
+		return nil, err
+	}
+	settings, err := flattenConfig(GenerateSchema(config), content)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %v", config.ConfigFileUsed(), err)
+	}
+
+	reloadablesMu.Lock()
+	defer reloadablesMu.Unlock()
+
+	result := &ReloadResult{}
+	toRun := map[int]struct{}{}
+	for _, key := range changedKeys(loaded.settings, settings) {
+		index := reloadableFor(key)
+		if index < 0 {
+			result.RequiresRestart = append(result.RequiresRestart, key)
+			continue
+		}
+		if config.IsSetByEnv(key) {
+			result.OverriddenByEnv = append(result.OverriddenByEnv, key)
+			continue
+		}
+
+		newValue, isSet := settings[key]
+		value, err := reloadedValue(config, key, loaded.settings[key], newValue, isSet, loaded.origin)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", key, err))
+			continue
+		}
+
+		if isSet {
+			config.SetWithSource(key, value, SourceFile)
+			loaded.settings[key] = newValue
+		} else {
+			config.SetWithSource(key, value, SourceDefault)
+			delete(loaded.settings, key)
+		}
+		result.Applied = append(result.Applied, key)
+		toRun[index] = struct{}{}
+	}
+
+	for i, r := range reloadables {
+		if _, found := toRun[i]; !found {
+			continue
+		}
+		if err := r.handler(); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", r.name, err))
+		}
+	}
+	return result, nil
+}
+
+// reloadedValue returns the value to set for a changed key. A key removed from
+// the file gets back its default value.
+func reloadedValue(config Config, key string, oldValue, newValue interface{}, isSet bool, origin string) (interface{}, error) {
+	if !isSet {
+		if value, found := config.GetDefaults()[key]; found {
+			return value, nil
+		}
+		// setting nil would let the previous value of the file show through
+		if oldValue != nil {
+			return reflect.Zero(reflect.TypeOf(normalizeYAMLValue(oldValue))).Interface(), nil
+		}
+		return nil, nil
+	}
+
+	value := normalizeYAMLValue(newValue)
+	if config.GetString("secret_backend_command") == "" {
+		return value, nil
+	}
+
+	// decrypt the secrets the new value may hold, like when loading the file
+	yamlValue, err := yaml.Marshal(map[string]interface{}{"value": value})
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(yamlValue, []byte("ENC[")) {
+		return value, nil
+	}
+	decrypted, err := secrets.Decrypt(yamlValue, origin)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt secret: %v", err)
+	}
+	var decryptedValue map[interface{}]interface{}
+	if err := yaml.Unmarshal(decrypted, &decryptedValue); err != nil {
+		return nil, err
+	}
+	return normalizeYAMLValue(decryptedValue["value"]), nil
+}
+
+// changedKeys returns the sorted keys whose value differs between two
+// flattened configuration files
+func changedKeys(oldSettings, newSettings map[string]interface{}) []string {
+	var keys []string
+	for key, value := range newSettings {
+		if oldValue, found := oldSettings[key]; !found || !reflect.DeepEqual(oldValue, value) {
+			keys = append(keys, key)
+		}
+	}
+	for key := range oldSettings {
+		if _, found := newSettings[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// flattenConfig returns the settings of a configuration file indexed by their
+// full key, descending into the sections of the schema
+func flattenConfig(schema Schema, content []byte) (map[string]interface{}, error) {
+	var settings map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, err
+	}
+
+	flattened := map[string]interface{}{}
+	flattenSection(schema, sectionPrefixes(schema), "", settings, flattened)
+	return flattened, nil
+}
+
+func flattenSection(schema Schema, prefixes map[string]struct{}, prefix string, settings map[interface{}]interface{}, flattened map[string]interface{}) {
+	for rawKey, value := range settings {
+		key := strings.ToLower(fmt.Sprint(rawKey))
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		keyType, found := schema[key]
+		if _, isSection := prefixes[key]; isSection && (!found || keyType == KeyTypeAny) {
+			if section, ok := value.(map[interface{}]interface{}); ok {
+				flattenSection(schema, prefixes, key, section, flattened)
+				continue
+			}
+		}
+		flattened[key] = value
+	}
+}
+
+// normalizeYAMLValue converts the maps of a value parsed from YAML to maps
+// indexed by strings, as viper does when reading a file
+func normalizeYAMLValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		normalized := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			normalized[fmt.Sprint(key)] = normalizeYAMLValue(item)
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, len(v))
+		for i, item := range v {
+			normalized[i] = normalizeYAMLValue(item)
+		}
+		return normalized
+	}
+	return value
+}
+
+// WatchConfigFile reloads the configuration file of a configuration whenever
+// its content changes, checking it at the given interval until the context is
+// done. onReload is called with the result of every reload.
+func WatchConfigFile(ctx context.Context, config Config, interval time.Duration, onReload func(*ReloadResult, error)) {
+	lastContent, _ := os.ReadFile(config.ConfigFileUsed())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			content, err := os.ReadFile(config.ConfigFileUsed())
+			if err != nil {
+				log.Debugf("Unable to read %s: %v", config.ConfigFileUsed(), err)
+				continue
+			}
+			if bytes.Equal(content, lastContent) {
+				continue
+			}
+			lastContent = content
+			onReload(Reload(config))
+		}
+	}
+}
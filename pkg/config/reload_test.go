@@ -0,0 +1,161 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupReloadableConfig loads a configuration from a file with the given
+// content, and returns the configuration and the path of the file
+func setupReloadableConfig(t *testing.T, content string) (Config, string) {
+	configPath := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+
+	config := NewConfig("datadog", "DD", strings.NewReplacer(".", "_"))
+	config.BindEnvAndSetDefault("tags", []string{})
+	config.BindEnvAndSetDefault("extra_tags", []string{})
+	config.BindEnvAndSetDefault("dogstatsd_port", 8125)
+	config.BindEnv("logs_config.processing_rules")
+	config.BindEnvAndSetDefault("logs_config.container_collect_all", false)
+	config.SetConfigFile(configPath)
+
+	_, err := LoadCustom(config, "unit_test", false, nil)
+	require.NoError(t, err)
+	return config, configPath
+}
+
+func TestReload(t *testing.T) {
+	config, configPath := setupReloadableConfig(t, `
+tags:
+  - team:a
+dogstatsd_port: 8125
+logs_config:
+  container_collect_all: true
+`)
+
+	tagsReloads := 0
+	RegisterReloadable("test tags", func() error {
+		tagsReloads++
+		return nil
+	}, "tags", "extra_tags")
+	rulesReloads := 0
+	RegisterReloadable("test processing rules", func() error {
+		rulesReloads++
+		return nil
+	}, "logs_config.processing_rules")
+
+	require.NoError(t, os.WriteFile(configPath, []byte(`
+tags:
+  - team:b
+extra_tags:
+  - env:prod
+dogstatsd_port: 8126
+logs_config:
+  container_collect_all: true
+  processing_rules:
+    - type: exclude_at_match
+      name: exclude_healthchecks
+      pattern: healthcheck
+`), 0644))
+
+	result, err := Reload(config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extra_tags", "logs_config.processing_rules", "tags"}, result.Applied)
+	assert.Equal(t, []string{"dogstatsd_port"}, result.RequiresRestart)
+	assert.Empty(t, result.OverriddenByEnv)
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, 1, tagsReloads)
+	assert.Equal(t, 1, rulesReloads)
+
+	assert.Equal(t, []string{"team:b"}, config.GetStringSlice("tags"))
+	assert.Equal(t, []string{"env:prod"}, config.GetStringSlice("extra_tags"))
+	assert.Equal(t, 8125, config.GetInt("dogstatsd_port"))
+	var rules []map[string]string
+	require.NoError(t, config.UnmarshalKey("logs_config.processing_rules", &rules))
+	assert.Equal(t, []map[string]string{{"type": "exclude_at_match", "name": "exclude_healthchecks", "pattern": "healthcheck"}}, rules)
+
+	// the keys requiring a restart are reported until the agent restarts
+	result, err = Reload(config)
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []string{"dogstatsd_port"}, result.RequiresRestart)
+	assert.Equal(t, 1, tagsReloads)
+
+	// removed keys get back their default value
+	require.NoError(t, os.WriteFile(configPath, []byte("dogstatsd_port: 8125\n"), 0644))
+	result, err = Reload(config)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"extra_tags", "logs_config.processing_rules", "tags"}, result.Applied)
+	assert.Equal(t, []string{"logs_config.container_collect_all"}, result.RequiresRestart)
+	assert.Empty(t, config.GetStringSlice("tags"))
+	assert.Empty(t, config.GetStringSlice("extra_tags"))
+	rules = nil
+	require.NoError(t, config.UnmarshalKey("logs_config.processing_rules", &rules))
+	assert.Empty(t, rules)
+	assert.Equal(t, 2, tagsReloads)
+	assert.Equal(t, 2, rulesReloads)
+}
+
+func TestReloadOverriddenByEnv(t *testing.T) {
+	t.Setenv("DD_TAGS", "team:env")
+	config, configPath := setupReloadableConfig(t, "tags:\n  - team:a\n")
+	RegisterReloadable("test tags", func() error { return nil }, "tags", "extra_tags")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("tags:\n  - team:b\n"), 0644))
+	result, err := Reload(config)
+	require.NoError(t, err)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []string{"tags"}, result.OverriddenByEnv)
+	assert.Equal(t, []string{"team:env"}, config.GetStringSlice("tags"))
+}
+
+func TestReloadInvalidFile(t *testing.T) {
+	config, configPath := setupReloadableConfig(t, "tags:\n  - team:a\n")
+	RegisterReloadable("test tags", func() error { return nil }, "tags", "extra_tags")
+
+	require.NoError(t, os.WriteFile(configPath, []byte("tags: [team:b\n"), 0644))
+	_, err := Reload(config)
+	assert.Error(t, err)
+	assert.Equal(t, []string{"team:a"}, config.GetStringSlice("tags"))
+}
+
+func TestReloadNotLoaded(t *testing.T) {
+	_, err := Reload(NewConfig("datadog", "DD", strings.NewReplacer(".", "_")))
+	assert.Error(t, err)
+}
+
+func TestWatchConfigFile(t *testing.T) {
+	config, configPath := setupReloadableConfig(t, "tags:\n  - team:a\n")
+	RegisterReloadable("test tags", func() error { return nil }, "tags", "extra_tags")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	results := make(chan *ReloadResult, 10)
+	go WatchConfigFile(ctx, config, 10*time.Millisecond, func(result *ReloadResult, err error) {
+		assert.NoError(t, err)
+		results <- result
+	})
+
+	// let the watcher read the current content first
+	time.Sleep(50 * time.Millisecond)
+	require.NoError(t, os.WriteFile(configPath, []byte("tags:\n  - team:b\n"), 0644))
+	select {
+	case result := <-results:
+		assert.Equal(t, []string{"tags"}, result.Applied)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the configuration file wasn't reloaded")
+	}
+	assert.Equal(t, []string{"team:b"}, config.GetStringSlice("tags"))
+}
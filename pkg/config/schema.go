@@ -0,0 +1,384 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// maxSuggestionDistance is the maximum edit distance between an unknown key and
+// the known key suggested instead
+const maxSuggestionDistance = 3
+
+// KeyType is the type of the value of a configuration key, inferred from the
+// type of its default value
+type KeyType string
+
+// Types of the configuration keys
+const (
+	// KeyTypeAny is the type of the keys without a default value, whose
+	// value isn't checked
+	KeyTypeAny      KeyType = "any"
+	KeyTypeBool     KeyType = "bool"
+	KeyTypeInt      KeyType = "int"
+	KeyTypeFloat    KeyType = "float"
+	KeyTypeDuration KeyType = "duration"
+	KeyTypeString   KeyType = "string"
+	KeyTypeList     KeyType = "list"
+	KeyTypeMap      KeyType = "map"
+)
+
+// deprecatedKeys are the keys that are still supported but replaced by other
+// keys, indexed by deprecated key
+var deprecatedKeys = map[string]string{
+	"apm_config.disable_rare_sampler":                  "apm_config.enable_rare_sampler",
+	"compliance_config.xccdf.enabled":                  "compliance_config.host_benchmarks.enabled",
+	"forwarder_retry_queue_max_size":                   "forwarder_retry_queue_payloads_max_size",
+	"log_enabled":                                      "logs_enabled",
+	"logs_config.use_http":                             "logs_config.force_use_http",
+	"logs_config.use_tcp":                              "logs_config.force_use_tcp",
+	"process_config.enabled":                           "process_config.process_collection.enabled",
+	"process_config.orchestrator_additional_endpoints": "orchestrator_explorer.orchestrator_additional_endpoints",
+	"process_config.orchestrator_dd_url":               "orchestrator_explorer.orchestrator_dd_url",
+	"tracemalloc_blacklist":                            "tracemalloc_exclude",
+	"tracemalloc_whitelist":                            "tracemalloc_include",
+}
+
+// UnknownKeyError is the validation error of a key that isn't known
+type UnknownKeyError struct {
+	Key string
+	// Suggestion is the known key closest to Key, if any
+	Suggestion string
+}
+
+func (e *UnknownKeyError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("unknown key '%s', did you mean '%s'?", e.Key, e.Suggestion)
+	}
+	return fmt.Sprintf("unknown key '%s'", e.Key)
+}
+
+// WrongTypeError is the validation error of a key whose value doesn't have the
+// type of its default value
+type WrongTypeError struct {
+	Key      string
+	Expected KeyType
+	Value    interface{}
+}
+
+func (e *WrongTypeError) Error() string {
+	return fmt.Sprintf("key '%s' expects a %s, got %s", e.Key, e.Expected, describeValue(e.Value))
+}
+
+// DeprecatedKeyError is the validation error of a deprecated key
+type DeprecatedKeyError struct {
+	Key         string
+	Replacement string
+}
+
+func (e *DeprecatedKeyError) Error() string {
+	return fmt.Sprintf("key '%s' is deprecated, use '%s' instead", e.Key, e.Replacement)
+}
+
+// Schema holds the types of the known keys of a configuration. Keys ending
+// with the ".*" wildcard accept any sub-key.
+type Schema map[string]KeyType
+
+// GenerateSchema generates the schema of a configuration from its known keys
+// and the types of their default values
+func GenerateSchema(config ConfigReader) Schema {
+	schema := Schema{}
+	for key := range config.GetKnownKeys() {
+		schema[key] = KeyTypeAny
+	}
+	for key, value := range config.GetDefaults() {
+		schema[key] = keyTypeOf(value)
+	}
+	return schema
+}
+
+// keyTypeOf returns the type of a key with the given default value
+func keyTypeOf(value interface{}) KeyType {
+	if _, ok := value.(time.Duration); ok {
+		return KeyTypeDuration
+	}
+	if value == nil {
+		return KeyTypeAny
+	}
+
+	switch reflect.TypeOf(value).Kind() {
+	case reflect.Bool:
+		return KeyTypeBool
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return KeyTypeInt
+	case reflect.Float32, reflect.Float64:
+		return KeyTypeFloat
+	case reflect.String:
+		return KeyTypeString
+	case reflect.Slice, reflect.Array:
+		return KeyTypeList
+	case reflect.Map, reflect.Struct:
+		return KeyTypeMap
+	}
+	return KeyTypeAny
+}
+
+// ValidateConfigFile validates the configuration file at the given path
+// against the schema of the configuration. It returns the validation errors,
+// which are *UnknownKeyError, *WrongTypeError or *DeprecatedKeyError, sorted by
+// key.
+func ValidateConfigFile(config ConfigReader, path string) ([]error, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ValidateConfig(GenerateSchema(config), content)
+}
+
+// ValidateConfig validates the content of a configuration file against the
+// given schema
+func ValidateConfig(schema Schema, content []byte) ([]error, error) {
+	var settings map[interface{}]interface{}
+	if err := yaml.Unmarshal(content, &settings); err != nil {
+		return nil, err
+	}
+
+	v := &schemaValidator{schema: schema, prefixes: sectionPrefixes(schema)}
+	v.validateSection("", settings)
+
+	sort.SliceStable(v.errors, func(i, j int) bool {
+		return validationErrorKey(v.errors[i]) < validationErrorKey(v.errors[j])
+	})
+	return v.errors, nil
+}
+
+// sectionPrefixes returns the sections holding the keys of a schema
+func sectionPrefixes(schema Schema) map[string]struct{} {
+	prefixes := map[string]struct{}{}
+	for key := range schema {
+		parts := strings.Split(key, ".")
+		for i := 1; i < len(parts); i++ {
+			prefixes[strings.Join(parts[:i], ".")] = struct{}{}
+		}
+	}
+	return prefixes
+}
+
+// schemaValidator validates the settings of a configuration file against a
+// schema
+type schemaValidator struct {
+	schema Schema
+	// prefixes are the sections holding known keys
+	prefixes map[string]struct{}
+	errors   []error
+}
+
+// validateSection validates the settings of a section, with the given prefix
+func (v *schemaValidator) validateSection(prefix string, settings map[interface{}]interface{}) {
+	for rawKey, value := range settings {
+		key := strings.ToLower(fmt.Sprint(rawKey))
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		v.validateKey(key, value)
+	}
+}
+
+// validateKey validates the value of a key
+func (v *schemaValidator) validateKey(key string, value interface{}) {
+	if replacement, found := deprecatedKeys[key]; found {
+		v.errors = append(v.errors, &DeprecatedKeyError{Key: key, Replacement: replacement})
+	}
+
+	keyType, found := v.schema[key]
+	if _, isSection := v.prefixes[key]; isSection && (!found || keyType == KeyTypeAny) {
+		// the sections are also known keys, without a type
+		if section, ok := value.(map[interface{}]interface{}); ok {
+			v.validateSection(key, section)
+		} else if value != nil && !found {
+			v.errors = append(v.errors, &WrongTypeError{Key: key, Expected: KeyTypeMap, Value: value})
+		}
+		return
+	}
+
+	if found {
+		if value != nil && !valueMatchesType(value, keyType) {
+			v.errors = append(v.errors, &WrongTypeError{Key: key, Expected: keyType, Value: value})
+		}
+		return
+	}
+
+	if v.matchesWildcard(key) {
+		return
+	}
+
+	v.errors = append(v.errors, &UnknownKeyError{Key: key, Suggestion: v.suggest(key)})
+}
+
+// matchesWildcard returns whether a key is a sub-key of a key ending with the
+// ".*" wildcard
+func (v *schemaValidator) matchesWildcard(key string) bool {
+	parts := strings.Split(key, ".")
+	for i := 1; i < len(parts); i++ {
+		if _, found := v.schema[strings.Join(parts[:i], ".")+".*"]; found {
+			return true
+		}
+	}
+	return false
+}
+
+// suggest returns the known key closest to an unknown key, if close enough
+func (v *schemaValidator) suggest(key string) string {
+	suggestion := ""
+	bestDistance := maxSuggestionDistance + 1
+	for known := range v.schema {
+		if strings.HasSuffix(known, ".*") {
+			continue
+		}
+		if distance := editDistance(key, known); distance < bestDistance || (distance == bestDistance && known < suggestion) {
+			suggestion, bestDistance = known, distance
+		}
+	}
+	// short keys are too likely to be close to an unrelated key
+	if bestDistance >= len(key)/2 {
+		return ""
+	}
+	return suggestion
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min3(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// valueMatchesType returns whether a value parsed from YAML can be used as a
+// value of the given type, following the conversions done when reading the
+// configuration
+func valueMatchesType(value interface{}, keyType KeyType) bool {
+	switch keyType {
+	case KeyTypeBool:
+		switch v := value.(type) {
+		case bool, int:
+			return true
+		case string:
+			_, err := strconv.ParseBool(strings.TrimSpace(v))
+			return err == nil
+		}
+		return false
+	case KeyTypeInt:
+		switch v := value.(type) {
+		case int, int64, uint64:
+			return true
+		case string:
+			_, err := strconv.Atoi(strings.TrimSpace(v))
+			return err == nil
+		}
+		return false
+	case KeyTypeFloat:
+		switch v := value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		case string:
+			_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			return err == nil
+		}
+		return false
+	case KeyTypeDuration:
+		switch v := value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		case string:
+			if _, err := time.ParseDuration(strings.TrimSpace(v)); err == nil {
+				return true
+			}
+			_, err := strconv.Atoi(strings.TrimSpace(v))
+			return err == nil
+		}
+		return false
+	case KeyTypeString:
+		switch value.(type) {
+		case []interface{}, map[interface{}]interface{}:
+			return false
+		}
+		return true
+	case KeyTypeList:
+		switch value.(type) {
+		case []interface{}, string:
+			return true
+		}
+		return false
+	case KeyTypeMap:
+		switch value.(type) {
+		case map[interface{}]interface{}, string:
+			return true
+		}
+		return false
+	}
+	return true
+}
+
+// describeValue describes a value parsed from YAML in validation errors
+func describeValue(value interface{}) string {
+	switch v := value.(type) {
+	case []interface{}:
+		return "a list"
+	case map[interface{}]interface{}:
+		return "a map"
+	case string:
+		return fmt.Sprintf("the string %q", v)
+	case bool:
+		return fmt.Sprintf("the bool %t", v)
+	}
+	return fmt.Sprintf("the %T %v", value, value)
+}
+
+// validationErrorKey returns the key of a validation error
+func validationErrorKey(err error) string {
+	switch e := err.(type) {
+	case *UnknownKeyError:
+		return e.Key
+	case *WrongTypeError:
+		return e.Key
+	case *DeprecatedKeyError:
+		return e.Key
+	}
+	return ""
+}
@@ -0,0 +1,116 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSchema(t *testing.T) {
+	config := NewConfig("test", "DD", strings.NewReplacer(".", "_"))
+	config.BindEnvAndSetDefault("logs_enabled", false)
+	config.BindEnvAndSetDefault("dogstatsd_port", 8125)
+	config.BindEnvAndSetDefault("histogram_percentiles", []string{"0.95"})
+	config.BindEnvAndSetDefault("compliance_config.check_interval", 20*time.Minute)
+	config.BindEnvAndSetDefault("container_env_as_tags", map[string]string{})
+	config.BindEnv("api_key")
+	config.SetKnown("apm_config.analyzed_rate_by_service.*")
+
+	schema := GenerateSchema(config)
+	assert.Equal(t, KeyTypeBool, schema["logs_enabled"])
+	assert.Equal(t, KeyTypeInt, schema["dogstatsd_port"])
+	assert.Equal(t, KeyTypeList, schema["histogram_percentiles"])
+	assert.Equal(t, KeyTypeDuration, schema["compliance_config.check_interval"])
+	assert.Equal(t, KeyTypeMap, schema["container_env_as_tags"])
+	assert.Equal(t, KeyTypeAny, schema["api_key"])
+	assert.Equal(t, KeyTypeAny, schema["apm_config.analyzed_rate_by_service.*"])
+}
+
+func TestValidateConfig(t *testing.T) {
+	schema := Schema{
+		"api_key":                               KeyTypeAny,
+		"logs_enabled":                          KeyTypeBool,
+		"log_enabled":                           KeyTypeBool,
+		"dogstatsd_port":                        KeyTypeInt,
+		"histogram_percentiles":                 KeyTypeList,
+		"logs_config":                           KeyTypeAny,
+		"logs_config.container_collect_all":     KeyTypeBool,
+		"logs_config.force_use_http":            KeyTypeBool,
+		"logs_config.use_http":                  KeyTypeBool,
+		"compliance_config.check_interval":      KeyTypeDuration,
+		"container_env_as_tags":                 KeyTypeMap,
+		"apm_config.analyzed_rate_by_service.*": KeyTypeAny,
+	}
+
+	validationErrors, err := ValidateConfig(schema, []byte(`
+api_key: abcdef
+logs_enable: true
+log_enabled: true
+dogstatsd_port: "8125"
+histogram_percentiles: 0.95
+logs_config:
+  container_collect_all: "yes"
+  use_htp: true
+  use_http: true
+compliance_config:
+  check_interval: 20m
+container_env_as_tags:
+  TEAM: team
+apm_config:
+  analyzed_rate_by_service:
+    web: 1
+unknown_section:
+  enabled: true
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []error{
+		&WrongTypeError{Key: "histogram_percentiles", Expected: KeyTypeList, Value: 0.95},
+		&DeprecatedKeyError{Key: "log_enabled", Replacement: "logs_enabled"},
+		&WrongTypeError{Key: "logs_config.container_collect_all", Expected: KeyTypeBool, Value: "yes"},
+		&UnknownKeyError{Key: "logs_config.use_htp", Suggestion: "logs_config.use_http"},
+		&DeprecatedKeyError{Key: "logs_config.use_http", Replacement: "logs_config.force_use_http"},
+		&UnknownKeyError{Key: "logs_enable", Suggestion: "logs_enabled"},
+		&UnknownKeyError{Key: "unknown_section"},
+	}, validationErrors)
+
+	assert.Equal(t, "unknown key 'logs_enable', did you mean 'logs_enabled'?", validationErrors[5].Error())
+	assert.Equal(t, "unknown key 'unknown_section'", validationErrors[6].Error())
+	assert.Equal(t, "key 'logs_config.container_collect_all' expects a bool, got the string \"yes\"", validationErrors[2].Error())
+	assert.Equal(t, "key 'log_enabled' is deprecated, use 'logs_enabled' instead", validationErrors[1].Error())
+}
+
+func TestValidateConfigInvalidYAML(t *testing.T) {
+	_, err := ValidateConfig(Schema{}, []byte("logs_enabled: [true"))
+	assert.Error(t, err)
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("logs_enabled: true\nlogs_enabeld: true\n"), 0644))
+
+	validationErrors, err := ValidateConfigFile(Datadog, configPath)
+	require.NoError(t, err)
+	require.Len(t, validationErrors, 1)
+
+	var unknownKeyErr *UnknownKeyError
+	require.ErrorAs(t, validationErrors[0], &unknownKeyErr)
+	assert.Equal(t, "logs_enabled", unknownKeyErr.Suggestion)
+}
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("logs_enabled", "logs_enabled"))
+	assert.Equal(t, 1, editDistance("logs_enable", "logs_enabled"))
+	assert.Equal(t, 2, editDistance("logs_enabeld", "logs_enabled"))
+	assert.Equal(t, 3, editDistance("", "abc"))
+}
@@ -13,6 +13,7 @@ type Client interface {
 	Set(key string, value string) (bool, error)
 	List() (map[string]RuntimeSettingResponse, error)
 	FullConfig() (string, error)
+	FullConfigWithSources() (string, error)
 }
 
 // ClientBuilder represents a function returning a runtime settings API client
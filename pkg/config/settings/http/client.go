@@ -43,6 +43,22 @@ func (rc *runtimeSettingsHTTPClient) FullConfig() (string, error) {
 	return string(r), nil
 }
 
+func (rc *runtimeSettingsHTTPClient) FullConfigWithSources() (string, error) {
+	r, err := util.DoGet(rc.c, fmt.Sprintf("%s/%s", rc.baseURL, "with-sources"), util.LeaveConnectionOpen)
+	if err != nil {
+		var errMap = make(map[string]string)
+		_ = json.Unmarshal(r, &errMap)
+		// If the error has been marshalled into a json object, check it and return it properly
+		if e, found := errMap["error"]; found {
+			return "", fmt.Errorf(e)
+		}
+
+		return "", fmt.Errorf("Could not reach %s: %v \nMake sure the %s is running before requesting the runtime configuration and contact support if you continue having issues", rc.targetProcessName, err, rc.targetProcessName)
+	}
+
+	return string(r), nil
+}
+
 func (rc *runtimeSettingsHTTPClient) List() (map[string]settings.RuntimeSettingResponse, error) {
 	r, err := util.DoGet(rc.c, fmt.Sprintf("%s/%s", rc.baseURL, "list-runtime"), util.LeaveConnectionOpen)
 	if err != nil {
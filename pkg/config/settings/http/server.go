@@ -21,17 +21,19 @@ import (
 
 // Server offers functions that implement the standard runtime settings HTTP API
 var Server = struct {
-	GetFullDatadogConfig     func(...string) http.HandlerFunc
-	GetFullSystemProbeConfig func(...string) http.HandlerFunc
-	GetValue                 http.HandlerFunc
-	SetValue                 http.HandlerFunc
-	ListConfigurable         http.HandlerFunc
+	GetFullDatadogConfig            func(...string) http.HandlerFunc
+	GetFullSystemProbeConfig        func(...string) http.HandlerFunc
+	GetFullDatadogConfigWithSources http.HandlerFunc
+	GetValue                        http.HandlerFunc
+	SetValue                        http.HandlerFunc
+	ListConfigurable                http.HandlerFunc
 }{
-	GetFullDatadogConfig:     getGlobalFullConfig(ddconfig.Datadog),
-	GetFullSystemProbeConfig: getGlobalFullConfig(ddconfig.SystemProbe),
-	GetValue:                 getConfigValue,
-	SetValue:                 setConfigValue,
-	ListConfigurable:         listConfigurableSettings,
+	GetFullDatadogConfig:            getGlobalFullConfig(ddconfig.Datadog),
+	GetFullSystemProbeConfig:        getGlobalFullConfig(ddconfig.SystemProbe),
+	GetFullDatadogConfigWithSources: getFullConfigWithSources(ddconfig.Datadog),
+	GetValue:                        getConfigValue,
+	SetValue:                        setConfigValue,
+	ListConfigurable:                listConfigurableSettings,
 }
 
 func getGlobalFullConfig(cfg ddconfig.Config) func(...string) http.HandlerFunc {
@@ -94,6 +96,21 @@ func getFullConfig(cfg ddconfig.Config, namespaces ...string) http.HandlerFunc {
 	}
 }
 
+func getFullConfigWithSources(cfg ddconfig.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		// the values are scrubbed by AllSettingsWithSources
+		runtimeConfig, err := yaml.Marshal(ddconfig.AllSettingsWithSources(cfg))
+		if err != nil {
+			log.Errorf("Unable to marshal runtime config with sources response: %s", err)
+			body, _ := json.Marshal(map[string]string{"error": err.Error()})
+			http.Error(w, string(body), http.StatusInternalServerError)
+			return
+		}
+
+		_, _ = w.Write(runtimeConfig)
+	}
+}
+
 func listConfigurableSettings(w http.ResponseWriter, _ *http.Request) {
 	configurableSettings := make(map[string]settings.RuntimeSettingResponse)
 	for name, setting := range settings.RuntimeSettings() {
@@ -145,7 +162,7 @@ func setConfigValue(w http.ResponseWriter, r *http.Request) {
 	_ = r.ParseForm()
 	value := html.UnescapeString(r.Form.Get("value"))
 
-	if err := settings.SetRuntimeSetting(setting, value); err != nil {
+	if err := settings.SetRuntimeSetting(setting, value, ddconfig.SourceRuntimeSetting); err != nil {
 		body, _ := json.Marshal(map[string]string{"error": err.Error()})
 		switch err.(type) {
 		case *settings.SettingNotFoundError:
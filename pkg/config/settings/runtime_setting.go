@@ -10,6 +10,8 @@ import (
 	"fmt"
 	"strconv"
 	"sync"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
 )
 
 var runtimeSettings = make(map[string]RuntimeSetting)
@@ -39,6 +41,12 @@ type RuntimeSetting interface {
 	Hidden() bool
 }
 
+// RuntimeSettingWithSource is implemented by the runtime settings which record
+// the source of their changes in the configuration.
+type RuntimeSettingWithSource interface {
+	SetWithSource(v interface{}, source config.Source) error
+}
+
 // RegisterRuntimeSetting keeps track of configurable settings
 func RegisterRuntimeSetting(setting RuntimeSetting) error {
 	if _, ok := runtimeSettings[setting.Name()]; ok {
@@ -53,13 +61,17 @@ func RuntimeSettings() map[string]RuntimeSetting {
 	return runtimeSettings
 }
 
-// SetRuntimeSetting changes the value of a runtime configurable setting
-func SetRuntimeSetting(setting string, value interface{}) error {
+// SetRuntimeSetting changes the value of a runtime configurable setting, recording
+// the source of the change
+func SetRuntimeSetting(setting string, value interface{}, source config.Source) error {
 	runtimeSettingsLock.Lock()
 	defer runtimeSettingsLock.Unlock()
 	if _, ok := runtimeSettings[setting]; !ok {
 		return &SettingNotFoundError{name: setting}
 	}
+	if s, ok := runtimeSettings[setting].(RuntimeSettingWithSource); ok {
+		return s.SetWithSource(value, source)
+	}
 	return runtimeSettings[setting].Set(value)
 }
 
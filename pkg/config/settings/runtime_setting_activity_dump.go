@@ -45,19 +45,24 @@ func (l ActivityDumpRuntimeSetting) Get() (interface{}, error) {
 	return val, nil
 }
 
-func (l ActivityDumpRuntimeSetting) setMaxDumpSize(v interface{}) {
+func (l ActivityDumpRuntimeSetting) setMaxDumpSize(v interface{}, source config.Source) {
 	intVar, _ := strconv.Atoi(v.(string))
-	config.SystemProbe.Set(l.ConfigKey, intVar)
+	config.SystemProbe.SetWithSource(l.ConfigKey, intVar, source)
 }
 
 // Set changes the value of the runtime setting
 func (l ActivityDumpRuntimeSetting) Set(v interface{}) error {
+	return l.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (l ActivityDumpRuntimeSetting) SetWithSource(v interface{}, source config.Source) error {
 	val := v.(string)
 	log.Infof("ActivityDumpRuntimeSetting Set %s = %s\n", l.ConfigKey, val)
 
 	switch l.ConfigKey {
 	case MaxDumpSizeConfKey:
-		l.setMaxDumpSize(v)
+		l.setMaxDumpSize(v, source)
 	default:
 		return fmt.Errorf("Field %s does not exist", l.ConfigKey)
 	}
@@ -41,6 +41,11 @@ func (r RuntimeBlockProfileRate) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (r RuntimeBlockProfileRate) Set(value interface{}) error {
+	return r.SetWithSource(value, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (r RuntimeBlockProfileRate) SetWithSource(value interface{}, source config.Source) error {
 	rate, err := GetInt(value)
 	if err != nil {
 		return err
@@ -53,7 +58,7 @@ func (r RuntimeBlockProfileRate) Set(value interface{}) error {
 	if r.Config != nil {
 		cfg = r.Config
 	}
-	cfg.Set(r.ConfigPrefix+"internal_profiling.block_profile_rate", rate)
+	cfg.SetWithSource(r.ConfigPrefix+"internal_profiling.block_profile_rate", rate, source)
 
 	return err
 }
@@ -43,6 +43,11 @@ func (l LogLevelRuntimeSetting) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (l LogLevelRuntimeSetting) Set(v interface{}) error {
+	return l.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (l LogLevelRuntimeSetting) SetWithSource(v interface{}, source config.Source) error {
 	logLevel := v.(string)
 	err := config.ChangeLogLevel(logLevel)
 	if err != nil {
@@ -56,7 +61,7 @@ func (l LogLevelRuntimeSetting) Set(v interface{}) error {
 	if l.Config != nil {
 		cfg = l.Config
 	}
-	cfg.Set(key, logLevel)
+	cfg.SetWithSource(key, logLevel, source)
 	// we trigger a new inventory metadata payload since the configuration was updated by the user.
 	inventories.Refresh()
 	return nil
@@ -37,6 +37,11 @@ func (l LogPayloadsRuntimeSetting) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (l LogPayloadsRuntimeSetting) Set(v interface{}) error {
+	return l.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (l LogPayloadsRuntimeSetting) SetWithSource(v interface{}, source config.Source) error {
 	var newValue bool
 	var err error
 
@@ -44,6 +49,6 @@ func (l LogPayloadsRuntimeSetting) Set(v interface{}) error {
 		return fmt.Errorf("LogPayloadsRuntimeSetting: %v", err)
 	}
 
-	config.Datadog.Set("log_payloads", newValue)
+	config.Datadog.SetWithSource("log_payloads", newValue, source)
 	return nil
 }
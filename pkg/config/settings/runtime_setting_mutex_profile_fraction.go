@@ -41,6 +41,11 @@ func (r RuntimeMutexProfileFraction) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (r RuntimeMutexProfileFraction) Set(value interface{}) error {
+	return r.SetWithSource(value, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (r RuntimeMutexProfileFraction) SetWithSource(value interface{}, source config.Source) error {
 	rate, err := GetInt(value)
 	if err != nil {
 		return err
@@ -53,7 +58,7 @@ func (r RuntimeMutexProfileFraction) Set(value interface{}) error {
 	if r.Config != nil {
 		cfg = r.Config
 	}
-	cfg.Set(r.ConfigPrefix+"internal_profiling.mutex_profile_fraction", rate)
+	cfg.SetWithSource(r.ConfigPrefix+"internal_profiling.mutex_profile_fraction", rate, source)
 
 	return err
 }
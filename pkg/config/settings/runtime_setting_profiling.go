@@ -49,14 +49,19 @@ func (l ProfilingRuntimeSetting) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (l ProfilingRuntimeSetting) Set(v interface{}) error {
+	return l.SetWithSource(v, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (l ProfilingRuntimeSetting) SetWithSource(v interface{}, source config.Source) error {
 	var profile bool
 	var err error
 
 	if v, ok := v.(string); ok && strings.ToLower(v) == "restart" {
-		if err := l.Set(false); err != nil {
+		if err := l.SetWithSource(false, source); err != nil {
 			return err
 		}
-		return l.Set(true)
+		return l.SetWithSource(true, source)
 	}
 
 	profile, err = GetBool(v)
@@ -101,11 +106,11 @@ func (l ProfilingRuntimeSetting) Set(v interface{}) error {
 		}
 		err := profiling.Start(settings)
 		if err == nil {
-			cfg.Set(l.ConfigPrefix+"internal_profiling.enabled", true)
+			cfg.SetWithSource(l.ConfigPrefix+"internal_profiling.enabled", true, source)
 		}
 	} else {
 		profiling.Stop()
-		cfg.Set(l.ConfigPrefix+"internal_profiling.enabled", false)
+		cfg.SetWithSource(l.ConfigPrefix+"internal_profiling.enabled", false, source)
 	}
 
 	return nil
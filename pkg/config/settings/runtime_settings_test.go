@@ -54,7 +54,7 @@ func TestRuntimeSettings(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, runtimeSetting.value, v)
 
-	err = SetRuntimeSetting(runtimeSetting.Name(), 123)
+	err = SetRuntimeSetting(runtimeSetting.Name(), 123, config.SourceRuntimeSetting)
 	assert.Nil(t, err)
 
 	v, err = GetRuntimeSetting(runtimeSetting.Name())
@@ -96,6 +96,31 @@ func TestLogLevel(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestSetRuntimeSettingSource(t *testing.T) {
+	cleanRuntimeSetting()
+	config.SetupLogger("TEST", "debug", "", "", true, true, true)
+	assert.Nil(t, RegisterRuntimeSetting(LogLevelRuntimeSetting{}))
+
+	err := SetRuntimeSetting("log_level", "warn", config.SourceRC)
+	assert.Nil(t, err)
+	assert.Equal(t, config.SourceRC, config.Datadog.GetSource("log_level"))
+
+	err = SetRuntimeSetting("log_level", "info", config.SourceRuntimeSetting)
+	assert.Nil(t, err)
+	assert.Equal(t, "info", config.Datadog.GetString("log_level"))
+	assert.Equal(t, config.SourceRuntimeSetting, config.Datadog.GetSource("log_level"))
+
+	// the source is recorded even when the value doesn't change
+	err = SetRuntimeSetting("log_level", "info", config.SourceRC)
+	assert.Nil(t, err)
+	assert.Equal(t, config.SourceRC, config.Datadog.GetSource("log_level"))
+
+	// the source is left untouched when the setting fails
+	err = SetRuntimeSetting("log_level", "invalid", config.SourceRuntimeSetting)
+	assert.NotNil(t, err)
+	assert.Equal(t, config.SourceRC, config.Datadog.GetSource("log_level"))
+}
+
 func TestProfiling(t *testing.T) {
 	cleanRuntimeSetting()
 	config.SetupConf()
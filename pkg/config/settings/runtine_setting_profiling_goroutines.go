@@ -41,6 +41,11 @@ func (r ProfilingGoroutines) Get() (interface{}, error) {
 
 // Set changes the value of the runtime setting
 func (r ProfilingGoroutines) Set(value interface{}) error {
+	return r.SetWithSource(value, config.SourceAgentRuntime)
+}
+
+// SetWithSource changes the value of the runtime setting, recording the source of the change
+func (r ProfilingGoroutines) SetWithSource(value interface{}, source config.Source) error {
 	enabled, err := GetBool(value)
 	if err != nil {
 		return err
@@ -50,7 +55,7 @@ func (r ProfilingGoroutines) Set(value interface{}) error {
 	if r.Config != nil {
 		cfg = r.Config
 	}
-	cfg.Set(r.ConfigPrefix+"internal_profiling.enable_goroutine_stacktraces", enabled)
+	cfg.SetWithSource(r.ConfigPrefix+"internal_profiling.enable_goroutine_stacktraces", enabled, source)
 
 	return nil
 }
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/util/scrubber"
+)
+
+// Source is the layer of the configuration the value of a key comes from
+type Source string
+
+// Sources of the configuration values
+const (
+	// SourceDefault is the default value of the key
+	SourceDefault Source = "default"
+	// SourceFile is the configuration file
+	SourceFile Source = "file"
+	// SourceEnvVar is an env var bound to the key
+	SourceEnvVar Source = "environment-variable"
+	// SourceRC is remote configuration
+	SourceRC Source = "remote-config"
+	// SourceRuntimeSetting is a runtime setting changed through the API or
+	// the CLI of the agent
+	SourceRuntimeSetting Source = "runtime-setting"
+	// SourceAgentRuntime is the agent itself, setting the value at runtime
+	SourceAgentRuntime Source = "agent-runtime"
+)
+
+// ValueWithSource is the value of a key along with its source
+type ValueWithSource struct {
+	Value  interface{} `yaml:"value" json:"value"`
+	Source Source      `yaml:"source" json:"source"`
+}
+
+// isInLoadedFile returns whether a key, one of its sections or one of its
+// sub-keys is set in the configuration file loaded by a configuration
+func isInLoadedFile(config Config, key string) bool {
+	reloadMu.Lock()
+	defer reloadMu.Unlock()
+
+	loaded, found := loadedFiles[config]
+	if !found {
+		return false
+	}
+
+	parts := strings.Split(key, ".")
+	for i := 1; i <= len(parts); i++ {
+		if _, found := loaded.settings[strings.Join(parts[:i], ".")]; found {
+			return true
+		}
+	}
+	for fileKey := range loaded.settings {
+		if strings.HasPrefix(fileKey, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// setWithSourceOf sets the value of a key computed by the agent at load time from
+// the value of sourceKey, which may be the key itself, recording the source of
+// sourceKey rather than the agent runtime
+func setWithSourceOf(config Config, key string, value interface{}, sourceKey string) {
+	config.SetWithSource(key, value, config.GetSource(sourceKey))
+}
+
+// AllSettingsWithSources returns the value and the source of every key having
+// a value, indexed by key. The values are scrubbed from credentials.
+func AllSettingsWithSources(config ConfigReader) map[string]ValueWithSource {
+	settings := map[string]ValueWithSource{}
+	for _, key := range config.AllKeys() {
+		value := config.Get(key)
+		if value == nil {
+			continue
+		}
+		settings[key] = ValueWithSource{
+			Value:  scrubValue(key, value),
+			Source: config.GetSource(key),
+		}
+	}
+	return settings
+}
+
+// scrubValue scrubs the credentials from the value of a key. The value is
+// scrubbed under the last part of the key, as the scrubber recognizes the
+// sensitive settings by their name.
+func scrubValue(key string, value interface{}) interface{} {
+	name := key[strings.LastIndex(key, ".")+1:]
+	const redacted = "********"
+
+	data, err := yaml.Marshal(map[string]interface{}{name: value})
+	if err != nil {
+		return redacted
+	}
+	scrubbed, err := scrubber.ScrubYaml(data)
+	if err != nil {
+		return redacted
+	}
+	var scrubbedValue map[string]interface{}
+	if err := yaml.Unmarshal(scrubbed, &scrubbedValue); err != nil {
+		return redacted
+	}
+	return scrubbedValue[name]
+}
@@ -0,0 +1,105 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSource(t *testing.T) {
+	t.Setenv("DD_EXTRA_TAGS", "env:prod")
+	config, _ := setupReloadableConfig(t, `
+tags:
+  - team:a
+logs_config:
+  container_collect_all: true
+`)
+
+	assert.Equal(t, SourceFile, config.GetSource("tags"))
+	assert.Equal(t, SourceFile, config.GetSource("logs_config.container_collect_all"))
+	assert.Equal(t, SourceFile, config.GetSource("logs_config"))
+	assert.Equal(t, SourceEnvVar, config.GetSource("extra_tags"))
+	assert.Equal(t, SourceDefault, config.GetSource("dogstatsd_port"))
+
+	config.Set("dogstatsd_port", 8126)
+	assert.Equal(t, SourceAgentRuntime, config.GetSource("dogstatsd_port"))
+
+	config.SetWithSource("Tags", []string{"team:b"}, SourceRuntimeSetting)
+	assert.Equal(t, SourceRuntimeSetting, config.GetSource("tags"))
+
+	config.SetWithSource("extra_tags", []string{"env:staging"}, SourceRC)
+	assert.Equal(t, SourceRC, config.GetSource("extra_tags"))
+}
+
+func TestGetSourceAfterReload(t *testing.T) {
+	config, configPath := setupReloadableConfig(t, "tags:\n  - team:a\n")
+	RegisterReloadable("test tags", func() error { return nil }, "tags", "extra_tags")
+
+	config.SetWithSource("tags", []string{"team:b"}, SourceRuntimeSetting)
+	require.NoError(t, os.WriteFile(configPath, []byte("extra_tags:\n  - env:prod\n"), 0644))
+	_, err := Reload(config)
+	require.NoError(t, err)
+
+	assert.Equal(t, SourceDefault, config.GetSource("tags"))
+	assert.Equal(t, SourceFile, config.GetSource("extra_tags"))
+}
+
+func TestAllSettingsWithSources(t *testing.T) {
+	config, _ := setupReloadableConfig(t, "tags:\n  - team:a\n")
+	config.BindEnvAndSetDefault("api_key", "")
+	config.SetWithSource("api_key", "aaaaaaaaaaaaaaaaaaaaaaaaaaaabbbb", SourceRuntimeSetting)
+
+	settings := AllSettingsWithSources(config)
+	assert.Equal(t, ValueWithSource{Value: []interface{}{"team:a"}, Source: SourceFile}, settings["tags"])
+	assert.Equal(t, ValueWithSource{Value: 8125, Source: SourceDefault}, settings["dogstatsd_port"])
+	assert.Equal(t, ValueWithSource{Value: "***************************abbbb", Source: SourceRuntimeSetting}, settings["api_key"])
+	assert.NotContains(t, settings, "logs_config.processing_rules")
+}
+
+func TestLoadTimeSettingsSources(t *testing.T) {
+	// ignore the overrides added by the other tests
+	defer func(old map[string]interface{}) { overrideVars = old }(overrideVars)
+	overrideVars = map[string]interface{}{}
+
+	loadConfig := func(content string) Config {
+		configPath := filepath.Join(t.TempDir(), "datadog.yaml")
+		require.NoError(t, os.WriteFile(configPath, []byte(content), 0644))
+		config := SetupConf()
+		config.SetConfigFile(configPath)
+		_, err := LoadDatadogCustom(config, "unit_test", false, nil)
+		require.NoError(t, err)
+		return config
+	}
+	t.Setenv("DD_PROXY_HTTP", "http://proxy.env")
+	t.Setenv("NO_PROXY", "a,b")
+
+	// the values set by the agent while loading the configuration keep the
+	// source of the settings they come from
+	config := loadConfig(`
+proxy:
+  https: https://proxy.file
+check_runners: 2
+`)
+	assert.Equal(t, "http://proxy.env", config.GetString("proxy.http"))
+	assert.Equal(t, SourceEnvVar, config.GetSource("proxy.http"))
+	assert.Equal(t, SourceEnvVar, config.GetSource("proxy.no_proxy"))
+	assert.Equal(t, SourceFile, config.GetSource("proxy.https"))
+	assert.Equal(t, SourceFile, config.GetSource("check_runners"))
+	assert.Equal(t, SourceDefault, config.GetSource("tracemalloc_debug"))
+
+	config = loadConfig(`
+fips:
+  enabled: true
+  local_address: localhost
+`)
+	assert.Equal(t, SourceFile, config.GetSource("dd_url"))
+	assert.Equal(t, SourceFile, config.GetSource("logs_config.logs_dd_url"))
+}
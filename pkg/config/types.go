@@ -59,10 +59,20 @@ type ConfigReader interface {
 	// 1) have a default, 2) have an environment variable binded, 3) are an alias or 4) have been SetKnown()
 	GetKnownKeys() map[string]interface{}
 
+	// GetDefaults returns the default values of the keys, indexed by key
+	GetDefaults() map[string]interface{}
+
 	// GetEnvVars returns a list of the env vars that the config supports.
 	// These have had the EnvPrefix applied, as well as the EnvKeyReplacer.
 	GetEnvVars() []string
 
+	// IsSetByEnv returns whether the value of a key is set by one of the env
+	// vars bound to it
+	IsSetByEnv(key string) bool
+
+	// GetSource returns the source of the value of a key
+	GetSource(key string) Source
+
 	// IsSectionSet checks if a given section is set by checking if any of
 	// its subkeys is set.
 	IsSectionSet(section string) bool
@@ -72,6 +82,8 @@ type ConfigReader interface {
 
 type ConfigWriter interface {
 	Set(key string, value interface{})
+	// SetWithSource sets the value of a key, recording where it comes from
+	SetWithSource(key string, value interface{}, source Source)
 	CopyConfig(cfg Config)
 }
 
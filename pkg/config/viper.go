@@ -8,6 +8,7 @@ package config
 import (
 	"fmt"
 	"io"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -36,13 +37,52 @@ type safeConfig struct {
 	// configEnvVars is the set of env vars that are consulted for
 	// configuration values.
 	configEnvVars map[string]struct{}
+	// keyEnvVars are the env vars bound to each key, indexed by key
+	keyEnvVars map[string][]string
+
+	// sources are the sources of the values set with Set or SetWithSource,
+	// indexed by key
+	sources map[string]Source
+
+	// defaults are the default values of the keys, used to infer the types
+	// of the keys in the configuration schema
+	defaults map[string]interface{}
 }
 
-// Set wraps Viper for concurrent access
+// Set wraps Viper for concurrent access, recording the agent as the source
+// of the value
 func (c *safeConfig) Set(key string, value interface{}) {
+	c.SetWithSource(key, value, SourceAgentRuntime)
+}
+
+// SetWithSource wraps Viper for concurrent access, and records the source of
+// the value
+func (c *safeConfig) SetWithSource(key string, value interface{}, source Source) {
 	c.Lock()
 	defer c.Unlock()
 	c.Viper.Set(key, value)
+	c.sources[strings.ToLower(key)] = source
+}
+
+// GetSource returns the source of the value of a key: the source recorded
+// when it was set, otherwise the env vars, the configuration file and the
+// default value, in this order of precedence
+func (c *safeConfig) GetSource(key string) Source {
+	key = strings.ToLower(key)
+	c.RLock()
+	source, found := c.sources[key]
+	c.RUnlock()
+
+	if found {
+		return source
+	}
+	if c.IsSetByEnv(key) {
+		return SourceEnvVar
+	}
+	if isInLoadedFile(c, key) {
+		return SourceFile
+	}
+	return SourceDefault
 }
 
 // SetDefault wraps Viper for concurrent access
@@ -50,6 +90,19 @@ func (c *safeConfig) SetDefault(key string, value interface{}) {
 	c.Lock()
 	defer c.Unlock()
 	c.Viper.SetDefault(key, value)
+	c.defaults[strings.ToLower(key)] = value
+}
+
+// GetDefaults returns the default values of the keys, indexed by key
+func (c *safeConfig) GetDefaults() map[string]interface{} {
+	c.RLock()
+	defer c.RUnlock()
+
+	defaults := make(map[string]interface{}, len(c.defaults))
+	for key, value := range c.defaults {
+		defaults[key] = value
+	}
+	return defaults
 }
 
 // SetKnown adds a key to the set of known valid config keys
@@ -336,12 +389,14 @@ func (c *safeConfig) BindEnv(input ...string) {
 		envKeys = input[1:]
 	}
 
+	configKey := strings.ToLower(input[0])
 	for _, key := range envKeys {
 		// apply EnvKeyReplacer to each key
 		if c.envKeyReplacer != nil {
 			key = c.envKeyReplacer.Replace(key)
 		}
 		c.configEnvVars[key] = struct{}{}
+		c.keyEnvVars[configKey] = append(c.keyEnvVars[configKey], key)
 	}
 
 	_ = c.Viper.BindEnv(input...)
@@ -476,6 +531,20 @@ func (c *safeConfig) GetEnvVars() []string {
 	return vars
 }
 
+// IsSetByEnv returns whether the value of a key is set by one of the env vars
+// bound to it
+func (c *safeConfig) IsSetByEnv(key string) bool {
+	c.RLock()
+	defer c.RUnlock()
+	for _, envVar := range c.keyEnvVars[strings.ToLower(key)] {
+		// like viper, ignore the env vars set to an empty value
+		if value, found := os.LookupEnv(envVar); found && value != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // BindEnvAndSetDefault implements the Config interface
 func (c *safeConfig) BindEnvAndSetDefault(key string, val interface{}, env ...string) {
 	c.SetDefault(key, val)
@@ -491,6 +560,9 @@ func NewConfig(name string, envPrefix string, envKeyReplacer *strings.Replacer)
 	config := safeConfig{
 		Viper:         viper.New(),
 		configEnvVars: map[string]struct{}{},
+		keyEnvVars:    map[string][]string{},
+		sources:       map[string]Source{},
+		defaults:      map[string]interface{}{},
 	}
 	config.SetConfigName(name)
 	config.SetEnvPrefix(envPrefix)
@@ -510,6 +582,9 @@ func (c *safeConfig) CopyConfig(cfg Config) {
 		c.envPrefix = cfg.envPrefix
 		c.envKeyReplacer = cfg.envKeyReplacer
 		c.configEnvVars = cfg.configEnvVars
+		c.keyEnvVars = cfg.keyEnvVars
+		c.sources = cfg.sources
+		c.defaults = cfg.defaults
 		return
 	}
 	panic("Replacement config must be an instance of safeConfig")
@@ -87,6 +87,10 @@ func CompleteFlare(fb flarehelpers.FlareBuilder) error {
 
 	fb.AddFileFromFunc("process_agent_runtime_config_dump.yaml", getProcessAgentFullConfig)
 	fb.AddFileFromFunc("runtime_config_dump.yaml", func() ([]byte, error) { return yaml.Marshal(config.Datadog.AllSettings()) })
+	fb.AddFileFromFunc("runtime_config_dump_with_sources.yaml", func() ([]byte, error) {
+		return yaml.Marshal(config.AllSettingsWithSources(config.Datadog))
+	})
+	fb.AddFileFromFunc("config-validation.log", getConfigValidation)
 	fb.AddFileFromFunc("system_probe_runtime_config_dump.yaml", func() ([]byte, error) { return yaml.Marshal(config.SystemProbe.AllSettings()) })
 	fb.AddFileFromFunc("diagnose.log", func() ([]byte, error) { return functionOutputToBytes(diagnose.RunMetadataAvail), nil })
 	fb.AddFileFromFunc("connectivity.log", getDatadogConnectivity)
@@ -313,6 +317,27 @@ func getConfigCheck() ([]byte, error) {
 	return functionOutputToBytes(fct), nil
 }
 
+// getConfigValidation validates the loaded configuration file against the
+// schema of the configuration
+func getConfigValidation() ([]byte, error) {
+	path := config.Datadog.ConfigFileUsed()
+	if path == "" {
+		return []byte("no configuration file loaded"), nil
+	}
+
+	validationErrors, err := config.ValidateConfigFile(config.Datadog, path)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "=== %d issue(s) found in %s ===\n", len(validationErrors), path)
+	for _, validationErr := range validationErrors {
+		fmt.Fprintf(&b, "%s\n", validationErr)
+	}
+	return b.Bytes(), nil
+}
+
 func getAgentTaggerList() ([]byte, error) {
 	ipcAddress, err := config.GetIPCAddress()
 	if err != nil {
@@ -307,3 +307,17 @@ func TestProcessAgentChecks(t *testing.T) {
 		mock.AssertFileContent(string(expectedProcessDiscoveryJSON), "process_discovery_check_output.json")
 	})
 }
+
+func TestConfigValidation(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "datadog.yaml")
+	require.NoError(t, os.WriteFile(configPath, []byte("logs_enabled: true\nlogs_enable: true\n"), 0644))
+
+	confMock := config.Mock(t)
+	confMock.SetConfigFile(configPath)
+	require.NoError(t, confMock.ReadInConfig())
+
+	content, err := getConfigValidation()
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "1 issue(s) found")
+	assert.Contains(t, string(content), "unknown key 'logs_enable', did you mean 'logs_enabled'?")
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/DataDog/datadog-agent/cmd/agent/api/response"
 	"github.com/DataDog/datadog-agent/pkg/api/util"
 	"github.com/DataDog/datadog-agent/pkg/autodiscovery/integration"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers"
 	checkid "github.com/DataDog/datadog-agent/pkg/collector/check/id"
 	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/util/scrubber"
@@ -64,6 +65,13 @@ func GetConfigCheck(w io.Writer, withDebug bool) error {
 		}
 	}
 
+	if len(cr.GitRepositories) > 0 {
+		fmt.Fprintln(w, fmt.Sprintf("=== Git %s ===", color.BlueString("repositories")))
+		for _, repo := range cr.GitRepositories {
+			printGitRepository(w, repo)
+		}
+	}
+
 	for _, c := range cr.Configs {
 		PrintConfig(w, c, "")
 	}
@@ -99,6 +107,24 @@ func GetClusterAgentConfigCheck(w io.Writer, withDebug bool) error {
 	return GetConfigCheck(w, withDebug)
 }
 
+// printGitRepository prints the commit a git config provider collects the
+// configs from
+func printGitRepository(w io.Writer, repo providers.GitRepositoryStatus) {
+	fmt.Fprintln(w, fmt.Sprintf("\n%s: %s", color.BlueString("Repository"), color.CyanString(repo.Repository)))
+	if repo.Branch != "" {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Branch"), repo.Branch))
+	}
+	if repo.Commit != "" {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Commit"), color.CyanString(repo.Commit)))
+	} else {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Commit"), color.RedString("none")))
+	}
+	fmt.Fprintln(w, fmt.Sprintf("%s: %t", color.BlueString("Signature verified"), repo.SignatureVerified))
+	if repo.LastError != "" {
+		fmt.Fprintln(w, fmt.Sprintf("%s: %s", color.BlueString("Last error"), color.RedString(repo.LastError)))
+	}
+}
+
 func printYaml(w io.Writer, data []byte) {
 	scrubbed, err := scrubber.ScrubYaml(data)
 	if err == nil {
@@ -66,6 +66,7 @@ var allowedEnvvarNames = []string{
 	"DD_APM_FEATURES",
 	"DD_APM_RECEIVER_SOCKET",
 	"DD_APM_REPLACE_TAGS",
+	"DD_APM_SPAN_METRICS",
 	"DD_APM_PROFILING_DD_URL",
 	"DD_APM_WINDOWS_PIPE_BUFFER_SIZE",
 	"DD_APM_REMOTE_TAGGER",
@@ -83,8 +84,13 @@ var allowedEnvvarNames = []string{
 	"DD_APM_INTERNAL_PROFILING_ENABLED",
 	"DD_APM_DEBUGGER_DD_URL",
 	"DD_APM_SYMDB_DD_URL",
+	"DD_APM_OBFUSCATION_CASSANDRA_ENABLED",
 	"DD_APM_OBFUSCATION_CREDIT_CARDS_ENABLED",
 	"DD_APM_OBFUSCATION_CREDIT_CARDS_LUHN",
+	"DD_APM_OBFUSCATION_SCRUB_RULES",
+	"DD_APM_OBFUSCATION_DYNAMODB_ENABLED",
+	"DD_APM_OBFUSCATION_DYNAMODB_KEEP_VALUES",
+	"DD_APM_OBFUSCATION_DYNAMODB_OBFUSCATE_SQL_VALUES",
 	"DD_APM_OBFUSCATION_ELASTICSEARCH_ENABLED",
 	"DD_APM_OBFUSCATION_ELASTICSEARCH_KEEP_VALUES",
 	"DD_APM_OBFUSCATION_ELASTICSEARCH_OBFUSCATE_SQL_VALUES",
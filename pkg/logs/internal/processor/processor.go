@@ -23,6 +23,7 @@ type Processor struct {
 	inputChan                 chan *message.Message
 	outputChan                chan *message.Message
 	processingRules           []*config.ProcessingRule
+	rulesMu                   sync.RWMutex
 	encoder                   Encoder
 	done                      chan struct{}
 	diagnosticMessageReceiver diagnostic.MessageReceiver
@@ -41,6 +42,14 @@ func New(inputChan, outputChan chan *message.Message, processingRules []*config.
 	}
 }
 
+// SetProcessingRules replaces the global processing rules applied to the
+// messages that haven't been processed yet.
+func (p *Processor) SetProcessingRules(processingRules []*config.ProcessingRule) {
+	p.rulesMu.Lock()
+	defer p.rulesMu.Unlock()
+	p.processingRules = processingRules
+}
+
 // Start starts the Processor.
 func (p *Processor) Start() {
 	go p.run()
@@ -108,7 +117,10 @@ func (p *Processor) processMessage(msg *message.Message) {
 // and a copy of the message with some fields redacted, depending on config
 func (p *Processor) applyRedactingRules(msg *message.Message) (bool, []byte) {
 	content := msg.Content
-	rules := append(p.processingRules, msg.Origin.LogSource.Config.ProcessingRules...)
+	p.rulesMu.RLock()
+	globalRules := p.processingRules
+	p.rulesMu.RUnlock()
+	rules := append(globalRules[:len(globalRules):len(globalRules)], msg.Origin.LogSource.Config.ProcessingRules...)
 	for _, rule := range rules {
 		switch rule.Type {
 		case config.ExcludeAtMatch:
@@ -130,6 +130,19 @@ func TestMask(t *testing.T) {
 	assert.Equal(t, []byte("New data added to data_values= on prod"), redactedMessage)
 }
 
+func TestSetProcessingRules(t *testing.T) {
+	p := &Processor{processingRules: []*config.ProcessingRule{newProcessingRule("exclude_at_match", "", "world")}}
+	source := sources.LogSource{Config: &config.LogsConfig{}}
+
+	shouldProcess, _ := p.applyRedactingRules(newMessage([]byte("hello world"), &source, ""))
+	assert.Equal(t, false, shouldProcess)
+
+	p.SetProcessingRules([]*config.ProcessingRule{newProcessingRule("mask_sequences", "[masked_world]", "world")})
+	shouldProcess, redactedMessage := p.applyRedactingRules(newMessage([]byte("hello world"), &source, ""))
+	assert.Equal(t, true, shouldProcess)
+	assert.Equal(t, []byte("hello [masked_world]"), redactedMessage)
+}
+
 func TestTruncate(t *testing.T) {
 	p := &Processor{}
 
@@ -128,6 +128,29 @@ func Flush(ctx context.Context) {
 	log.Debug("Flush in the logs-agent done.")
 }
 
+// ReloadProcessingRules applies the current global processing rules to the
+// running instance of the Logs Agent.
+func ReloadProcessingRules() error {
+	processingRules, err := config.GlobalProcessingRules()
+	if err != nil {
+		return fmt.Errorf("invalid processing rules: %v", err)
+	}
+
+	if !IsAgentRunning() || agent == nil {
+		return nil
+	}
+
+	status.RemoveGlobalWarning(invalidProcessingRules)
+	if config.HasMultiLineRule(processingRules) {
+		log.Warn(multiLineWarning)
+		status.AddGlobalWarning(invalidProcessingRules, multiLineWarning)
+	}
+
+	agent.pipelineProvider.SetProcessingRules(processingRules)
+	log.Infof("Reloaded %d global processing rules in the logs-agent", len(processingRules))
+	return nil
+}
+
 // IsAgentRunning returns true if the logs-agent is running.
 func IsAgentRunning() bool {
 	return status.Get(false).IsRunning
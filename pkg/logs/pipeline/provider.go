@@ -7,6 +7,7 @@ package pipeline
 
 import (
 	"context"
+	"sync"
 
 	"go.uber.org/atomic"
 
@@ -26,6 +27,9 @@ type Provider interface {
 	NextPipelineChan() chan *message.Message
 	// Flush flushes all pipeline contained in this Provider
 	Flush(ctx context.Context)
+	// SetProcessingRules replaces the global processing rules of all the
+	// pipelines contained in this Provider
+	SetProcessingRules(processingRules []*config.ProcessingRule)
 }
 
 // provider implements providing logic
@@ -37,6 +41,9 @@ type provider struct {
 	processingRules           []*config.ProcessingRule
 	endpoints                 *config.Endpoints
 
+	// mu protects the processing rules and the pipelines from concurrent
+	// reloads of the processing rules
+	mu                   sync.Mutex
 	pipelines            []*Pipeline
 	currentPipelineIndex *atomic.Uint32
 	destinationsContext  *client.DestinationsContext
@@ -75,6 +82,9 @@ func newProvider(numberOfPipelines int, auditor auditor.Auditor, diagnosticMessa
 
 // Start initializes the pipelines
 func (p *provider) Start() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	// This requires the auditor to be started before.
 	p.outputChan = p.auditor.Channel()
 
@@ -88,6 +98,9 @@ func (p *provider) Start() {
 // Stop stops all pipelines in parallel,
 // this call blocks until all pipelines are stopped
 func (p *provider) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	stopper := startstop.NewParallelStopper()
 	for _, pipeline := range p.pipelines {
 		stopper.Add(pipeline)
@@ -97,6 +110,17 @@ func (p *provider) Stop() {
 	p.outputChan = nil
 }
 
+// SetProcessingRules replaces the global processing rules of all the pipelines
+func (p *provider) SetProcessingRules(processingRules []*config.ProcessingRule) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.processingRules = processingRules
+	for _, pipeline := range p.pipelines {
+		pipeline.SetProcessingRules(processingRules)
+	}
+}
+
 // NextPipelineChan returns the next pipeline input channel
 func (p *provider) NextPipelineChan() chan *message.Message {
 	pipelinesLen := len(p.pipelines)
@@ -63,6 +63,12 @@ func appendAndSplitTags(target []string, tags []string, splits map[string]string
 	return target
 }
 
+// ResetHostTagsCache drops the cached host tags, so that the next call to
+// GetHostTags gets them again from the configuration and the providers
+func ResetHostTagsCache() {
+	cache.Cache.Delete(buildKey("hostTags"))
+}
+
 // GetHostTags get the host tags, optionally looking in the cache
 // There are two levels of caching:
 // - First one controlled by `cached` boolean, used for performances (cache all tags)
@@ -158,3 +158,20 @@ func TestHostTagsCache(t *testing.T) {
 	assert.NotNil(t, hostTags.System)
 	assert.Equal(t, []string{"foo1:value1"}, hostTags.System)
 }
+
+func TestResetHostTagsCache(t *testing.T) {
+	ctx := context.Background()
+	mockConfig := config.Mock(t)
+	mockConfig.Set("autoconfig_from_environment", false)
+	defer mockConfig.Set("autoconfig_from_environment", true)
+
+	mockConfig.Set("tags", []string{"tag1"})
+	defer mockConfig.Set("tags", nil)
+	assert.Equal(t, []string{"tag1"}, GetHostTags(ctx, false).System)
+
+	mockConfig.Set("tags", []string{"tag2"})
+	assert.Equal(t, []string{"tag1"}, GetHostTags(ctx, true).System)
+
+	ResetHostTagsCache()
+	assert.Equal(t, []string{"tag2"}, GetHostTags(ctx, true).System)
+}
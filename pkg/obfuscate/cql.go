@@ -0,0 +1,26 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package obfuscate
+
+// ObfuscateCQLString quantizes and obfuscates the given Cassandra CQL query. CQL shares most of
+// its grammar with SQL, so the SQL tokenizer is reused. On top of the SQL rules, set and map
+// literals (e.g. {'a': 1}), list literals (e.g. [1, 2]) and UUID literals are replaced by a
+// single "?". Literal values found in "USING TTL" and "USING TIMESTAMP" clauses and blobs
+// (e.g. 0xCAFE) are obfuscated like any other number.
+func (o *Obfuscator) ObfuscateCQLString(in string) (*ObfuscatedQuery, error) {
+	opts := o.opts.SQL
+	opts.DBMS = DBMSCassandra
+	return o.ObfuscateSQLStringWithOptions(in, &opts)
+}
+
+// ObfuscatePartiQLString quantizes and obfuscates the given DynamoDB PartiQL statement. Like
+// for CQL, the SQL tokenizer is reused and struct literals (e.g. {'Artist': 'Acme'}) are
+// replaced by a single "?".
+func (o *Obfuscator) ObfuscatePartiQLString(in string) (*ObfuscatedQuery, error) {
+	opts := o.opts.SQL
+	opts.DBMS = DBMSDynamoDB
+	return o.ObfuscateSQLStringWithOptions(in, &opts)
+}
@@ -0,0 +1,115 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package obfuscate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestObfuscateCQL(t *testing.T) {
+	for _, tt := range []struct {
+		in, out string
+	}{
+		{
+			"SELECT * FROM ks.users WHERE user_id = 'abc' AND bucket = 5",
+			"SELECT * FROM ks.users WHERE user_id = ? AND bucket = ?",
+		},
+		{
+			"SELECT * FROM users WHERE id = 550e8400-e29b-41d4-a716-446655440000",
+			"SELECT * FROM users WHERE id = ?",
+		},
+		{
+			"SELECT * FROM users WHERE id = a50e8400-e29b-41d4-a716-446655440000 AND name = abc",
+			"SELECT * FROM users WHERE id = ? AND name = abc",
+		},
+		{
+			"INSERT INTO users (id, tags, attrs) VALUES (123, {'a', 'b'}, {'k': 'v', 'nested': {'x': '}'}}) USING TTL 86400 AND TIMESTAMP 1234567",
+			"INSERT INTO users ( id, tags, attrs ) VALUES ( ? ) USING TTL ? AND TIMESTAMP ?",
+		},
+		{
+			"UPDATE users USING TTL 300 SET emails = emails + {'x@y.com'} WHERE id = 0xCAFEBABE",
+			"UPDATE users USING TTL ? SET emails = emails + ? WHERE id = ?",
+		},
+		{
+			"UPDATE users SET scores = [1, 2, 3] WHERE id = 1",
+			"UPDATE users SET scores = [ ? ] WHERE id = ?",
+		},
+		{
+			"SELECT * FROM users WHERE attrs['key'] = 'v' AND token(id) > ?",
+			"SELECT * FROM users WHERE attrs [ ? ] = ? AND token ( id ) > ?",
+		},
+		{
+			"BEGIN BATCH INSERT INTO t (a) VALUES (1); UPDATE t SET a = 2 WHERE b = 'x'; APPLY BATCH",
+			"BEGIN BATCH INSERT INTO t ( a ) VALUES ( ? ) UPDATE t SET a = ? WHERE b = ? APPLY BATCH",
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			oq, err := NewObfuscator(Config{}).ObfuscateCQLString(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, oq.Query)
+		})
+	}
+}
+
+func TestObfuscateCQLErrors(t *testing.T) {
+	for _, in := range []string{
+		"INSERT INTO users (id, attrs) VALUES (1, {'k': 'v'",
+		"INSERT INTO users (id, attrs) VALUES (1, {'k': 'v})",
+	} {
+		_, err := NewObfuscator(Config{}).ObfuscateCQLString(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestIsUUID(t *testing.T) {
+	for _, tt := range []struct {
+		in  string
+		out bool
+	}{
+		{"550e8400-e29b-41d4-a716-446655440000", true},
+		{"550E8400-E29B-41D4-A716-446655440000 AND", true},
+		{"550e8400-e29b-41d4-a716-446655440000)", true},
+		{"550e8400-e29b-41d4-a716-44665544000", false},
+		{"550e8400-e29b-41d4-a716-4466554400001", false},
+		{"550e8400-e29b-41d4-a716-44665544000g", false},
+		{"550e8400e29b-41d4-a716-446655440000", false},
+		{"zzz", false},
+	} {
+		assert.Equal(t, tt.out, isUUID([]byte(tt.in)), tt.in)
+	}
+}
+
+func TestObfuscatePartiQL(t *testing.T) {
+	for _, tt := range []struct {
+		in, out string
+	}{
+		{
+			`SELECT * FROM "Music" WHERE Artist = 'Acme Band' AND SongTitle = 'PartiQL Rocks'`,
+			"SELECT * FROM Music WHERE Artist = ? AND SongTitle = ?",
+		},
+		{
+			`INSERT INTO "Music" VALUE {'Artist': 'Acme Band', 'Awards': {'Grammys': [2020, 2018]}}`,
+			"INSERT INTO Music VALUE ?",
+		},
+		{
+			`UPDATE "Music" SET AwardsWon = 1 SET AwardDetail = {'Grammys': [2020, 2018]} WHERE Artist = 'Acme Band'`,
+			"UPDATE Music SET AwardsWon = ? SET AwardDetail = ? WHERE Artist = ?",
+		},
+		{
+			`DELETE FROM "Music" WHERE Artist = ? AND SongTitle = ?`,
+			"DELETE FROM Music WHERE Artist = ? AND SongTitle = ?",
+		},
+	} {
+		t.Run("", func(t *testing.T) {
+			oq, err := NewObfuscator(Config{}).ObfuscatePartiQLString(tt.in)
+			require.NoError(t, err)
+			assert.Equal(t, tt.out, oq.Query)
+		})
+	}
+}
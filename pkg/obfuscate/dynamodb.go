@@ -0,0 +1,63 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package obfuscate
+
+// dynamoDBKeepValues holds the keys of DynamoDB requests for which values are never obfuscated.
+// Expressions only reference values through placeholders found in ExpressionAttributeValues,
+// which is obfuscated.
+var dynamoDBKeepValues = []string{
+	"TableName",
+	"IndexName",
+	"Select",
+	"Limit",
+	"ConsistentRead",
+	"ScanIndexForward",
+	"Segment",
+	"TotalSegments",
+	"ProjectionExpression",
+	"KeyConditionExpression",
+	"FilterExpression",
+	"UpdateExpression",
+	"ConditionExpression",
+	"ExpressionAttributeNames",
+	"ReturnValues",
+	"ReturnConsumedCapacity",
+	"ReturnItemCollectionMetrics",
+}
+
+// dynamoDBStatementKeys holds the keys of DynamoDB requests holding PartiQL statements
+// (ExecuteStatement, BatchExecuteStatement and ExecuteTransaction).
+var dynamoDBStatementKeys = []string{"Statement"}
+
+// newDynamoDBObfuscator returns a JSON obfuscator for DynamoDB request bodies. Values of the keys
+// in cfg.KeepValues are kept along with the values of well-known DynamoDB structural keys, and
+// PartiQL statements are obfuscated using ObfuscatePartiQLString.
+func newDynamoDBObfuscator(cfg *JSONConfig, o *Obfuscator) *jsonObfuscator {
+	jcfg := JSONConfig{
+		Enabled:            true,
+		KeepValues:         append(append([]string{}, dynamoDBKeepValues...), cfg.KeepValues...),
+		ObfuscateSQLValues: append(append([]string{}, dynamoDBStatementKeys...), cfg.ObfuscateSQLValues...),
+	}
+	obf := newJSONObfuscator(&jcfg, o)
+	obf.transformer = partiQLObfuscationTransformer(o)
+	return obf
+}
+
+func partiQLObfuscationTransformer(o *Obfuscator) func(string) string {
+	return func(s string) string {
+		result, err := o.ObfuscatePartiQLString(s)
+		if err != nil {
+			o.log.Debugf("Failed to obfuscate PartiQL string '%s': %s", s, err.Error())
+			return "Datadog-agent failed to obfuscate PartiQL string. Enable agent debug logs for more info."
+		}
+		return result.Query
+	}
+}
+
+// ObfuscateDynamoDBString obfuscates the given DynamoDB JSON request body.
+func (o *Obfuscator) ObfuscateDynamoDBString(cmd string) string {
+	return obfuscateJSONString(cmd, o.dynamodb)
+}
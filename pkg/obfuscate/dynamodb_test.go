@@ -0,0 +1,57 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package obfuscate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObfuscateDynamoDB(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		cfg  JSONConfig
+		in   string
+		out  string
+	}{
+		{
+			name: "disabled",
+			cfg:  JSONConfig{},
+			in:   `{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"}}}`,
+			out:  `{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"}}}`,
+		},
+		{
+			name: "get-item",
+			cfg:  JSONConfig{Enabled: true},
+			in:   `{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"}},"ProjectionExpression":"#n, Year","ExpressionAttributeNames":{"#n":"Name"}}`,
+			out:  `{"TableName":"Music","Key":{"Artist":{"S":"?"}},"ProjectionExpression":"#n, Year","ExpressionAttributeNames":{"#n":"Name"}}`,
+		},
+		{
+			name: "query",
+			cfg:  JSONConfig{Enabled: true},
+			in:   `{"TableName":"Music","KeyConditionExpression":"Artist = :a","ExpressionAttributeValues":{":a":{"S":"Acme Band"}},"Limit":10}`,
+			out:  `{"TableName":"Music","KeyConditionExpression":"Artist = :a","ExpressionAttributeValues":{":a":{"S":"?"}},"Limit":10}`,
+		},
+		{
+			name: "execute-statement",
+			cfg:  JSONConfig{Enabled: true},
+			in:   `{"Statement":"SELECT * FROM \"Music\" WHERE Artist = 'Acme Band'","Parameters":[{"S":"x"}]}`,
+			out:  `{"Statement":"SELECT * FROM Music WHERE Artist = ?","Parameters":[{"S":"?"}]}`,
+		},
+		{
+			name: "keep-values",
+			cfg:  JSONConfig{Enabled: true, KeepValues: []string{"Artist"}},
+			in:   `{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"},"SongTitle":{"S":"Rocks"}}}`,
+			out:  `{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"},"SongTitle":{"S":"?"}}}`,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewObfuscator(Config{DynamoDB: tt.cfg})
+			assert.Equal(t, tt.out, o.ObfuscateDynamoDBString(tt.in))
+		})
+	}
+}
@@ -178,6 +178,10 @@ const (
 	DBMSSQLServer = "mssql"
 	// DBMSPostgres is a PostgreSQL Server
 	DBMSPostgres = "postgresql"
+	// DBMSCassandra is an Apache Cassandra (CQL) database
+	DBMSCassandra = "cassandra"
+	// DBMSDynamoDB is an Amazon DynamoDB database, queried using PartiQL
+	DBMSDynamoDB = "dynamodb"
 )
 
 const escapeCharacter = '\\'
@@ -268,6 +272,8 @@ func (tkn *SQLTokenizer) Scan() (TokenKind, []byte) {
 	tkn.SkipBlank()
 
 	switch ch := tkn.lastChar; {
+	case tkn.hasCollectionLiterals() && isUUID(tkn.buf):
+		return tkn.scanUUID()
 	case isLeadingLetter(ch) &&
 		!(tkn.cfg.DBMS == DBMSPostgres && ch == '@'):
 		// The '@' symbol should not be considered part of an identifier in
@@ -503,6 +509,11 @@ func (tkn *SQLTokenizer) Scan() (TokenKind, []byte) {
 			}
 			fallthrough
 		case '{':
+			if tkn.hasCollectionLiterals() {
+				// CQL and PartiQL use curly braces for set, map and tuple literals,
+				// which may be nested.
+				return tkn.scanCollectionLiteral()
+			}
 			if tkn.pos == 1 || tkn.curlys > 0 {
 				// Do not fully obfuscate top-level SQL escape sequences like {{[?=]call procedure-name[([parameter][,parameter]...)]}.
 				// We want these to display a bit more context than just a plain '?'
@@ -692,6 +703,76 @@ func (tkn *SQLTokenizer) scanEscapeSequence(braces rune) (TokenKind, []byte) {
 	return EscapeSequence, tkn.bytes()
 }
 
+// hasCollectionLiterals reports whether the configured DBMS supports curly brace delimited
+// collection literals (e.g. CQL sets and maps or PartiQL structs).
+func (tkn *SQLTokenizer) hasCollectionLiterals() bool {
+	return tkn.cfg.DBMS == DBMSCassandra || tkn.cfg.DBMS == DBMSDynamoDB
+}
+
+// scanCollectionLiteral scans a curly brace delimited collection literal, such as
+// {'a': 1, 'b': {'c': [1, 2]}}. The opening brace was already consumed.
+func (tkn *SQLTokenizer) scanCollectionLiteral() (TokenKind, []byte) {
+	depth := 1
+	for depth > 0 {
+		switch tkn.lastChar {
+		case EndChar:
+			tkn.setErr("unexpected EOF in collection literal")
+			return LexError, tkn.bytes()
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '\'', '"':
+			// skip over strings, which may contain braces
+			delim := tkn.lastChar
+			for tkn.advance(); tkn.lastChar != delim; tkn.advance() {
+				if tkn.lastChar == EndChar {
+					tkn.setErr("unexpected EOF in string")
+					return LexError, tkn.bytes()
+				}
+			}
+		}
+		tkn.advance()
+	}
+	return EscapeSequence, tkn.bytes()
+}
+
+// uuidLen is the length of the canonical textual representation of a UUID.
+const uuidLen = 36
+
+// isUUID reports whether buf starts with a UUID literal, such as the ones found in CQL
+// queries (e.g. 550e8400-e29b-41d4-a716-446655440000).
+func isUUID(buf []byte) bool {
+	if len(buf) < uuidLen {
+		return false
+	}
+	for i, ch := range buf[:uuidLen] {
+		switch i {
+		case 8, 13, 18, 23:
+			if ch != '-' {
+				return false
+			}
+		default:
+			if digitVal(rune(ch)) > 15 {
+				return false
+			}
+		}
+	}
+	if len(buf) > uuidLen {
+		next := rune(buf[uuidLen])
+		return !isLetter(next) && !isDigit(next) && next != '-'
+	}
+	return true
+}
+
+// scanUUID scans a UUID literal. It must be called only after isUUID has succeeded.
+func (tkn *SQLTokenizer) scanUUID() (TokenKind, []byte) {
+	for i := 0; i < uuidLen; i++ {
+		tkn.advance()
+	}
+	return Number, tkn.bytes()
+}
+
 func (tkn *SQLTokenizer) scanBindVar() (TokenKind, []byte) {
 	token := ValueArg
 	if tkn.lastChar == ':' {
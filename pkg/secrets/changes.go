@@ -0,0 +1,25 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package secrets
+
+// SecretChange is a place where a secret was used, whose value changed when the
+// secrets were refreshed
+type SecretChange struct {
+	// Handle is the handle of the secret
+	Handle string
+	// Origin is the configuration name where the handle was found
+	Origin string
+	// Path is the key associated to the secret in the YAML configuration, its
+	// elements being separated by '/'
+	Path string
+	// OldValue and NewValue are the values of the secret before and after the refresh
+	OldValue string
+	NewValue string
+}
+
+// RefreshCallback is called with the places where the secrets whose value changed
+// were used, after the secrets were refreshed
+type RefreshCallback func(changes []SecretChange)
@@ -156,9 +156,13 @@ func fetchSecret(secretsHandle []string) (map[string]string, error) {
 			return nil, fmt.Errorf("decrypted secret for '%s' is empty", sec)
 		}
 
-		// add it to the cache
-		secretCache[sec] = v.Value
 		res[sec] = v.Value
 	}
+
+	// add them to the cache once they were all decrypted, so that it never holds
+	// the values of a partially failed fetch
+	for sec, value := range res {
+		secretCache[sec] = value
+	}
 	return res, nil
 }
@@ -10,8 +10,10 @@
 package secrets
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"time"
 )
 
 // SecretBackendOutputMaxSize defines max size of the JSON output from a secrets reader backend
@@ -30,3 +32,17 @@ func Decrypt(data []byte, origin string) ([]byte, error) {
 func GetDebugInfo(w io.Writer) {
 	fmt.Fprintf(w, "Secret feature is not available in this version of the agent")
 }
+
+// RegisterRefreshCallback placeholder when compiled without the 'secrets' build tag
+func RegisterRefreshCallback(callback RefreshCallback) {}
+
+// Refresh placeholder when compiled without the 'secrets' build tag
+func Refresh() ([]SecretChange, error) {
+	return nil, nil
+}
+
+// StartRefreshRoutine placeholder when compiled without the 'secrets' build tag
+func StartRefreshRoutine(ctx context.Context, interval time.Duration, refreshOnTrigger bool) {}
+
+// TriggerRefresh placeholder when compiled without the 'secrets' build tag
+func TriggerRefresh() {}
@@ -0,0 +1,185 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package secrets
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// minTriggeredRefreshInterval is the minimum interval between two refreshes
+// triggered by TriggerRefresh, so that a component failing to authenticate with
+// a secret doesn't run the secret backend command continuously
+const minTriggeredRefreshInterval = time.Minute
+
+var (
+	refreshCallbacks     []RefreshCallback
+	refreshCallbacksLock sync.Mutex
+
+	// refreshTrigger is set when the refresh routine handles the refreshes
+	// triggered by TriggerRefresh
+	refreshTrigger     chan struct{}
+	refreshTriggerLock sync.Mutex
+)
+
+// RegisterRefreshCallback registers a callback called with the places where the
+// secrets whose value changed were used, every time the secrets are refreshed
+func RegisterRefreshCallback(callback RefreshCallback) {
+	refreshCallbacksLock.Lock()
+	defer refreshCallbacksLock.Unlock()
+
+	refreshCallbacks = append(refreshCallbacks, callback)
+}
+
+// Refresh fetches again all the secrets which were decrypted, by executing
+// "secret_backend_command" once, and updates the cache. The callbacks registered
+// with RegisterRefreshCallback are then called with the places where the secrets
+// whose value changed were used, which are also returned.
+func Refresh() ([]SecretChange, error) {
+	changes, err := refreshCache()
+	if err != nil || len(changes) == 0 {
+		return changes, err
+	}
+
+	log.Infof("Secrets refreshed, %d places where a secret was used are affected", len(changes))
+
+	refreshCallbacksLock.Lock()
+	callbacks := append([]RefreshCallback{}, refreshCallbacks...)
+	refreshCallbacksLock.Unlock()
+
+	// the callbacks are called without holding secretLock, as they are expected
+	// to decrypt the configurations again
+	for _, callback := range callbacks {
+		callback(changes)
+	}
+	return changes, nil
+}
+
+// refreshCache fetches again the secrets used in the configurations and returns
+// the places where the secrets whose value changed were used. The cache is only
+// updated if all the secrets were fetched.
+func refreshCache() ([]SecretChange, error) {
+	if secretBackendCommand == "" {
+		return nil, nil
+	}
+
+	secretLock.Lock()
+	defer secretLock.Unlock()
+
+	if len(secretOrigin) == 0 {
+		return nil, nil
+	}
+
+	handles := make([]string, 0, len(secretOrigin))
+	oldValues := make(map[string]string, len(secretOrigin))
+	for handle := range secretOrigin {
+		handles = append(handles, handle)
+		oldValues[handle] = secretCache[handle]
+	}
+	// we sort handles so the changes are consistent and testable
+	sort.Strings(handles)
+
+	newValues, err := secretFetcher(handles)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := []SecretChange{}
+	for _, handle := range handles {
+		newValue := newValues[handle]
+		secretCache[handle] = newValue
+		if newValue == oldValues[handle] {
+			continue
+		}
+
+		log.Debugf("Secret '%s' changed", handle)
+		for _, place := range secretOrigin[handle] {
+			changes = append(changes, SecretChange{
+				Handle:   handle,
+				Origin:   place.origin,
+				Path:     place.yamlPath,
+				OldValue: oldValues[handle],
+				NewValue: newValue,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// StartRefreshRoutine refreshes the secrets every interval, if it's not 0, and
+// when TriggerRefresh is called, if refreshOnTrigger is set, until the context is
+// cancelled
+func StartRefreshRoutine(ctx context.Context, interval time.Duration, refreshOnTrigger bool) {
+	if secretBackendCommand == "" || (interval <= 0 && !refreshOnTrigger) {
+		return
+	}
+
+	var tickerC <-chan time.Time
+	if interval > 0 {
+		ticker := time.NewTicker(interval)
+		tickerC = ticker.C
+		go func() {
+			<-ctx.Done()
+			ticker.Stop()
+		}()
+	}
+
+	var trigger chan struct{}
+	if refreshOnTrigger {
+		trigger = make(chan struct{}, 1)
+		refreshTriggerLock.Lock()
+		refreshTrigger = trigger
+		refreshTriggerLock.Unlock()
+	}
+
+	go func() {
+		var lastTriggeredRefresh time.Time
+		for {
+			select {
+			case <-ctx.Done():
+				refreshTriggerLock.Lock()
+				refreshTrigger = nil
+				refreshTriggerLock.Unlock()
+				return
+			case <-tickerC:
+			case <-trigger:
+				if time.Since(lastTriggeredRefresh) < minTriggeredRefreshInterval {
+					log.Debugf("Secrets were refreshed less than %s ago, ignoring refresh request", minTriggeredRefreshInterval)
+					continue
+				}
+				lastTriggeredRefresh = time.Now()
+			}
+
+			if _, err := Refresh(); err != nil {
+				log.Errorf("Unable to refresh secrets: %s", err)
+			}
+		}
+	}()
+}
+
+// TriggerRefresh requests a refresh of the secrets, for instance when a component
+// fails to authenticate with a secret which may have been rotated. It doesn't
+// block, and does nothing unless the refresh routine was started to handle these
+// requests.
+func TriggerRefresh() {
+	refreshTriggerLock.Lock()
+	defer refreshTriggerLock.Unlock()
+
+	if refreshTrigger == nil {
+		return
+	}
+	select {
+	case refreshTrigger <- struct{}{}:
+	default:
+		// a refresh is already pending
+	}
+}
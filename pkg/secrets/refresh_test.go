@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build secrets
+
+package secrets
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefresh(t *testing.T) {
+	defer resetPackageVars()
+	defer func() { refreshCallbacks = nil }()
+	secretBackendCommand = "some_command"
+	scrubberAddReplacer = func([]string) {}
+
+	values := map[string]string{"pass1": "password1", "pass2": "password2"}
+	secretFetcher = func(handles []string) (map[string]string, error) {
+		res := map[string]string{}
+		for _, handle := range handles {
+			res[handle] = values[handle]
+		}
+		return res, nil
+	}
+
+	_, err := Decrypt(testConf, "test")
+	require.NoError(t, err)
+
+	var notified []SecretChange
+	RegisterRefreshCallback(func(changes []SecretChange) {
+		notified = append(notified, changes...)
+	})
+
+	// nothing changed
+	changes, err := Refresh()
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+	assert.Empty(t, notified)
+
+	values["pass2"] = "rotated"
+	changes, err = Refresh()
+	require.NoError(t, err)
+	expected := []SecretChange{{Handle: "pass2", Origin: "test", Path: "instances/password", OldValue: "password2", NewValue: "rotated"}}
+	assert.Equal(t, expected, changes)
+	assert.Equal(t, expected, notified)
+
+	// the configurations are decrypted with the new value
+	decrypted, err := Decrypt(testConf, "test")
+	require.NoError(t, err)
+	assert.Contains(t, string(decrypted), "rotated")
+
+	// the cache is kept if the backend fails
+	secretFetcher = func([]string) (map[string]string, error) {
+		return nil, fmt.Errorf("some error")
+	}
+	_, err = Refresh()
+	assert.Error(t, err)
+	assert.Equal(t, "rotated", secretCache["pass2"])
+}
+
+func TestRefreshPartialFailure(t *testing.T) {
+	defer resetPackageVars()
+	defer func(old func(string) ([]byte, error)) { runCommand = old }(runCommand)
+	secretBackendCommand = "some_command"
+	scrubberAddReplacer = func([]string) {}
+
+	output := `{"pass1": {"value": "password1"}, "pass2": {"value": "password2"}}`
+	var fetched []string
+	runCommand = func(payload string) ([]byte, error) {
+		var p struct{ Secrets []string }
+		require.NoError(t, json.Unmarshal([]byte(payload), &p))
+		fetched = p.Secrets
+		return []byte(output), nil
+	}
+
+	_, err := Decrypt(testConf, "test")
+	require.NoError(t, err)
+	// a handle which isn't used by any configuration isn't fetched again
+	secretCache["unused"] = "value"
+
+	// the backend fails on the second handle: the rotation of the first one
+	// isn't recorded in the cache
+	output = `{"pass1": {"value": "rotated"}, "pass2": {"error": "some error"}}`
+	_, err = Refresh()
+	assert.Error(t, err)
+	assert.Equal(t, []string{"pass1", "pass2"}, fetched)
+	assert.Equal(t, "password1", secretCache["pass1"])
+
+	// so it's reported by the next refresh
+	output = `{"pass1": {"value": "rotated"}, "pass2": {"value": "password2"}}`
+	changes, err := Refresh()
+	require.NoError(t, err)
+	assert.Equal(t, []SecretChange{{Handle: "pass1", Origin: "test", Path: "instances/password", OldValue: "password1", NewValue: "rotated"}}, changes)
+	assert.Equal(t, "rotated", secretCache["pass1"])
+}
+
+func TestTriggerRefreshWithoutRoutine(t *testing.T) {
+	// doesn't block when the refresh routine isn't started
+	TriggerRefresh()
+	TriggerRefresh()
+}
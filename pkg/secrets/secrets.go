@@ -13,6 +13,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
 
 	yaml "gopkg.in/yaml.v2"
@@ -31,6 +32,9 @@ var (
 	secretCache map[string]string
 	// list of handles and where they were found
 	secretOrigin handleToContext
+	// secretLock protects secretCache and secretOrigin, which are updated when
+	// the secrets are refreshed
+	secretLock sync.Mutex
 
 	secretBackendCommand               string
 	secretBackendArguments             []string
@@ -180,6 +184,9 @@ func Decrypt(data []byte, origin string) ([]byte, error) {
 		return data, nil
 	}
 
+	secretLock.Lock()
+	defer secretLock.Unlock()
+
 	var config interface{}
 	err := yaml.Unmarshal(data, &config)
 	if err != nil {
@@ -230,6 +237,7 @@ func Decrypt(data []byte, origin string) ([]byte, error) {
 				if ok, handle := isEnc(str); ok {
 					if secret, ok := secrets[handle]; ok {
 						log.Debugf("Secret '%s' was retrieved from executable", handle)
+						secretCache[handle] = secret
 						// keep track of place where a handle was found
 						registerSecretOrigin(handle, origin, yamlPath)
 						return secret, nil
@@ -298,6 +306,7 @@ func GetDebugInfo(w io.Writer) {
 		info.ExecutablePermissionsError = err.Error()
 	}
 
+	secretLock.Lock()
 	// we sort handles so the output is consistent and testable
 	orderedHandles := []string{}
 	for handle := range secretOrigin {
@@ -313,6 +322,7 @@ func GetDebugInfo(w io.Writer) {
 		}
 		info.Handles[handle] = details
 	}
+	secretLock.Unlock()
 
 	err = t.Execute(w, info)
 	if err != nil {
@@ -33,6 +33,7 @@ func Fmap() htemplate.FuncMap {
 		"formatUnixTime":     formatUnixTime,
 		"humanize":           mkHuman,
 		"humanizeDuration":   mkHumanDuration,
+		"humanizeBytes":      mkHumanBytes,
 		"toUnsortedList":     toUnsortedList,
 		"formatTitle":        formatTitle,
 		"add":                add,
@@ -56,6 +57,7 @@ func Textfmap() ttemplate.FuncMap {
 		"formatUnixTime":     formatUnixTime,
 		"humanize":           mkHuman,
 		"humanizeDuration":   mkHumanDuration,
+		"humanizeBytes":      mkHumanBytes,
 		"toUnsortedList":     toUnsortedList,
 		"formatTitle":        formatTitle,
 		"add":                add,
@@ -154,6 +156,11 @@ func mkHumanDuration(f float64, unit string) string {
 	return duration.String()
 }
 
+// mkHumanBytes makes byte sizes more readable
+func mkHumanBytes(f float64) string {
+	return humanize.IBytes(uint64(f))
+}
+
 func stringLength(s string) int {
 	/*
 		len(string) is wrong if the string has unicode characters in it,
@@ -90,9 +90,9 @@ func FormatStatus(data []byte) (string, error) {
 		return nil
 	}
 	autodiscoveryFunc := func() error {
-		if config.IsContainerized() {
+		if config.IsContainerized() || stats["adGitRepositories"] != nil {
 			return renderAutodiscoveryStats(b, stats["adEnabledFeatures"], stats["adConfigErrors"],
-				stats["filterErrors"])
+				stats["filterErrors"], stats["adGitRepositories"])
 		}
 		return nil
 	}
@@ -307,11 +307,12 @@ func renderRuntimeSecurityStats(w io.Writer, runtimeSecurityStatus interface{})
 	return RenderStatusTemplate(w, "/runtimesecurity.tmpl", status)
 }
 
-func renderAutodiscoveryStats(w io.Writer, adEnabledFeatures interface{}, adConfigErrors interface{}, filterErrors interface{}) error {
+func renderAutodiscoveryStats(w io.Writer, adEnabledFeatures interface{}, adConfigErrors interface{}, filterErrors interface{}, adGitRepositories interface{}) error {
 	autodiscoveryStats := make(map[string]interface{})
 	autodiscoveryStats["adEnabledFeatures"] = adEnabledFeatures
 	autodiscoveryStats["adConfigErrors"] = adConfigErrors
 	autodiscoveryStats["filterErrors"] = filterErrors
+	autodiscoveryStats["adGitRepositories"] = adGitRepositories
 	return RenderStatusTemplate(w, "/autodiscovery.tmpl", autodiscoveryStats)
 }
 
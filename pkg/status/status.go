@@ -16,6 +16,7 @@ import (
 	"time"
 
 	"github.com/DataDog/datadog-agent/cmd/agent/common"
+	"github.com/DataDog/datadog-agent/pkg/autodiscovery/providers"
 	"github.com/DataDog/datadog-agent/pkg/clusteragent/admission"
 	"github.com/DataDog/datadog-agent/pkg/clusteragent/clusterchecks"
 	"github.com/DataDog/datadog-agent/pkg/clusteragent/custommetrics"
@@ -100,6 +101,10 @@ func GetStatus(verbose bool) (map[string]interface{}, error) {
 		stats["filterErrors"] = containers.GetFilterErrors()
 	}
 
+	if gitRepositories := providers.GetGitRepositoriesStatus(); len(gitRepositories) > 0 {
+		stats["adGitRepositories"] = gitRepositories
+	}
+
 	stats["remoteConfiguration"] = getRemoteConfigStatus()
 	return stats, nil
 }
@@ -64,6 +64,10 @@ type Agent struct {
 	// tags based on their type.
 	obfuscator     *obfuscate.Obfuscator
 	cardObfuscator *ccObfuscator
+	scrubber       *scrubber
+
+	// samplingDecisions records recent sampling decisions for debugging purposes.
+	samplingDecisions *api.SamplingDecisions
 
 	// DiscardSpan will be called on all spans, if non-nil. If it returns true, the span will be deleted before processing.
 	DiscardSpan func(*pb.Span) bool
@@ -105,12 +109,15 @@ func NewAgent(ctx context.Context, conf *config.AgentConfig, telemetryCollector
 		StatsWriter:           writer.NewStatsWriter(conf, statsChan, telemetryCollector),
 		obfuscator:            obfuscate.NewObfuscator(oconf),
 		cardObfuscator:        newCreditCardsObfuscator(conf.Obfuscation.CreditCards),
+		scrubber:              newScrubber(conf.Obfuscation),
 		In:                    in,
 		conf:                  conf,
 		ctx:                   ctx,
 		DebugServer:           api.NewDebugServer(conf),
 	}
 	agnt.Receiver = api.NewHTTPReceiver(conf, dynConf, in, agnt, telemetryCollector)
+	agnt.DebugServer.AddRoute("/debug/capture", agnt.Receiver.CaptureHandler())
+	agnt.samplingDecisions = agnt.DebugServer.SamplingDecisions()
 	agnt.OTLPReceiver = api.NewOTLPReceiver(in, conf)
 	agnt.RemoteConfigHandler = remoteconfighandler.New(conf, agnt.PrioritySampler, agnt.RareSampler, agnt.ErrorsSampler)
 	agnt.TraceWriter = writer.NewTraceWriter(conf, agnt.PrioritySampler, agnt.ErrorsSampler, agnt.RareSampler, telemetryCollector)
@@ -295,6 +302,7 @@ func (a *Agent) Process(p *api.Payload) {
 				a.ModifySpan(chunk, span)
 			}
 			a.obfuscateSpan(span)
+			a.scrubber.scrubSpan(span)
 			a.Truncate(span)
 			if p.ClientComputedTopLevel {
 				traceutil.UpdateTracerTopLevel(span)
@@ -501,15 +509,18 @@ func (a *Agent) sample(now time.Time, ts *info.TagStats, pt *traceutil.Processed
 	}
 	if a.conf.HasFeature("error_rare_sample_tracer_drop") {
 		if isManualUserDrop(priority, pt) {
+			a.recordSamplingDecision(now, pt, false, sampler.NameManual)
 			return 0, false, pt
 		}
 	} else { // This path to be deleted once manualUserDrop detection is available on all tracers for P < 1.
 		if priority < 0 {
+			a.recordSamplingDecision(now, pt, false, sampler.NameManual)
 			return 0, false, pt
 		}
 	}
 
-	sampled := a.runSamplers(now, *pt, hasPriority)
+	sampled, samplerName := a.runSamplers(now, *pt, hasPriority)
+	a.recordSamplingDecision(now, pt, sampled, samplerName)
 	pt.TraceChunk.DroppedTrace = !sampled
 	numEvents, numExtracted := a.EventProcessor.Process(pt)
 
@@ -519,9 +530,28 @@ func (a *Agent) sample(now time.Time, ts *info.TagStats, pt *traceutil.Processed
 	return numEvents, sampled, pt
 }
 
+// recordSamplingDecision records the sampling decision made on pt by the named sampler,
+// if sampling decisions are being watched.
+func (a *Agent) recordSamplingDecision(now time.Time, pt *traceutil.ProcessedTrace, kept bool, samplerName string) {
+	if !a.samplingDecisions.Active(now) {
+		return
+	}
+	a.samplingDecisions.Record(api.SamplingDecision{
+		Time:     now,
+		TraceID:  pt.Root.TraceID,
+		Env:      pt.TracerEnv,
+		Service:  pt.Root.Service,
+		Resource: pt.Root.Resource,
+		Priority: pt.TraceChunk.Priority,
+		Sampler:  samplerName,
+		Rate:     sampler.AppliedRate(samplerName, pt.TraceChunk, pt.Root),
+		Kept:     kept,
+	})
+}
+
 // runSamplers runs all the agent's samplers on pt and returns the sampling decision
-// along with the sampling rate.
-func (a *Agent) runSamplers(now time.Time, pt traceutil.ProcessedTrace, hasPriority bool) bool {
+// along with the name of the sampler which made it.
+func (a *Agent) runSamplers(now time.Time, pt traceutil.ProcessedTrace, hasPriority bool) (bool, string) {
 	if hasPriority {
 		return a.samplePriorityTrace(now, pt)
 	}
@@ -531,25 +561,28 @@ func (a *Agent) runSamplers(now time.Time, pt traceutil.ProcessedTrace, hasPrior
 // samplePriorityTrace samples traces with priority set on them. PrioritySampler and
 // ErrorSampler are run in parallel. The RareSampler catches traces with rare top-level
 // or measured spans that are not caught by PrioritySampler and ErrorSampler.
-func (a *Agent) samplePriorityTrace(now time.Time, pt traceutil.ProcessedTrace) bool {
+func (a *Agent) samplePriorityTrace(now time.Time, pt traceutil.ProcessedTrace) (bool, string) {
 	// run this early to make sure the signature gets counted by the RareSampler.
 	rare := a.RareSampler.Sample(now, pt.TraceChunk, pt.TracerEnv)
 	if a.PrioritySampler.Sample(now, pt.TraceChunk, pt.Root, pt.TracerEnv, pt.ClientDroppedP0sWeight) {
-		return true
+		return true, sampler.PrioritySamplerName(pt.TraceChunk, pt.Root)
 	}
 	if traceContainsError(pt.TraceChunk.Spans) {
-		return a.ErrorsSampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv)
+		return a.ErrorsSampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv), sampler.NameErrors
+	}
+	if rare {
+		return true, sampler.NameRare
 	}
-	return rare
+	return false, sampler.PrioritySamplerName(pt.TraceChunk, pt.Root)
 }
 
 // sampleNoPriorityTrace samples traces with no priority set on them. The traces
 // get sampled by either the score sampler or the error sampler if they have an error.
-func (a *Agent) sampleNoPriorityTrace(now time.Time, pt traceutil.ProcessedTrace) bool {
+func (a *Agent) sampleNoPriorityTrace(now time.Time, pt traceutil.ProcessedTrace) (bool, string) {
 	if traceContainsError(pt.TraceChunk.Spans) {
-		return a.ErrorsSampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv)
+		return a.ErrorsSampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv), sampler.NameErrors
 	}
-	return a.NoPrioritySampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv)
+	return a.NoPrioritySampler.Sample(now, pt.TraceChunk.Spans, pt.Root, pt.TracerEnv), sampler.NameNoPriority
 }
 
 func traceContainsError(trace pb.Trace) bool {
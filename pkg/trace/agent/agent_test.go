@@ -8,6 +8,7 @@ package agent
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"io"
 	"math"
 	"net/http"
@@ -977,7 +978,7 @@ func TestSampling(t *testing.T) {
 			a := configureAgent(tt.agentConfig)
 			for _, tc := range tt.testCases {
 				_, hasPriority := sampler.GetSamplingPriority(tc.trace.TraceChunk)
-				sampled := a.runSamplers(time.Now(), tc.trace, hasPriority)
+				sampled, _ := a.runSamplers(time.Now(), tc.trace, hasPriority)
 				assert.EqualValues(t, tc.wantSampled, sampled)
 			}
 		})
@@ -1077,6 +1078,65 @@ func TestSample(t *testing.T) {
 	}
 }
 
+func TestSampleRecordsDecisions(t *testing.T) {
+	cfg := &config.AgentConfig{TargetTPS: 5, ErrorTPS: 1000, Features: make(map[string]struct{})}
+	a := &Agent{
+		NoPrioritySampler: sampler.NewNoPrioritySampler(cfg),
+		ErrorsSampler:     sampler.NewErrorsSampler(cfg),
+		PrioritySampler:   sampler.NewPrioritySampler(cfg, &sampler.DynamicConfig{}),
+		RareSampler:       sampler.NewRareSampler(config.New()),
+		EventProcessor:    newEventProcessor(cfg),
+		conf:              cfg,
+		samplingDecisions: api.NewSamplingDecisions(),
+	}
+	genTrace := func(traceID uint64, decisionMaker string, priority sampler.SamplingPriority) *traceutil.ProcessedTrace {
+		root := &pb.Span{
+			TraceID:  traceID,
+			Service:  "serv1",
+			Resource: "GET /",
+			Start:    time.Now().UnixNano(),
+			Duration: (100 * time.Millisecond).Nanoseconds(),
+			Metrics:  map[string]float64{"_top_level": 1, "_dd.agent_psr": 0.5},
+			Meta:     map[string]string{"_dd.p.dm": decisionMaker},
+		}
+		pt := &traceutil.ProcessedTrace{TraceChunk: testutil.TraceChunkWithSpan(root), Root: root, TracerEnv: "prod"}
+		pt.TraceChunk.Priority = int32(priority)
+		return pt
+	}
+	ts := info.NewReceiverStats().GetTagStats(info.Tags{})
+
+	// decisions are only recorded while the endpoint is being watched
+	a.sample(time.Now(), ts, genTrace(1, "-1", sampler.PriorityAutoKeep))
+	rec := httptest.NewRecorder()
+	a.samplingDecisions.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/sampling", nil))
+	assert.Contains(t, rec.Body.String(), `"decisions":[]`)
+
+	a.sample(time.Now(), ts, genTrace(2, "-1", sampler.PriorityAutoKeep))
+	a.sample(time.Now(), ts, genTrace(3, "-4", sampler.PriorityUserDrop))
+	a.sample(time.Now(), ts, genTrace(4, "-9", sampler.PriorityAutoKeep))
+
+	rec = httptest.NewRecorder()
+	a.samplingDecisions.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/sampling", nil))
+	var resp api.SamplingDecisionsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	require.Len(t, resp.Decisions, 3)
+	for i, want := range []api.SamplingDecision{
+		{TraceID: 2, Priority: 1, Sampler: sampler.NamePriority, Rate: 0.5, Kept: true},
+		{TraceID: 3, Priority: -1, Sampler: sampler.NameManual, Rate: 1, Kept: false},
+		{TraceID: 4, Priority: 1, Sampler: sampler.NameProbabilistic, Rate: 1, Kept: true},
+	} {
+		got := resp.Decisions[i]
+		assert.Equal(t, want.TraceID, got.TraceID)
+		assert.Equal(t, "prod", got.Env)
+		assert.Equal(t, "serv1", got.Service)
+		assert.Equal(t, "GET /", got.Resource)
+		assert.Equal(t, want.Priority, got.Priority)
+		assert.Equal(t, want.Sampler, got.Sampler)
+		assert.Equal(t, want.Rate, got.Rate)
+		assert.Equal(t, want.Kept, got.Kept)
+	}
+}
+
 func TestPartialSamplingFree(t *testing.T) {
 	cfg := &config.AgentConfig{RareSamplerEnabled: false, BucketInterval: 10 * time.Second}
 	statsChan := make(chan *pb.StatsPayload, 100)
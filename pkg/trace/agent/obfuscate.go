@@ -19,6 +19,9 @@ const (
 	tagRedisRawCommand  = "redis.raw_command"
 	tagMemcachedCommand = "memcached.command"
 	tagMongoDBQuery     = "mongodb.query"
+	tagCassandraQuery   = "cassandra.query"
+	tagDynamoDBQuery    = "dynamodb.query"
+	tagDBStatement      = "db.statement"
 	tagElasticBody      = "elasticsearch.body"
 	tagSQLQuery         = "sql.query"
 	tagHTTPURL          = "http.url"
@@ -32,10 +35,18 @@ func (a *Agent) obfuscateSpan(span *pb.Span) {
 	o := a.obfuscator
 	switch span.Type {
 	case "sql", "cassandra":
+		if v, ok := span.Meta[tagCassandraQuery]; ok && v != "" && span.Type == "cassandra" && a.conf.Obfuscation.Cassandra.Enabled {
+			if oq, err := o.ObfuscateCQLString(v); err == nil {
+				span.Meta[tagCassandraQuery] = oq.Query
+			} else {
+				log.Debugf("Error parsing CQL query: %v. Query: %q", err, v)
+				span.Meta[tagCassandraQuery] = textNonParsable
+			}
+		}
 		if span.Resource == "" {
 			return
 		}
-		oq, err := o.ObfuscateSQLString(span.Resource)
+		oq, err := a.obfuscateQuery(span.Type, span.Resource)
 		if err != nil {
 			// we have an error, discard the SQL to avoid polluting user resources.
 			log.Debugf("Error parsing SQL query: %v. Resource: %q", err, span.Resource)
@@ -101,14 +112,46 @@ func (a *Agent) obfuscateSpan(span *pb.Span) {
 			return
 		}
 		span.Meta[tagElasticBody] = o.ObfuscateElasticSearchString(v)
+	case "dynamodb":
+		if !a.conf.Obfuscation.DynamoDB.Enabled || span.Meta == nil {
+			return
+		}
+		if v, ok := span.Meta[tagDynamoDBQuery]; ok {
+			span.Meta[tagDynamoDBQuery] = o.ObfuscateDynamoDBString(v)
+		}
+		if v, ok := span.Meta[tagDBStatement]; ok && v != "" {
+			oq, err := o.ObfuscatePartiQLString(v)
+			if err != nil {
+				log.Debugf("Error parsing PartiQL statement: %v. Statement: %q", err, v)
+				span.Meta[tagDBStatement] = textNonParsable
+				if span.Resource == v {
+					span.Resource = textNonParsable
+				}
+				return
+			}
+			if span.Resource == v {
+				// the resource holds the raw statement, obfuscate it too
+				span.Resource = oq.Query
+			}
+			span.Meta[tagDBStatement] = oq.Query
+		}
+	}
+}
+
+// obfuscateQuery obfuscates the given query coming from a span or stats group of type typ.
+// Cassandra queries are obfuscated using the CQL rules when enabled, and as SQL otherwise.
+func (a *Agent) obfuscateQuery(typ, query string) (*obfuscate.ObfuscatedQuery, error) {
+	if typ == "cassandra" && a.conf.Obfuscation.Cassandra.Enabled {
+		return a.obfuscator.ObfuscateCQLString(query)
 	}
+	return a.obfuscator.ObfuscateSQLString(query)
 }
 
 func (a *Agent) obfuscateStatsGroup(b *pb.ClientGroupedStats) {
 	o := a.obfuscator
 	switch b.Type {
 	case "sql", "cassandra":
-		oq, err := o.ObfuscateSQLString(b.Resource)
+		oq, err := a.obfuscateQuery(b.Type, b.Resource)
 		if err != nil {
 			log.Errorf("Error obfuscating stats group resource %q: %v", b.Resource, err)
 			b.Resource = textNonParsable
@@ -63,6 +63,7 @@ func TestObfuscateStatsGroup(t *testing.T) {
 	}{
 		{statsGroup("sql", "SELECT 1 FROM db"), "SELECT ? FROM db"},
 		{statsGroup("sql", "SELECT 1\nFROM Blogs AS [b\nORDER BY [b]"), textNonParsable},
+		{statsGroup("cassandra", "SELECT * FROM users WHERE id = 1"), "SELECT * FROM users WHERE id = ?"},
 		{statsGroup("redis", "ADD 1, 2"), "ADD"},
 		{statsGroup("other", "ADD 1, 2"), "ADD 1, 2"},
 	} {
@@ -227,6 +228,46 @@ func TestObfuscateConfig(t *testing.T) {
 		&config.ObfuscationConfig{Memcached: config.Enablable{Enabled: true}},
 	))
 
+	t.Run("cassandra/enabled", testConfig(
+		"cassandra",
+		"cassandra.query",
+		"INSERT INTO users (id, emails) VALUES (550e8400-e29b-41d4-a716-446655440000, {'a@b.c'}) USING TTL 60",
+		"INSERT INTO users ( id, emails ) VALUES ( ? ) USING TTL ?",
+		&config.ObfuscationConfig{Cassandra: config.Enablable{Enabled: true}},
+	))
+
+	t.Run("cassandra/disabled", testConfig(
+		"cassandra",
+		"cassandra.query",
+		"SELECT * FROM users WHERE id = 1",
+		"SELECT * FROM users WHERE id = 1",
+		&config.ObfuscationConfig{},
+	))
+
+	t.Run("dynamodb/json", testConfig(
+		"dynamodb",
+		"dynamodb.query",
+		`{"TableName":"Music","Key":{"Artist":{"S":"Acme Band"}}}`,
+		`{"TableName":"Music","Key":{"Artist":{"S":"?"}}}`,
+		&config.ObfuscationConfig{DynamoDB: config.JSONObfuscationConfig{Enabled: true}},
+	))
+
+	t.Run("dynamodb/partiql", testConfig(
+		"dynamodb",
+		"db.statement",
+		`INSERT INTO "Music" VALUE {'Artist': 'Acme Band', 'Awards': {'Grammys': [2020]}}`,
+		"INSERT INTO Music VALUE ?",
+		&config.ObfuscationConfig{DynamoDB: config.JSONObfuscationConfig{Enabled: true}},
+	))
+
+	t.Run("dynamodb/disabled", testConfig(
+		"dynamodb",
+		"db.statement",
+		"SELECT * FROM Music WHERE Artist = 'Acme Band'",
+		"SELECT * FROM Music WHERE Artist = 'Acme Band'",
+		&config.ObfuscationConfig{},
+	))
+
 	t.Run("memcached/disabled", testConfig(
 		"memcached",
 		"memcached.command",
@@ -246,6 +287,25 @@ func SQLSpan(query string) *pb.Span {
 	}
 }
 
+func TestCassandraResource(t *testing.T) {
+	ctx, cancelFunc := context.WithCancel(context.Background())
+	defer cancelFunc()
+	cfg := config.New()
+	cfg.Endpoints[0].APIKey = "test"
+	cfg.Obfuscation.Cassandra.Enabled = true
+	agnt := NewAgent(ctx, cfg, telemetry.NewNoopCollector())
+
+	query := "SELECT * FROM users WHERE id = 550e8400-e29b-41d4-a716-446655440000 AND tags CONTAINS {'k': 'v'}"
+	span := &pb.Span{Type: "cassandra", Resource: query}
+	agnt.obfuscateSpan(span)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ? AND tags CONTAINS ?", span.Resource)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ? AND tags CONTAINS ?", span.Meta["sql.query"])
+
+	stats := &pb.ClientGroupedStats{Type: "cassandra", Resource: query}
+	agnt.obfuscateStatsGroup(stats)
+	assert.Equal(t, "SELECT * FROM users WHERE id = ? AND tags CONTAINS ?", stats.Resource)
+}
+
 func TestSQLResourceQuery(t *testing.T) {
 	assert := assert.New(t)
 	span := &pb.Span{
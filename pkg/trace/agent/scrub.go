@@ -0,0 +1,155 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agent
+
+import (
+	"path"
+	"strings"
+
+	"github.com/tinylib/msgp/msgp"
+
+	"github.com/DataDog/datadog-agent/pkg/obfuscate"
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+	"github.com/DataDog/datadog-agent/pkg/trace/log"
+	"github.com/DataDog/datadog-agent/pkg/trace/metrics"
+	"github.com/DataDog/datadog-agent/pkg/trace/traceutil"
+)
+
+// scrubber scrubs sensitive values from span Meta and MetaStruct based on a set of
+// configured rules. Rules are expected to be compiled.
+type scrubber struct {
+	rules []*config.ScrubRule
+	luhn  bool
+	// hitTags holds the tags of the hit counter of each rule, by index.
+	hitTags [][]string
+}
+
+// newScrubber returns a new scrubber for the given configuration, or nil if no
+// scrub rules are configured.
+func newScrubber(cfg *config.ObfuscationConfig) *scrubber {
+	if cfg == nil || len(cfg.ScrubRules) == 0 {
+		return nil
+	}
+	s := &scrubber{
+		rules:   cfg.ScrubRules,
+		luhn:    cfg.CreditCards.Luhn,
+		hitTags: make([][]string, len(cfg.ScrubRules)),
+	}
+	for i, r := range cfg.ScrubRules {
+		s.hitTags[i] = []string{"rule:" + r.Name}
+	}
+	return s
+}
+
+// matchesKey reports whether rule r applies to the tag with key k.
+func matchesKey(r *config.ScrubRule, k string) bool {
+	if len(r.Keys) == 0 {
+		return true
+	}
+	for _, pattern := range r.Keys {
+		if ok, _ := path.Match(pattern, k); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// scrubValue applies rule r to the value v of the tag with key k. It returns the
+// new value and whether it was changed.
+func (s *scrubber) scrubValue(r *config.ScrubRule, k, v string) (string, bool) {
+	if strings.HasPrefix(k, "_dd") || !matchesKey(r, k) {
+		return v, false
+	}
+	switch {
+	case r.Re != nil:
+		if !r.Re.MatchString(v) {
+			return v, false
+		}
+		return r.Re.ReplaceAllString(v, r.Repl), true
+	case r.Builtin == "credit_card":
+		if !obfuscate.IsCardNumber(v, s.luhn) {
+			return v, false
+		}
+		return r.Repl, true
+	default:
+		// key-only rule
+		if v == r.Repl {
+			return v, false
+		}
+		return r.Repl, true
+	}
+}
+
+// scrubSpan scrubs the Meta and MetaStruct of span and counts the hits of each rule.
+func (s *scrubber) scrubSpan(span *pb.Span) {
+	if s == nil {
+		return
+	}
+	for i, r := range s.rules {
+		var hits int64
+		for k, v := range span.Meta {
+			if nv, ok := s.scrubValue(r, k, v); ok {
+				span.Meta[k] = nv
+				hits++
+			}
+		}
+		s.countHits(i, hits)
+	}
+	for k, raw := range span.MetaStruct {
+		val, _, err := msgp.ReadIntfBytes(raw)
+		if err != nil {
+			continue
+		}
+		var changed bool
+		for i, r := range s.rules {
+			var hits int64
+			val, hits = s.scrubStruct(r, k, val)
+			s.countHits(i, hits)
+			changed = changed || hits > 0
+		}
+		if !changed {
+			continue
+		}
+		if err := traceutil.SetMetaStruct(span, k, val); err != nil {
+			log.Debugf("Error encoding scrubbed meta_struct %q, removing it: %v", k, err)
+			delete(span.MetaStruct, k)
+		}
+	}
+}
+
+// countHits reports n hits of the rule at index i.
+func (s *scrubber) countHits(i int, n int64) {
+	if n > 0 {
+		_ = metrics.Count("datadog.trace_agent.obfuscation.scrub_hits", n, s.hitTags[i], 1)
+	}
+}
+
+// scrubStruct applies rule r to all the string values found in the decoded
+// MetaStruct value v, whose key is k. Nested keys are joined with dots. It returns
+// the new value along with the number of scrubbed strings.
+func (s *scrubber) scrubStruct(r *config.ScrubRule, k string, v interface{}) (interface{}, int64) {
+	var hits int64
+	switch vv := v.(type) {
+	case string:
+		if nv, ok := s.scrubValue(r, k, vv); ok {
+			return nv, 1
+		}
+	case map[string]interface{}:
+		for kk, e := range vv {
+			var n int64
+			vv[kk], n = s.scrubStruct(r, k+"."+kk, e)
+			hits += n
+		}
+	case []interface{}:
+		for i, e := range vv {
+			var n int64
+			vv[i], n = s.scrubStruct(r, k, e)
+			hits += n
+		}
+	}
+	return v, hits
+}
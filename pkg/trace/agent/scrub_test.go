@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package agent
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+	"github.com/DataDog/datadog-agent/pkg/trace/metrics"
+	"github.com/DataDog/datadog-agent/pkg/trace/teststatsd"
+	"github.com/DataDog/datadog-agent/pkg/trace/traceutil"
+)
+
+func testScrubber() *scrubber {
+	return newScrubber(&config.ObfuscationConfig{
+		CreditCards: config.CreditCardsConfig{Luhn: true},
+		ScrubRules: []*config.ScrubRule{
+			{Name: "passwords", Keys: []string{"*.password", "http.request.headers.authorization"}, Repl: "?"},
+			{Name: "emails", Builtin: "email", Re: regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`), Repl: "<email>"},
+			{Name: "cards", Builtin: "credit_card", Repl: "?"},
+			{Name: "tokens", Keys: []string{"http.url"}, Re: regexp.MustCompile(`token=[^&]*`), Repl: "token=?"},
+		},
+	})
+}
+
+func TestNewScrubber(t *testing.T) {
+	assert.Nil(t, newScrubber(nil))
+	assert.Nil(t, newScrubber(&config.ObfuscationConfig{}))
+	var s *scrubber
+	assert.NotPanics(t, func() { s.scrubSpan(&pb.Span{Meta: map[string]string{"db.password": "secret"}}) })
+}
+
+func TestScrubSpanMeta(t *testing.T) {
+	statsclient := &teststatsd.Client{}
+	defer func(old metrics.StatsClient) { metrics.Client = old }(metrics.Client)
+	metrics.Client = statsclient
+
+	span := &pb.Span{Meta: map[string]string{
+		"db.password":                        "hunter2",
+		"http.request.headers.authorization": "Bearer abc",
+		"password":                           "kept, no dot",
+		"user.contact":                       "jane.doe@example.com, john@example.org",
+		"payment.card":                       "4111 1111 1111 1111",
+		"http.url":                           "http://example.com/?token=abc&page=2",
+		"other.url":                          "http://example.com/?token=abc",
+		"_dd.p.dm":                           "-4",
+	}}
+	testScrubber().scrubSpan(span)
+	assert.Equal(t, map[string]string{
+		"db.password":                        "?",
+		"http.request.headers.authorization": "?",
+		"password":                           "kept, no dot",
+		"user.contact":                       "<email>, <email>",
+		"payment.card":                       "?",
+		"http.url":                           "http://example.com/?token=?&page=2",
+		"other.url":                          "http://example.com/?token=abc",
+		"_dd.p.dm":                           "-4",
+	}, span.Meta)
+
+	hits := map[string]float64{}
+	for _, c := range statsclient.CountCalls {
+		assert.Equal(t, "datadog.trace_agent.obfuscation.scrub_hits", c.Name)
+		hits[c.Tags[0]] += c.Value
+	}
+	assert.Equal(t, map[string]float64{
+		"rule:passwords": 2,
+		"rule:emails":    1,
+		"rule:cards":     1,
+		"rule:tokens":    1,
+	}, hits)
+}
+
+func TestScrubSpanMetaStruct(t *testing.T) {
+	span := &pb.Span{}
+	require.NoError(t, traceutil.SetMetaStruct(span, "appsec", map[string]interface{}{
+		"user": map[string]interface{}{
+			"password": "hunter2",
+			"emails":   []interface{}{"jane.doe@example.com", "not an email"},
+		},
+		"count": 3,
+	}))
+	require.NoError(t, traceutil.SetMetaStruct(span, "untouched", map[string]interface{}{"name": "value"}))
+	untouched := span.MetaStruct["untouched"]
+
+	testScrubber().scrubSpan(span)
+	v, ok := traceutil.GetMetaStruct(span, "appsec")
+	require.True(t, ok)
+	assert.Equal(t, map[string]interface{}{
+		"user": map[string]interface{}{
+			"password": "?",
+			"emails":   []interface{}{"<email>", "not an email"},
+		},
+		"count": int64(3),
+	}, v)
+	assert.Equal(t, untouched, span.MetaStruct["untouched"])
+}
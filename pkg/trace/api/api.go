@@ -34,6 +34,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/trace/log"
 	"github.com/DataDog/datadog-agent/pkg/trace/metrics"
 	"github.com/DataDog/datadog-agent/pkg/trace/metrics/timing"
+	"github.com/DataDog/datadog-agent/pkg/trace/replay"
 	"github.com/DataDog/datadog-agent/pkg/trace/sampler"
 	"github.com/DataDog/datadog-agent/pkg/trace/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/trace/watchdog"
@@ -82,6 +83,9 @@ type HTTPReceiver struct {
 
 	// outOfCPUCounter is counter to throttle the out of cpu warning log
 	outOfCPUCounter *atomic.Uint32
+
+	// capture records incoming trace payloads to a file when a capture is ongoing.
+	capture *replay.Writer
 }
 
 // NewHTTPReceiver returns a pointer to a new HTTPReceiver
@@ -107,6 +111,8 @@ func NewHTTPReceiver(conf *config.AgentConfig, dynConf *sampler.DynamicConfig, o
 		exit: make(chan struct{}),
 
 		outOfCPUCounter: atomic.NewUint32(0),
+
+		capture: replay.NewWriter(),
 	}
 }
 
@@ -276,6 +282,7 @@ func (r *HTTPReceiver) Stop() error {
 		return err
 	}
 	r.wg.Wait()
+	r.capture.Stop()
 	close(r.out)
 	return nil
 }
@@ -293,10 +300,21 @@ func (r *HTTPReceiver) handleWithVersion(v Version, f func(Version, http.Respons
 			return
 		}
 
+		var captured *bytes.Buffer
+		if r.capture.Ongoing() {
+			captured = new(bytes.Buffer)
+			req.Body = teeReadCloser{Reader: io.TeeReader(req.Body, captured), Closer: req.Body}
+		}
+
 		// TODO(x): replace with http.MaxBytesReader?
-		req.Body = apiutil.NewLimitedReader(req.Body, r.conf.MaxRequestBytes)
+		lr := apiutil.NewLimitedReader(req.Body, r.conf.MaxRequestBytes)
+		req.Body = lr
 
 		f(v, w, req)
+
+		if captured != nil && lr.Count < r.conf.MaxRequestBytes {
+			r.captureRequest(req, captured.Bytes())
+		}
 	}
 }
 
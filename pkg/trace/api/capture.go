@@ -0,0 +1,76 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/api/internal/header"
+	"github.com/DataDog/datadog-agent/pkg/trace/log"
+	"github.com/DataDog/datadog-agent/pkg/trace/replay"
+)
+
+// defaultCaptureDuration is the capture duration used when none is specified.
+const defaultCaptureDuration = time.Minute
+
+// teeReadCloser is an io.ReadCloser reading from Reader and closing Closer.
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// captureRequest records the given request and its body into the ongoing capture.
+// The container ID is resolved and stored as a header so that payloads received
+// over UDS keep their container attribution when replayed.
+func (r *HTTPReceiver) captureRequest(req *http.Request, body []byte) {
+	h := req.Header.Clone()
+	if h.Get(header.ContainerID) == "" {
+		if cid := r.containerIDProvider.GetContainerID(req.Context(), req.Header); cid != "" {
+			h.Set(header.ContainerID, cid)
+		}
+	}
+	r.capture.Record(req.URL.Path, h, body)
+}
+
+// CaptureHandler returns an http.Handler controlling trace payload captures. A GET
+// request returns the status of the capture, while a POST request starts a new capture
+// for the duration given by the "duration" query parameter (e.g. "?duration=30s").
+// Captures are written to the directory set in apm_config.capture_path.
+func (r *HTTPReceiver) CaptureHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet:
+		case http.MethodPost:
+			d := defaultCaptureDuration
+			if v := req.URL.Query().Get("duration"); v != "" {
+				var err error
+				if d, err = time.ParseDuration(v); err != nil || d <= 0 {
+					http.Error(w, fmt.Sprintf("invalid duration %q", v), http.StatusBadRequest)
+					return
+				}
+			}
+			if _, err := r.capture.Start(r.conf.CaptureDir, d); err != nil {
+				status := http.StatusInternalServerError
+				if err == replay.ErrCaptureOngoing {
+					status = http.StatusConflict
+				}
+				http.Error(w, err.Error(), status)
+				return
+			}
+		default:
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(r.capture.Status()); err != nil {
+			log.Errorf("Error encoding capture status: %v", err)
+		}
+	})
+}
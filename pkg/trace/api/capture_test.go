@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/api/internal/header"
+	"github.com/DataDog/datadog-agent/pkg/trace/replay"
+)
+
+func TestCapture(t *testing.T) {
+	conf := newTestReceiverConfig()
+	conf.CaptureDir = t.TempDir()
+	r := newTestReceiverFromConfig(conf)
+	captureHandler := r.CaptureHandler()
+
+	rec := httptest.NewRecorder()
+	captureHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/capture?duration=invalid", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	rec = httptest.NewRecorder()
+	captureHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/capture?duration=1m", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var status replay.Status
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&status))
+	assert.True(t, status.Ongoing)
+
+	rec = httptest.NewRecorder()
+	captureHandler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/debug/capture", nil))
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	payload := []byte("[]")
+	req := httptest.NewRequest(http.MethodPost, "/v0.4/traces", bytes.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(header.Lang, "go")
+	req.Header.Set(header.ContainerID, "container-1")
+	rec = httptest.NewRecorder()
+	r.handleWithVersion(v04, r.handleTraces).ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	r.capture.Stop()
+
+	f, err := os.Open(status.Path)
+	require.NoError(t, err)
+	defer f.Close()
+	rd, err := replay.NewReader(f)
+	require.NoError(t, err)
+	captured, err := rd.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "/v0.4/traces", captured.Path)
+	assert.Equal(t, payload, captured.Body)
+	assert.Equal(t, "go", captured.Header.Get(header.Lang))
+	assert.Equal(t, "container-1", captured.Header.Get(header.ContainerID))
+	_, err = rd.Next()
+	assert.Equal(t, io.EOF, err)
+}
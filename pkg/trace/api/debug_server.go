@@ -31,15 +31,30 @@ const (
 type DebugServer struct {
 	conf   *config.AgentConfig
 	server *http.Server
+	mux    *http.ServeMux
+
+	samplingDecisions *SamplingDecisions
 }
 
 // NewDebugServer returns a debug server
 func NewDebugServer(conf *config.AgentConfig) *DebugServer {
 	return &DebugServer{
-		conf: conf,
+		conf:              conf,
+		mux:               http.NewServeMux(),
+		samplingDecisions: NewSamplingDecisions(),
 	}
 }
 
+// SamplingDecisions returns the recent sampling decisions served at /debug/sampling.
+func (ds *DebugServer) SamplingDecisions() *SamplingDecisions {
+	return ds.samplingDecisions
+}
+
+// AddRoute adds a route to the debug server. It must be called before Start.
+func (ds *DebugServer) AddRoute(route string, handler http.Handler) {
+	ds.mux.Handle(route, handler)
+}
+
 // Start configures and starts the http server
 func (ds *DebugServer) Start() {
 	if ds.conf.DebugServerPort == 0 {
@@ -49,7 +64,7 @@ func (ds *DebugServer) Start() {
 	ds.server = &http.Server{
 		ReadTimeout:  defaultTimeout,
 		WriteTimeout: defaultTimeout,
-		Handler:      ds.mux(),
+		Handler:      ds.setupMux(),
 	}
 	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", ds.conf.DebugServerPort))
 	if err != nil {
@@ -75,8 +90,8 @@ func (ds *DebugServer) Stop() {
 	}
 }
 
-func (ds *DebugServer) mux() *http.ServeMux {
-	mux := http.NewServeMux()
+func (ds *DebugServer) setupMux() *http.ServeMux {
+	mux := ds.mux
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
 	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
@@ -103,6 +118,7 @@ func (ds *DebugServer) mux() *http.ServeMux {
 		pprof.Handler("block").ServeHTTP(w, r)
 		runtime.SetBlockProfileRate(0)
 	})
+	mux.Handle("/debug/sampling", ds.samplingDecisions)
 	mux.Handle("/debug/vars", http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 		// allow the GUI to call this endpoint so that the status can be reported
 		w.Header().Set("Access-Control-Allow-Origin", "http://127.0.0.1:"+ds.conf.GUIPort)
@@ -7,7 +7,11 @@
 
 package api
 
-import "github.com/DataDog/datadog-agent/pkg/trace/config"
+import (
+	"net/http"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+)
 
 type DebugServer struct{}
 
@@ -17,3 +21,7 @@ func NewDebugServer(conf *config.AgentConfig) *DebugServer {
 
 func (*DebugServer) Start() {}
 func (*DebugServer) Stop()  {}
+
+func (*DebugServer) AddRoute(route string, handler http.Handler) {}
+
+func (*DebugServer) SamplingDecisions() *SamplingDecisions { return nil }
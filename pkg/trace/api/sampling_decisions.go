@@ -0,0 +1,191 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+)
+
+const (
+	// samplingDecisionsSize is the number of most recent sampling decisions kept in memory.
+	samplingDecisionsSize = 1000
+
+	// samplingDecisionsTTL is the amount of time during which sampling decisions are
+	// recorded after the last request to the endpoint serving them.
+	samplingDecisionsTTL = time.Minute
+)
+
+// SamplingDecision describes the sampling decision made by the agent for a trace chunk.
+type SamplingDecision struct {
+	// Seq is the sequence number of the decision, strictly increasing.
+	Seq uint64 `json:"seq"`
+	// Time is the time at which the decision was made.
+	Time time.Time `json:"time"`
+	// TraceID is the ID of the trace.
+	TraceID uint64 `json:"trace_id"`
+	// Env is the environment of the trace.
+	Env string `json:"env"`
+	// Service is the service of the root span.
+	Service string `json:"service"`
+	// Resource is the resource of the root span.
+	Resource string `json:"resource"`
+	// Priority is the sampling priority of the chunk, sampler.PriorityNone if unset.
+	Priority int32 `json:"priority"`
+	// Sampler is the name of the sampler which made the decision.
+	Sampler string `json:"sampler"`
+	// Rate is the sampling rate applied by Sampler.
+	Rate float64 `json:"rate"`
+	// Kept reports whether the trace was kept.
+	Kept bool `json:"kept"`
+}
+
+// SamplingDecisions holds the most recent sampling decisions made by the agent.
+// To avoid any overhead when nobody is looking, decisions are only recorded for a
+// short while after they were last requested. A nil *SamplingDecisions is valid
+// and never records anything.
+type SamplingDecisions struct {
+	activeUntil *atomic.Int64 // unix nanoseconds
+
+	mu        sync.Mutex // guards below fields
+	seq       uint64
+	decisions []SamplingDecision // ring buffer, the decision with sequence number s is at (s-1) % samplingDecisionsSize
+}
+
+// NewSamplingDecisions returns a new, inactive, SamplingDecisions.
+func NewSamplingDecisions() *SamplingDecisions {
+	return &SamplingDecisions{
+		activeUntil: atomic.NewInt64(0),
+		decisions:   make([]SamplingDecision, 0, samplingDecisionsSize),
+	}
+}
+
+// Active reports whether decisions made at time now should be recorded. It is cheap
+// to call and is meant to be used on the hot path before building a decision.
+func (sd *SamplingDecisions) Active(now time.Time) bool {
+	return sd != nil && now.UnixNano() < sd.activeUntil.Load()
+}
+
+// Record records the decision d, setting its sequence number.
+func (sd *SamplingDecisions) Record(d SamplingDecision) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	sd.seq++
+	d.Seq = sd.seq
+	if len(sd.decisions) < samplingDecisionsSize {
+		sd.decisions = append(sd.decisions, d)
+		return
+	}
+	sd.decisions[(d.Seq-1)%samplingDecisionsSize] = d
+}
+
+// samplingDecisionFilter filters sampling decisions based on the query parameters of a request.
+type samplingDecisionFilter struct {
+	since    uint64
+	traceID  uint64
+	env      string
+	service  string
+	resource string
+	sampler  string
+	kept     *bool
+}
+
+func parseSamplingDecisionFilter(req *http.Request) (*samplingDecisionFilter, error) {
+	q := req.URL.Query()
+	f := samplingDecisionFilter{
+		env:      q.Get("env"),
+		service:  q.Get("service"),
+		resource: q.Get("resource"),
+		sampler:  q.Get("sampler"),
+	}
+	var err error
+	if v := q.Get("since"); v != "" {
+		if f.since, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if v := q.Get("trace_id"); v != "" {
+		if f.traceID, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return nil, err
+		}
+	}
+	if v := q.Get("kept"); v != "" {
+		kept, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, err
+		}
+		f.kept = &kept
+	}
+	return &f, nil
+}
+
+func (f *samplingDecisionFilter) matches(d *SamplingDecision) bool {
+	switch {
+	case d.Seq <= f.since:
+		return false
+	case f.traceID != 0 && d.TraceID != f.traceID:
+		return false
+	case f.env != "" && d.Env != f.env:
+		return false
+	case f.service != "" && d.Service != f.service:
+		return false
+	case f.resource != "" && d.Resource != f.resource:
+		return false
+	case f.sampler != "" && d.Sampler != f.sampler:
+		return false
+	case f.kept != nil && d.Kept != *f.kept:
+		return false
+	}
+	return true
+}
+
+// SamplingDecisionsResponse is the response of the sampling decisions endpoint.
+type SamplingDecisionsResponse struct {
+	// Next holds the value of the "since" parameter to use for getting the
+	// decisions following the ones in this response.
+	Next uint64 `json:"next"`
+	// Decisions holds the matching decisions, oldest first.
+	Decisions []SamplingDecision `json:"decisions"`
+}
+
+// ServeHTTP serves the recorded decisions matching the filters given as query
+// parameters: since, trace_id, env, service, resource, sampler and kept. Each call
+// keeps the recording active for another samplingDecisionsTTL.
+func (sd *SamplingDecisions) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	f, err := parseSamplingDecisionFilter(req)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	sd.activeUntil.Store(time.Now().Add(samplingDecisionsTTL).UnixNano())
+
+	resp := SamplingDecisionsResponse{Decisions: []SamplingDecision{}}
+	sd.mu.Lock()
+	resp.Next = sd.seq
+	if f.since > sd.seq {
+		// the agent was restarted since the client last called
+		f.since = 0
+	}
+	n := len(sd.decisions)
+	for i := 0; i < n; i++ {
+		// iterate from the oldest decision onwards
+		d := &sd.decisions[(sd.seq+uint64(i))%uint64(n)]
+		if f.matches(d) {
+			resp.Decisions = append(resp.Decisions, *d)
+		}
+	}
+	sd.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getSamplingDecisions(t *testing.T, sd *SamplingDecisions, query string) SamplingDecisionsResponse {
+	rec := httptest.NewRecorder()
+	sd.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/sampling?"+query, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp SamplingDecisionsResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp
+}
+
+func TestSamplingDecisions(t *testing.T) {
+	var nilDecisions *SamplingDecisions
+	assert.False(t, nilDecisions.Active(time.Now()))
+
+	sd := NewSamplingDecisions()
+	assert.False(t, sd.Active(time.Now()))
+	resp := getSamplingDecisions(t, sd, "")
+	assert.True(t, sd.Active(time.Now()))
+	assert.EqualValues(t, 0, resp.Next)
+	assert.Empty(t, resp.Decisions)
+
+	sd.Record(SamplingDecision{TraceID: 1, Service: "web", Sampler: "priority", Kept: true})
+	sd.Record(SamplingDecision{TraceID: 2, Service: "db", Sampler: "errors", Kept: false})
+	sd.Record(SamplingDecision{TraceID: 3, Service: "web", Sampler: "rare", Kept: true})
+
+	resp = getSamplingDecisions(t, sd, "since=0")
+	assert.EqualValues(t, 3, resp.Next)
+	require.Len(t, resp.Decisions, 3)
+	for i, d := range resp.Decisions {
+		assert.EqualValues(t, i+1, d.Seq)
+		assert.EqualValues(t, i+1, d.TraceID)
+	}
+
+	for query, want := range map[string][]uint64{
+		"since=1":                  {2, 3},
+		"since=3":                  {},
+		"since=42":                 {1, 2, 3},
+		"service=web":              {1, 3},
+		"sampler=errors":           {2},
+		"kept=false":               {2},
+		"trace_id=3":               {3},
+		"service=web&sampler=rare": {3},
+	} {
+		resp = getSamplingDecisions(t, sd, query)
+		got := []uint64{}
+		for _, d := range resp.Decisions {
+			got = append(got, d.TraceID)
+		}
+		assert.Equal(t, want, got, query)
+	}
+
+	rec := httptest.NewRecorder()
+	sd.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/sampling?kept=maybe", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSamplingDecisionsWrap(t *testing.T) {
+	sd := NewSamplingDecisions()
+	for i := 1; i <= samplingDecisionsSize+10; i++ {
+		sd.Record(SamplingDecision{TraceID: uint64(i)})
+	}
+	resp := getSamplingDecisions(t, sd, "")
+	assert.EqualValues(t, samplingDecisionsSize+10, resp.Next)
+	require.Len(t, resp.Decisions, samplingDecisionsSize)
+	assert.EqualValues(t, 11, resp.Decisions[0].TraceID)
+	assert.EqualValues(t, samplingDecisionsSize+10, resp.Decisions[samplingDecisionsSize-1].TraceID)
+
+	resp = getSamplingDecisions(t, sd, "since=1005")
+	require.Len(t, resp.Decisions, 5)
+	assert.EqualValues(t, 1006, resp.Decisions[0].Seq)
+}
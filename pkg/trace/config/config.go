@@ -105,8 +105,20 @@ type ObfuscationConfig struct {
 	// for spans of type "memcached".
 	Memcached Enablable `mapstructure:"memcached"`
 
+	// Cassandra holds the configuration for obfuscating the resource and the
+	// "cassandra.query" tag of spans of type "cassandra" using CQL specific rules.
+	Cassandra Enablable `mapstructure:"cassandra"`
+
+	// DynamoDB holds the obfuscation configuration for the "dynamodb.query" and
+	// "db.statement" tags of spans of type "dynamodb".
+	DynamoDB JSONObfuscationConfig `mapstructure:"dynamodb"`
+
 	// CreditCards holds the configuration for obfuscating credit cards.
 	CreditCards CreditCardsConfig `mapstructure:"credit_cards"`
+
+	// ScrubRules holds rules scrubbing sensitive values from span Meta and MetaStruct,
+	// regardless of the span type.
+	ScrubRules []*ScrubRule `mapstructure:"scrub_rules"`
 }
 
 // Export returns an obfuscate.Config matching o.
@@ -147,6 +159,11 @@ func (o *ObfuscationConfig) Export(conf *AgentConfig) obfuscate.Config {
 			Enabled:       o.Redis.Enabled,
 			RemoveAllArgs: o.Redis.RemoveAllArgs,
 		},
+		DynamoDB: obfuscate.JSONConfig{
+			Enabled:            o.DynamoDB.Enabled,
+			KeepValues:         o.DynamoDB.KeepValues,
+			ObfuscateSQLValues: o.DynamoDB.ObfuscateSQLValues,
+		},
 		Logger: new(debugLogger),
 	}
 }
@@ -169,6 +186,33 @@ type CreditCardsConfig struct {
 	Luhn bool `mapstructure:"luhn"`
 }
 
+// ScrubRule specifies a rule scrubbing sensitive values from span tags. A rule matches
+// tags by key, by value, or both.
+type ScrubRule struct {
+	// Name identifies the rule in the hit counters reported by the agent.
+	Name string `mapstructure:"name"`
+
+	// Keys holds glob patterns (e.g. "*.password") matching the keys of the tags
+	// this rule applies to. Nested MetaStruct keys are joined with dots. When empty,
+	// the rule applies to all tags. When Pattern and Builtin are both empty, the
+	// whole value of matching tags is replaced.
+	Keys []string `mapstructure:"keys"`
+
+	// Pattern specifies a regular expression whose matches in tag values are replaced.
+	Pattern string `mapstructure:"pattern"`
+
+	// Builtin specifies a predefined value detector to use instead of Pattern. It is one
+	// of "credit_card", "email" or "jwt". Credit card numbers are detected using the
+	// credit_cards settings and replace the whole value.
+	Builtin string `mapstructure:"builtin"`
+
+	// Repl specifies the replacement string. It defaults to "?".
+	Repl string `mapstructure:"repl"`
+
+	// Re holds the compiled Pattern, or the one of Builtin, and is only used internally.
+	Re *regexp.Regexp `mapstructure:"-"`
+}
+
 // HTTPObfuscationConfig holds the configuration settings for HTTP obfuscation.
 type HTTPObfuscationConfig struct {
 	// RemoveQueryStrings determines query strings to be removed from HTTP URLs.
@@ -232,6 +276,33 @@ type ReplaceRule struct {
 	Repl string `mapstructure:"repl"`
 }
 
+// SpanMetricRule specifies a rule for emitting the duration of matching spans as a
+// distribution metric through the agent's statsd client. The rules only apply to
+// the spans of the traces the agent computes stats for: traces whose stats were
+// computed by the tracer never reach them.
+type SpanMetricRule struct {
+	// Name specifies the name of the emitted metric. It is required.
+	Name string `mapstructure:"name"`
+
+	// Service, when set, restricts the rule to spans of this service.
+	Service string `mapstructure:"service"`
+
+	// Operation, when set, restricts the rule to spans with this operation name.
+	Operation string `mapstructure:"operation"`
+
+	// MatchTags restricts the rule to spans having all of these tags. An empty
+	// value only requires the tag to be present.
+	MatchTags map[string]string `mapstructure:"match_tags"`
+
+	// TagKeys lists the span tags which are added as tags to the emitted metric,
+	// in addition to env, service and error.
+	TagKeys []string `mapstructure:"tag_keys"`
+
+	// IncludeResource adds the resource of the span as a tag to the emitted
+	// metric. Resources can have a high cardinality, so it is disabled by default.
+	IncludeResource bool `mapstructure:"include_resource"`
+}
+
 // WriterConfig specifies configuration for an API writer.
 type WriterConfig struct {
 	// ConnectionLimit specifies the maximum number of concurrent outgoing
@@ -331,10 +402,11 @@ type AgentConfig struct {
 	Endpoints []*Endpoint
 
 	// Concentrator
-	BucketInterval         time.Duration // the size of our pre-aggregation per bucket
-	ExtraAggregators       []string      // DEPRECATED
-	PeerServiceAggregation bool          // enables/disables stats aggregation for peer.service, used by Concentrator and ClientStatsAggregator
-	ComputeStatsBySpanKind bool          // enables/disables the computing of stats based on a span's `span.kind` field
+	BucketInterval         time.Duration     // the size of our pre-aggregation per bucket
+	ExtraAggregators       []string          // DEPRECATED
+	PeerServiceAggregation bool              // enables/disables stats aggregation for peer.service, used by Concentrator and ClientStatsAggregator
+	ComputeStatsBySpanKind bool              // enables/disables the computing of stats based on a span's `span.kind` field
+	SpanMetrics            []*SpanMetricRule // rules deriving distribution metrics from span durations, used by Concentrator
 
 	// Sampler configuration
 	ExtraSampleRate float64
@@ -458,6 +530,9 @@ type AgentConfig struct {
 
 	// DebugServerPort defines the port used by the debug server
 	DebugServerPort int
+
+	// CaptureDir specifies the directory where trace payload captures are written.
+	CaptureDir string
 }
 
 // RemoteClient client is used to APM Sampling Updates from a remote source.
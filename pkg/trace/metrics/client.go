@@ -20,6 +20,13 @@ type StatsClient interface {
 	Flush() error
 }
 
+// DistributionClient is implemented by the StatsClients capable of sending
+// distributions, such as the statsd client. It's separate from StatsClient, so
+// that the existing implementations of StatsClient keep satisfying it.
+type DistributionClient interface {
+	Distribution(name string, value float64, tags []string, rate float64) error
+}
+
 // Client is a global Statsd client. When a client is configured via Configure,
 // that becomes the new global Statsd client in the package.
 var Client StatsClient = (*statsd.Client)(nil)
@@ -56,6 +63,16 @@ func Timing(name string, value time.Duration, tags []string, rate float64) error
 	return Client.Timing(name, value, tags, rate)
 }
 
+// Distribution calls Distribution on the global Client, if set and capable of
+// sending distributions.
+func Distribution(name string, value float64, tags []string, rate float64) error {
+	client, ok := Client.(DistributionClient)
+	if !ok {
+		return nil // no-op
+	}
+	return client.Distribution(name, value, tags, rate)
+}
+
 // Flush flushes any pending metrics to the agent.
 func Flush() error {
 	if Client == nil {
@@ -37,11 +37,21 @@ func (ts *testStatsClient) Timing(name string, value time.Duration, tags []strin
 	return nil
 }
 
+func (ts *testStatsClient) Distribution(name string, value float64, tags []string, rate float64) error {
+	ts.counts.Inc()
+	return nil
+}
+
 func (ts *testStatsClient) Flush() error {
 	ts.counts.Inc()
 	return nil
 }
 
+// noDistributionStatsClient is a StatsClient which can't send distributions.
+type noDistributionStatsClient struct {
+	StatsClient
+}
+
 func TestForwarding(t *testing.T) {
 	t.Run("nil", func(t *testing.T) {
 		defer func(old StatsClient) { Client = old }(Client)
@@ -50,6 +60,7 @@ func TestForwarding(t *testing.T) {
 		assert.NoError(t, Count("stat", 1, nil, 1))
 		assert.NoError(t, Histogram("stat", 1, nil, 1))
 		assert.NoError(t, Timing("stat", time.Second, nil, 1))
+		assert.NoError(t, Distribution("stat", 1, nil, 1))
 		assert.NoError(t, Flush())
 	})
 
@@ -61,7 +72,17 @@ func TestForwarding(t *testing.T) {
 		assert.NoError(t, Count("stat", 1, nil, 1))
 		assert.NoError(t, Histogram("stat", 1, nil, 1))
 		assert.NoError(t, Timing("stat", time.Second, nil, 1))
+		assert.NoError(t, Distribution("stat", 1, nil, 1))
 		assert.NoError(t, Flush())
-		assert.Equal(t, testclient.counts.Load(), int64(5))
+		assert.Equal(t, testclient.counts.Load(), int64(6))
+	})
+
+	t.Run("no-distribution", func(t *testing.T) {
+		defer func(old StatsClient) { Client = old }(Client)
+		var testclient testStatsClient
+		Client = noDistributionStatsClient{&testclient}
+		assert.NoError(t, Gauge("stat", 1, nil, 1))
+		assert.NoError(t, Distribution("stat", 1, nil, 1))
+		assert.Equal(t, testclient.counts.Load(), int64(1))
 	})
 }
@@ -0,0 +1,128 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package replay implements capturing incoming trace payloads to a file and
+// replaying them against a running trace-agent.
+//
+// A capture file starts with a fixed header followed by a sequence of records.
+// Each record is laid out as follows (integers are little endian):
+//
+//	int64  offset of the request from the start of the capture, in nanoseconds
+//	uint32 length of the request metadata
+//	[]byte JSON encoded request metadata (path and HTTP headers)
+//	uint32 length of the request body
+//	[]byte request body, as sent by the tracer
+package replay
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// fileHeader is written at the beginning of every capture file.
+var fileHeader = []byte("DDTRCAP1")
+
+// maxFieldSize limits the size of any field read from a capture file, to avoid
+// allocating unbounded memory when reading corrupted files.
+const maxFieldSize = 256 * 1024 * 1024
+
+// ErrInvalidHeader is returned when a file does not start with a valid capture header.
+var ErrInvalidHeader = errors.New("invalid capture file header")
+
+// Record holds a single captured request.
+type Record struct {
+	// Offset is the time elapsed between the start of the capture and the reception
+	// of the request.
+	Offset time.Duration
+	// Path is the URL path the request was sent to (e.g. /v0.4/traces).
+	Path string
+	// Header holds the HTTP headers of the request.
+	Header http.Header
+	// Body holds the raw request body.
+	Body []byte
+}
+
+// recordMeta is the JSON encoded part of a record.
+type recordMeta struct {
+	Path   string      `json:"path"`
+	Header http.Header `json:"header"`
+}
+
+func writeRecord(w io.Writer, rec *Record) error {
+	meta, err := json.Marshal(recordMeta{Path: rec.Path, Header: rec.Header})
+	if err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, int64(rec.Offset)); err != nil {
+		return err
+	}
+	if err := writeField(w, meta); err != nil {
+		return err
+	}
+	return writeField(w, rec.Body)
+}
+
+func writeField(w io.Writer, b []byte) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(b))); err != nil {
+		return err
+	}
+	_, err := w.Write(b)
+	return err
+}
+
+func readRecord(r io.Reader) (*Record, error) {
+	var offset int64
+	if err := binary.Read(r, binary.LittleEndian, &offset); err != nil {
+		// io.EOF here means that there are no more records
+		return nil, err
+	}
+	meta, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	var m recordMeta
+	if err := json.Unmarshal(meta, &m); err != nil {
+		return nil, fmt.Errorf("error decoding record metadata: %v", err)
+	}
+	body, err := readField(r)
+	if err != nil {
+		return nil, err
+	}
+	return &Record{
+		Offset: time.Duration(offset),
+		Path:   m.Path,
+		Header: m.Header,
+		Body:   body,
+	}, nil
+}
+
+func readField(r io.Reader) ([]byte, error) {
+	var n uint32
+	if err := binary.Read(r, binary.LittleEndian, &n); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if n > maxFieldSize {
+		return nil, fmt.Errorf("record field too large: %d bytes", n)
+	}
+	b := make([]byte, n)
+	if _, err := io.ReadFull(r, b); err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	return b, nil
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF. It is used when
+// a record was only partially read.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,110 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package replay
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Reader reads records from a capture file.
+type Reader struct {
+	r *bufio.Reader
+}
+
+// NewReader returns a new Reader reading from r. It returns ErrInvalidHeader if r
+// does not hold a capture.
+func NewReader(r io.Reader) (*Reader, error) {
+	br := bufio.NewReader(r)
+	hdr := make([]byte, len(fileHeader))
+	if _, err := io.ReadFull(br, hdr); err != nil || !bytes.Equal(hdr, fileHeader) {
+		return nil, ErrInvalidHeader
+	}
+	return &Reader{r: br}, nil
+}
+
+// Next returns the next record from the capture. It returns io.EOF when there
+// are no more records.
+func (r *Reader) Next() (*Record, error) {
+	return readRecord(r.r)
+}
+
+// Replayer sends captured requests to a trace-agent.
+type Replayer struct {
+	// Target is the base URL of the receiving trace-agent (e.g. http://localhost:8126).
+	Target string
+	// Speed is the replay speed multiplier: 1 replays requests at their original pace,
+	// 2 twice as fast, and so on. A value of 0 or lower replays as fast as possible.
+	Speed float64
+	// Client is the HTTP client used to send requests. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// Replay sends all records from r to the target, respecting the original timing
+// adjusted by the replay speed. It returns the number of requests sent.
+func (rp *Replayer) Replay(ctx context.Context, r *Reader) (int, error) {
+	client := rp.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	target := strings.TrimSuffix(rp.Target, "/")
+	start := time.Now()
+	var n int
+	for {
+		rec, err := r.Next()
+		if err == io.EOF {
+			return n, nil
+		}
+		if err != nil {
+			return n, err
+		}
+		if rp.Speed > 0 {
+			due := start.Add(time.Duration(float64(rec.Offset) / rp.Speed))
+			if wait := time.Until(due); wait > 0 {
+				select {
+				case <-ctx.Done():
+					return n, ctx.Err()
+				case <-time.After(wait):
+				}
+			}
+		}
+		if err := rp.send(ctx, client, target, rec); err != nil {
+			return n, err
+		}
+		n++
+	}
+}
+
+func (rp *Replayer) send(ctx context.Context, client *http.Client, target string, rec *Record) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target+rec.Path, bytes.NewReader(rec.Body))
+	if err != nil {
+		return err
+	}
+	for k, vs := range rec.Header {
+		if http.CanonicalHeaderKey(k) == "Content-Length" {
+			continue
+		}
+		for _, v := range vs {
+			req.Header.Add(k, v)
+		}
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body) //nolint:errcheck
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("replaying request to %s: unexpected status %s", rec.Path, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package replay
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriterReader(t *testing.T) {
+	w := NewWriter()
+	assert.False(t, w.Ongoing())
+	w.Record("/v0.4/traces", nil, []byte("ignored"))
+
+	path, err := w.Start(t.TempDir(), time.Minute)
+	require.NoError(t, err)
+	assert.True(t, w.Ongoing())
+	_, err = w.Start(t.TempDir(), time.Minute)
+	assert.Equal(t, ErrCaptureOngoing, err)
+
+	w.Record("/v0.4/traces", http.Header{"Datadog-Meta-Lang": []string{"go"}}, []byte("payload1"))
+	w.Record("/v0.5/traces", http.Header{"Datadog-Container-Id": []string{"abc"}}, []byte("payload2"))
+	status := w.Status()
+	assert.True(t, status.Ongoing)
+	assert.EqualValues(t, 2, status.Records)
+	assert.Equal(t, path, status.Path)
+	w.Stop()
+	assert.False(t, w.Ongoing())
+	w.Record("/v0.4/traces", nil, []byte("ignored"))
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	r, err := NewReader(f)
+	require.NoError(t, err)
+
+	rec, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "/v0.4/traces", rec.Path)
+	assert.Equal(t, "go", rec.Header.Get("Datadog-Meta-Lang"))
+	assert.Equal(t, []byte("payload1"), rec.Body)
+
+	rec2, err := r.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "/v0.5/traces", rec2.Path)
+	assert.Equal(t, "abc", rec2.Header.Get("Datadog-Container-Id"))
+	assert.Equal(t, []byte("payload2"), rec2.Body)
+	assert.True(t, rec2.Offset >= rec.Offset)
+
+	_, err = r.Next()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestWriterStopsAfterDuration(t *testing.T) {
+	w := NewWriter()
+	_, err := w.Start(t.TempDir(), 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool { return !w.Ongoing() }, time.Second, 5*time.Millisecond)
+}
+
+func TestReaderInvalid(t *testing.T) {
+	_, err := NewReader(bytes.NewReader([]byte("not a capture")))
+	assert.Equal(t, ErrInvalidHeader, err)
+
+	var buf bytes.Buffer
+	buf.Write(fileHeader)
+	require.NoError(t, writeRecord(&buf, &Record{Path: "/v0.4/traces", Body: []byte("abc")}))
+	truncated := buf.Bytes()[:buf.Len()-1]
+	r, err := NewReader(bytes.NewReader(truncated))
+	require.NoError(t, err)
+	_, err = r.Next()
+	assert.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestReplayer(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		mu.Lock()
+		received = append(received, req.URL.Path+" "+req.Header.Get("Datadog-Meta-Lang")+" "+string(body))
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	buf.Write(fileHeader)
+	for _, rec := range []*Record{
+		{Offset: 0, Path: "/v0.4/traces", Header: http.Header{"Datadog-Meta-Lang": []string{"go"}}, Body: []byte("a")},
+		{Offset: 200 * time.Millisecond, Path: "/v0.5/traces", Header: http.Header{"Datadog-Meta-Lang": []string{"python"}}, Body: []byte("b")},
+	} {
+		require.NoError(t, writeRecord(&buf, rec))
+	}
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	start := time.Now()
+	rp := &Replayer{Target: srv.URL, Speed: 4}
+	n, err := rp.Replay(context.Background(), r)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+	assert.Equal(t, []string{"/v0.4/traces go a", "/v0.5/traces python b"}, received)
+}
@@ -0,0 +1,181 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package replay
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/atomic"
+
+	"github.com/DataDog/datadog-agent/pkg/trace/log"
+)
+
+const (
+	fileTemplate = "trace-capture-%d"
+
+	// MaxDuration is the maximum duration of a single capture.
+	MaxDuration = 10 * time.Minute
+)
+
+// ErrCaptureOngoing is returned when trying to start a capture while another
+// one is already running.
+var ErrCaptureOngoing = errors.New("a capture is already in progress")
+
+// Status describes the state of a Writer.
+type Status struct {
+	// Ongoing reports whether a capture is in progress.
+	Ongoing bool `json:"ongoing"`
+	// Path holds the path of the current or last capture file.
+	Path string `json:"path,omitempty"`
+	// Records holds the number of requests written to the current or last capture file.
+	Records int64 `json:"records"`
+	// Until holds the time at which the current capture stops.
+	Until time.Time `json:"until,omitempty"`
+}
+
+// Writer captures requests into a file, for a bounded amount of time.
+// It is safe for concurrent use.
+type Writer struct {
+	ongoing *atomic.Bool
+	records *atomic.Int64
+
+	mu    sync.Mutex // guards below fields
+	file  *os.File
+	buf   *bufio.Writer
+	path  string
+	start time.Time
+	until time.Time
+	timer *time.Timer
+}
+
+// NewWriter returns a new, idle, Writer.
+func NewWriter() *Writer {
+	return &Writer{
+		ongoing: atomic.NewBool(false),
+		records: atomic.NewInt64(0),
+	}
+}
+
+// Start starts a new capture in a new file inside dir, which is created if missing.
+// The capture automatically stops after d, which is capped to MaxDuration. It returns
+// the path of the capture file.
+func (w *Writer) Start(dir string, d time.Duration) (string, error) {
+	if d <= 0 {
+		return "", fmt.Errorf("invalid capture duration: %s", d)
+	}
+	if d > MaxDuration {
+		d = MaxDuration
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.ongoing.Load() {
+		return "", ErrCaptureOngoing
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	now := time.Now()
+	path, err := filepath.Abs(filepath.Join(dir, fmt.Sprintf(fileTemplate, now.Unix())))
+	if err != nil {
+		return "", err
+	}
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_EXCL, 0640)
+	if err != nil {
+		return "", err
+	}
+	buf := bufio.NewWriter(f)
+	if _, err := buf.Write(fileHeader); err != nil {
+		f.Close()
+		return "", err
+	}
+	w.file = f
+	w.buf = buf
+	w.path = path
+	w.start = now
+	w.until = now.Add(d)
+	w.records.Store(0)
+	w.timer = time.AfterFunc(d, w.Stop)
+	w.ongoing.Store(true)
+	log.Infof("Started capturing trace payloads to %s for %s", path, d)
+	return path, nil
+}
+
+// Ongoing reports whether a capture is in progress. It is cheap to call
+// and is meant to be used on the hot path before doing any capture work.
+func (w *Writer) Ongoing() bool {
+	return w.ongoing.Load()
+}
+
+// Record writes a request to the capture file. It is a no-op if no capture is in progress.
+func (w *Writer) Record(path string, header http.Header, body []byte) {
+	if !w.ongoing.Load() {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.buf == nil {
+		// capture was stopped in the meantime
+		return
+	}
+	rec := &Record{
+		Offset: time.Since(w.start),
+		Path:   path,
+		Header: header,
+		Body:   body,
+	}
+	if err := writeRecord(w.buf, rec); err != nil {
+		log.Errorf("Error writing to capture file %s, stopping capture: %v", w.path, err)
+		w.stopLocked()
+		return
+	}
+	w.records.Inc()
+}
+
+// Stop stops the ongoing capture, if any, and closes the capture file.
+func (w *Writer) Stop() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopLocked()
+}
+
+func (w *Writer) stopLocked() {
+	if w.buf == nil {
+		return
+	}
+	w.ongoing.Store(false)
+	w.timer.Stop()
+	if err := w.buf.Flush(); err != nil {
+		log.Errorf("Error flushing capture file %s: %v", w.path, err)
+	}
+	if err := w.file.Close(); err != nil {
+		log.Errorf("Error closing capture file %s: %v", w.path, err)
+	}
+	w.buf = nil
+	w.file = nil
+	log.Infof("Stopped capturing trace payloads, %d requests written to %s", w.records.Load(), w.path)
+}
+
+// Status returns the current status of the writer.
+func (w *Writer) Status() Status {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	s := Status{
+		Ongoing: w.ongoing.Load(),
+		Path:    w.path,
+		Records: w.records.Load(),
+	}
+	if s.Ongoing {
+		s.Until = w.until
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sampler
+
+import (
+	"strconv"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+)
+
+// Names of the samplers which can make a sampling decision on a trace chunk.
+const (
+	// NameManual is used when the decision was made by the user through a manual sampling priority.
+	NameManual = "manual"
+	// NamePriority is used when the decision was made by the PrioritySampler.
+	NamePriority = "priority"
+	// NameProbabilistic is used when the decision was made by the OTLP probabilistic sampler.
+	NameProbabilistic = "probabilistic"
+	// NameErrors is used when the decision was made by the ErrorsSampler.
+	NameErrors = "errors"
+	// NameRare is used when the decision was made by the RareSampler.
+	NameRare = "rare"
+	// NameNoPriority is used when the decision was made by the NoPrioritySampler.
+	NameNoPriority = "no_priority"
+)
+
+const (
+	// decisionMakerKey is the tag holding the sampling mechanism which set the priority of a chunk.
+	decisionMakerKey = "_dd.p.dm"
+	// decisionMakerProbabilistic is the value of decisionMakerKey set by the OTLP probabilistic sampler.
+	decisionMakerProbabilistic = "-9"
+	// otlpRateKey is the chunk tag holding the rate applied by the OTLP probabilistic sampler.
+	otlpRateKey = "_dd.otlp_sr"
+)
+
+// PrioritySamplerName returns the name of the sampler behind the sampling priority of chunk.
+func PrioritySamplerName(chunk *pb.TraceChunk, root *pb.Span) string {
+	switch p, _ := GetSamplingPriority(chunk); p {
+	case PriorityUserKeep, PriorityUserDrop:
+		return NameManual
+	}
+	if root.Meta[decisionMakerKey] == decisionMakerProbabilistic {
+		return NameProbabilistic
+	}
+	return NamePriority
+}
+
+// AppliedRate returns the sampling rate which the sampler with the given name applied
+// to chunk, or 1 if it is unknown.
+func AppliedRate(name string, chunk *pb.TraceChunk, root *pb.Span) float64 {
+	var keys []string
+	switch name {
+	case NamePriority:
+		keys = []string{agentRateKey, ruleRateKey, deprecatedRateKey}
+	case NameErrors:
+		keys = []string{errorsRateKey}
+	case NameNoPriority:
+		keys = []string{noPriorityRateKey}
+	case NameProbabilistic:
+		if rate, err := strconv.ParseFloat(chunk.Tags[otlpRateKey], 64); err == nil {
+			return rate
+		}
+	}
+	for _, k := range keys {
+		if rate, ok := getMetric(root, k); ok {
+			return rate
+		}
+	}
+	return 1
+}
@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package sampler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+)
+
+func TestPrioritySamplerName(t *testing.T) {
+	for _, tt := range []struct {
+		priority      SamplingPriority
+		decisionMaker string
+		want          string
+	}{
+		{PriorityAutoKeep, "-1", NamePriority},
+		{PriorityAutoDrop, "", NamePriority},
+		{PriorityUserKeep, "-4", NameManual},
+		{PriorityUserDrop, "-3", NameManual},
+		{PriorityAutoKeep, "-9", NameProbabilistic},
+	} {
+		root := &pb.Span{Meta: map[string]string{"_dd.p.dm": tt.decisionMaker}}
+		chunk := &pb.TraceChunk{Priority: int32(tt.priority), Spans: []*pb.Span{root}}
+		assert.Equal(t, tt.want, PrioritySamplerName(chunk, root))
+	}
+}
+
+func TestAppliedRate(t *testing.T) {
+	root := &pb.Span{Metrics: map[string]float64{ruleRateKey: 0.2, errorsRateKey: 0.3}}
+	chunk := &pb.TraceChunk{Tags: map[string]string{otlpRateKey: "0.25"}, Spans: []*pb.Span{root}}
+	assert.Equal(t, 0.2, AppliedRate(NamePriority, chunk, root))
+	assert.Equal(t, 0.3, AppliedRate(NameErrors, chunk, root))
+	assert.Equal(t, 1.0, AppliedRate(NameNoPriority, chunk, root))
+	assert.Equal(t, 0.25, AppliedRate(NameProbabilistic, chunk, root))
+	assert.Equal(t, 1.0, AppliedRate(NameRare, chunk, root))
+	assert.Equal(t, 1.0, AppliedRate(NameManual, chunk, root))
+}
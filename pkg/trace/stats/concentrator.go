@@ -46,8 +46,9 @@ type Concentrator struct {
 	agentEnv               string
 	agentHostname          string
 	agentVersion           string
-	peerSvcAggregation     bool // flag to enable peer.service aggregation
-	computeStatsBySpanKind bool // flag to enable computation of stats through checking the span.kind field
+	peerSvcAggregation     bool         // flag to enable peer.service aggregation
+	computeStatsBySpanKind bool         // flag to enable computation of stats through checking the span.kind field
+	spanMetrics            *spanMetrics // emits distribution metrics for spans matching the configured rules, if any
 }
 
 // NewConcentrator initializes a new concentrator ready to be started
@@ -69,6 +70,7 @@ func NewConcentrator(conf *config.AgentConfig, out chan *pb.StatsPayload, now ti
 		agentVersion:           conf.AgentVersion,
 		peerSvcAggregation:     conf.PeerServiceAggregation,
 		computeStatsBySpanKind: conf.ComputeStatsBySpanKind,
+		spanMetrics:            newSpanMetrics(conf.SpanMetrics),
 	}
 	return &c
 }
@@ -180,6 +182,9 @@ func (c *Concentrator) addNow(pt *traceutil.ProcessedTrace, containerID string)
 		ContainerID: containerID,
 	}
 	for _, s := range pt.TraceChunk.Spans {
+		if c.spanMetrics != nil && !traceutil.IsPartialSnapshot(s) {
+			c.spanMetrics.handleSpan(s, env)
+		}
 		isTop := traceutil.HasTopLevel(s)
 		eligibleSpanKind := c.computeStatsBySpanKind && computeStatsForSpanKind(s)
 		if !(isTop || traceutil.IsMeasured(s) || eligibleSpanKind) {
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package stats
+
+import (
+	"time"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+	"github.com/DataDog/datadog-agent/pkg/trace/log"
+	"github.com/DataDog/datadog-agent/pkg/trace/metrics"
+)
+
+// spanMetrics emits the durations of spans matching a set of rules as distribution
+// metrics. Unlike the stats computed by the concentrator, these metrics are not
+// weighted by the sampling rate of the trace. As they're emitted by the
+// concentrator, the spans of the traces with client-computed stats never reach
+// the rules.
+type spanMetrics struct {
+	rules []*config.SpanMetricRule
+}
+
+// newSpanMetrics returns a spanMetrics for the valid rules among the given ones,
+// or nil if there are none.
+func newSpanMetrics(rules []*config.SpanMetricRule) *spanMetrics {
+	var valid []*config.SpanMetricRule
+	for _, r := range rules {
+		if r == nil || r.Name == "" {
+			log.Warn("Ignoring span metric rule without a name")
+			continue
+		}
+		valid = append(valid, r)
+	}
+	if len(valid) == 0 {
+		return nil
+	}
+	return &spanMetrics{rules: valid}
+}
+
+// matches reports whether the span s is matched by rule r.
+func matches(r *config.SpanMetricRule, s *pb.Span) bool {
+	if r.Service != "" && r.Service != s.Service {
+		return false
+	}
+	if r.Operation != "" && r.Operation != s.Name {
+		return false
+	}
+	for k, v := range r.MatchTags {
+		sv, ok := s.Meta[k]
+		if !ok || (v != "" && v != sv) {
+			return false
+		}
+	}
+	return true
+}
+
+// handleSpan emits a distribution metric with the duration of s, in seconds, for
+// each rule matching it.
+func (sm *spanMetrics) handleSpan(s *pb.Span, env string) {
+	for _, r := range sm.rules {
+		if !matches(r, s) {
+			continue
+		}
+		tags := make([]string, 0, 4+len(r.TagKeys))
+		tags = append(tags,
+			"env:"+env,
+			"service:"+s.Service,
+		)
+		if r.IncludeResource {
+			tags = append(tags, "resource:"+s.Resource)
+		}
+		if s.Error != 0 {
+			tags = append(tags, "error:true")
+		} else {
+			tags = append(tags, "error:false")
+		}
+		for _, k := range r.TagKeys {
+			if v, ok := s.Meta[k]; ok {
+				tags = append(tags, k+":"+v)
+			}
+		}
+		_ = metrics.Distribution(r.Name, time.Duration(s.Duration).Seconds(), tags, 1)
+	}
+}
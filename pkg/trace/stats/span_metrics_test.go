@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package stats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/trace"
+	"github.com/DataDog/datadog-agent/pkg/trace/config"
+	"github.com/DataDog/datadog-agent/pkg/trace/metrics"
+	"github.com/DataDog/datadog-agent/pkg/trace/teststatsd"
+)
+
+func TestNewSpanMetrics(t *testing.T) {
+	assert.Nil(t, newSpanMetrics(nil))
+	assert.Nil(t, newSpanMetrics([]*config.SpanMetricRule{{Service: "web"}}))
+	sm := newSpanMetrics([]*config.SpanMetricRule{{Service: "web"}, {Name: "web.latency"}})
+	require.NotNil(t, sm)
+	assert.Len(t, sm.rules, 1)
+}
+
+func TestSpanMetricMatches(t *testing.T) {
+	span := &pb.Span{
+		Service: "web",
+		Name:    "http.request",
+		Meta:    map[string]string{"http.method": "GET", "customer.tier": "gold"},
+	}
+	for _, tt := range []struct {
+		rule *config.SpanMetricRule
+		want bool
+	}{
+		{&config.SpanMetricRule{}, true},
+		{&config.SpanMetricRule{Service: "web"}, true},
+		{&config.SpanMetricRule{Service: "db"}, false},
+		{&config.SpanMetricRule{Service: "web", Operation: "http.request"}, true},
+		{&config.SpanMetricRule{Operation: "grpc.request"}, false},
+		{&config.SpanMetricRule{MatchTags: map[string]string{"http.method": "GET"}}, true},
+		{&config.SpanMetricRule{MatchTags: map[string]string{"http.method": "POST"}}, false},
+		{&config.SpanMetricRule{MatchTags: map[string]string{"customer.tier": ""}}, true},
+		{&config.SpanMetricRule{MatchTags: map[string]string{"customer.id": ""}}, false},
+	} {
+		assert.Equal(t, tt.want, matches(tt.rule, span), "%+v", tt.rule)
+	}
+}
+
+func TestConcentratorSpanMetrics(t *testing.T) {
+	statsclient := &teststatsd.Client{}
+	defer func(old metrics.StatsClient) { metrics.Client = old }(metrics.Client)
+	metrics.Client = statsclient
+
+	now := time.Now()
+	c := NewTestConcentrator(now)
+	c.spanMetrics = newSpanMetrics([]*config.SpanMetricRule{{
+		Name:      "checkout.latency",
+		Service:   "A1",
+		MatchTags: map[string]string{"customer.tier": ""},
+		TagKeys:   []string{"customer.tier", "missing"},
+	}, {
+		Name:            "checkout.latency.by_resource",
+		Service:         "A1",
+		MatchTags:       map[string]string{"customer.tier": "gold"},
+		IncludeResource: true,
+	}})
+	spans := []*pb.Span{
+		testSpan(now, 1, 0, 1500000000, 0, "A1", "GET /checkout", 0, map[string]string{"customer.tier": "gold"}),
+		testSpan(now, 2, 1, 500000000, 0, "A1", "GET /cart", 1, map[string]string{"customer.tier": "silver"}),
+		testSpan(now, 3, 1, 100000000, 0, "A1", "GET /home", 0, nil),
+		testSpan(now, 4, 1, 100000000, 0, "A2", "GET /checkout", 0, map[string]string{"customer.tier": "gold"}),
+	}
+	c.addNow(toProcessedTrace(spans, "prod", ""), "")
+
+	// the resource is only added by the rule including it
+	require.Len(t, statsclient.DistributionCalls, 3)
+	assert.Equal(t, teststatsd.MetricsArgs{
+		Name:  "checkout.latency",
+		Value: 1.5,
+		Tags:  []string{"env:prod", "service:A1", "error:false", "customer.tier:gold"},
+		Rate:  1,
+	}, statsclient.DistributionCalls[0])
+	assert.Equal(t, teststatsd.MetricsArgs{
+		Name:  "checkout.latency.by_resource",
+		Value: 1.5,
+		Tags:  []string{"env:prod", "service:A1", "resource:GET /checkout", "error:false"},
+		Rate:  1,
+	}, statsclient.DistributionCalls[1])
+	assert.Equal(t, teststatsd.MetricsArgs{
+		Name:  "checkout.latency",
+		Value: 0.5,
+		Tags:  []string{"env:prod", "service:A1", "error:true", "customer.tier:silver"},
+		Rate:  1,
+	}, statsclient.DistributionCalls[2])
+}
@@ -46,7 +46,7 @@ func (c *cgroupV2) parseCPUController(stats *CPUStats) {
 		reportError(err)
 	}
 
-	if err := parsePSI(c.fr, c.pathFor("cpu.pressure"), &stats.PSISome, nil); err != nil {
+	if err := parsePSI(c.fr, c.pathFor("cpu.pressure"), &stats.PSISome, &stats.PSIFull); err != nil {
 		reportError(err)
 	}
 }
@@ -0,0 +1,15 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build linux
+
+package cgroups
+
+// ParsePSIFile parses a Pressure Stall Information file outside of a cgroup, such as
+// the host-wide /proc/pressure/memory. Either stats may be nil if not needed.
+// Total is in microseconds, as reported by the kernel.
+func ParsePSIFile(path string, somePsi, fullPsi *PSIStats) error {
+	return parsePSI(defaultFileReader, path, somePsi, fullPsi)
+}
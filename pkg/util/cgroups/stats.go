@@ -78,6 +78,7 @@ type CPUStats struct {
 	SchedulerQuota  *uint64
 
 	PSISome PSIStats
+	PSIFull PSIStats // Kernel 5.13+ only, always 0 at system level
 }
 
 // PIDStats store stats about running threads and processes
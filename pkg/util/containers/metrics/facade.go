@@ -26,6 +26,9 @@ type CollectorMetadata = provider.CollectorMetadata
 // Provider interface allows to mock the metrics provider
 type Provider = provider.Provider
 
+// PSIStats stores Pressure Stall Information averages.
+type PSIStats = provider.PSIStats
+
 // ContainerMemStats stores memory statistics.
 type ContainerMemStats = provider.ContainerMemStats
 
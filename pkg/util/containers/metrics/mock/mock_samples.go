@@ -39,6 +39,7 @@ func GetFullSampleContainerEntry() ContainerEntry {
 				ThrottledPeriods: pointer.Ptr(0.0),
 				ThrottledTime:    pointer.Ptr(100.0),
 				PartialStallTime: pointer.Ptr(96000.0),
+				PSISome:          &metrics.PSIStats{Avg10: pointer.Ptr(1.5), Avg60: pointer.Ptr(1.0), Avg300: pointer.Ptr(0.5)},
 			},
 			Memory: &metrics.ContainerMemStats{
 				UsageTotal:       pointer.Ptr(42000.0),
@@ -52,6 +53,9 @@ func GetFullSampleContainerEntry() ContainerEntry {
 				Swap:             pointer.Ptr(0.0),
 				OOMEvents:        pointer.Ptr(10.0),
 				PartialStallTime: pointer.Ptr(97000.0),
+				FullStallTime:    pointer.Ptr(47000.0),
+				PSISome:          &metrics.PSIStats{Avg10: pointer.Ptr(2.5), Avg60: pointer.Ptr(2.0), Avg300: pointer.Ptr(1.5)},
+				PSIFull:          &metrics.PSIStats{Avg10: pointer.Ptr(0.5), Avg60: pointer.Ptr(0.25), Avg300: pointer.Ptr(0.1)},
 			},
 			IO: &metrics.ContainerIOStats{
 				Devices: map[string]metrics.DeviceIOStats{
@@ -73,6 +77,7 @@ func GetFullSampleContainerEntry() ContainerEntry {
 				ReadOperations:   pointer.Ptr(20.0),
 				WriteOperations:  pointer.Ptr(40.0),
 				PartialStallTime: pointer.Ptr(98000.0),
+				FullStallTime:    pointer.Ptr(48000.0),
 			},
 			PID: &metrics.ContainerPIDStats{
 				PIDs:        []int{4, 2},
@@ -11,6 +11,13 @@ import "time"
 // All fields are float64 as that's is required by the sender API.
 // Common units: nanoseconds, bytes
 
+// PSIStats stores Pressure Stall Information averages.
+type PSIStats struct {
+	Avg10  *float64 // Percentage 0-100
+	Avg60  *float64 // Percentage 0-100
+	Avg300 *float64 // Percentage 0-100
+}
+
 // ContainerMemStats stores memory statistics.
 type ContainerMemStats struct {
 	// Common fields
@@ -27,6 +34,9 @@ type ContainerMemStats struct {
 	Cache            *float64
 	OOMEvents        *float64 // Number of events where memory allocation failed
 	PartialStallTime *float64 // Correspond to PSI Some total
+	FullStallTime    *float64 // Correspond to PSI Full total
+	PSISome          *PSIStats
+	PSIFull          *PSIStats
 
 	// Windows-only fields
 	PrivateWorkingSet *float64
@@ -49,6 +59,9 @@ type ContainerCPUStats struct {
 	ThrottledPeriods *float64
 	ThrottledTime    *float64
 	PartialStallTime *float64 // Correspond to PSI Some total
+	FullStallTime    *float64 // Correspond to PSI Full total
+	PSISome          *PSIStats
+	PSIFull          *PSIStats
 }
 
 // DeviceIOStats stores Device IO stats.
@@ -70,6 +83,9 @@ type ContainerIOStats struct {
 
 	// Linux only
 	PartialStallTime *float64 // Correspond to PSI Some total
+	FullStallTime    *float64 // Correspond to PSI Full total
+	PSISome          *PSIStats
+	PSIFull          *PSIStats
 
 	Devices map[string]DeviceIOStats
 }
@@ -38,6 +38,9 @@ func buildIOStats(procPath string, cgs *cgroups.IOStats) *provider.ContainerIOSt
 	convertField(cgs.ReadOperations, &cs.ReadOperations)
 	convertField(cgs.WriteOperations, &cs.WriteOperations)
 	convertFieldAndUnit(cgs.PSISome.Total, &cs.PartialStallTime, float64(time.Microsecond))
+	convertFieldAndUnit(cgs.PSIFull.Total, &cs.FullStallTime, float64(time.Microsecond))
+	cs.PSISome = convertPSI(cgs.PSISome)
+	cs.PSIFull = convertPSI(cgs.PSIFull)
 
 	deviceMapping, err := GetDiskDeviceMapping(procPath)
 	if err != nil {
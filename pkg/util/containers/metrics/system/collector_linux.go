@@ -231,6 +231,9 @@ func buildMemoryStats(cgs *cgroups.MemoryStats) *provider.ContainerMemStats {
 	convertField(cgs.SwapLimit, &cs.SwapLimit)
 	convertField(cgs.OOMEvents, &cs.OOMEvents)
 	convertFieldAndUnit(cgs.PSISome.Total, &cs.PartialStallTime, float64(time.Microsecond))
+	convertFieldAndUnit(cgs.PSIFull.Total, &cs.FullStallTime, float64(time.Microsecond))
+	cs.PSISome = convertPSI(cgs.PSISome)
+	cs.PSIFull = convertPSI(cgs.PSIFull)
 
 	// Compute complex fields
 	if cgs.UsageTotal != nil && cgs.InactiveFile != nil {
@@ -255,6 +258,9 @@ func buildCPUStats(cgs *cgroups.CPUStats, parentCPUStatsRetriever func(parentCPU
 	convertField(cgs.ThrottledPeriods, &cs.ThrottledPeriods)
 	convertField(cgs.ThrottledTime, &cs.ThrottledTime)
 	convertFieldAndUnit(cgs.PSISome.Total, &cs.PartialStallTime, float64(time.Microsecond))
+	convertFieldAndUnit(cgs.PSIFull.Total, &cs.FullStallTime, float64(time.Microsecond))
+	cs.PSISome = convertPSI(cgs.PSISome)
+	cs.PSIFull = convertPSI(cgs.PSIFull)
 
 	// Compute complex fields
 	cs.Limit, cs.DefaultedLimit = computeCPULimitPct(cgs, parentCPUStatsRetriever)
@@ -7,7 +7,11 @@
 
 package system
 
-import "github.com/DataDog/datadog-agent/pkg/util/pointer"
+import (
+	"github.com/DataDog/datadog-agent/pkg/util/cgroups"
+	"github.com/DataDog/datadog-agent/pkg/util/containers/metrics/provider"
+	"github.com/DataDog/datadog-agent/pkg/util/pointer"
+)
 
 func convertField(s *uint64, t **float64) {
 	if s != nil {
@@ -15,6 +19,18 @@ func convertField(s *uint64, t **float64) {
 	}
 }
 
+// convertPSI returns the averages of s, or nil if none are available
+func convertPSI(s cgroups.PSIStats) *provider.PSIStats {
+	if s.Avg10 == nil && s.Avg60 == nil && s.Avg300 == nil {
+		return nil
+	}
+	return &provider.PSIStats{
+		Avg10:  s.Avg10,
+		Avg60:  s.Avg60,
+		Avg300: s.Avg300,
+	}
+}
+
 func convertFieldAndUnit(s *uint64, t **float64, multiplier float64) {
 	if s != nil {
 		*t = pointer.Ptr(float64(*s) * multiplier)